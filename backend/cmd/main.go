@@ -3,10 +3,11 @@ package main
 import (
 	"context"
 	"log"
-	"os"
+	"time"
 
 	"ellp-volunter-platform/backend/internal/config"
 	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/mailer"
 	"ellp-volunter-platform/backend/internal/middleware"
 	"ellp-volunter-platform/backend/internal/repositories"
 	"ellp-volunter-platform/backend/internal/routes"
@@ -16,50 +17,163 @@ import (
 )
 
 func main() {
+	// Carregar e validar a configuração; fora do desenvolvimento não há valores padrão
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Chaves de assinatura dos tokens: RS256/EdDSA em JWT_KEYS_DIR ou HS256 com JWT_SECRET
+	keyRing, err := config.NewKeyRingFromConfig(cfg.JWT)
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.SetKeyRing(keyRing)
+
 	// Conectar ao MongoDB
-	client := config.ConnectDB()
+	client := config.ConnectDB(cfg.MongoURI)
 	defer func() {
 		if err := client.Disconnect(context.Background()); err != nil {
 			log.Fatal(err)
 		}
 	}()
-	db := client.Database("ellp_db")
+	db := client.Database(cfg.MongoDatabase)
 
 	// Inicializar repositórios
 	userRepo := repositories.NewMongoUserRepository(db)
+	sessionRepo := repositories.NewMongoSessionRepository(db)
 	volunteerRepo := repositories.NewMongoVolunteerRepository(db)
+	volunteerRevisionRepo := repositories.NewMongoVolunteerRevisionRepository(db)
+	workshopRepo := repositories.NewMongoWorkshopRepository(db)
+	attendanceRepo := repositories.NewMongoAttendanceRepository(db)
+	certificateRepo := repositories.NewMongoCertificateRepository(db)
+	inviteRepo := repositories.NewMongoInviteRepository(db)
+	passwordResetRepo := repositories.NewMongoPasswordResetRepository(db)
+	settingsRepo := repositories.NewMongoSettingsRepository(db)
+	roleRepo := repositories.NewMongoRoleRepository(db)
+	auditRepo := repositories.NewMongoAuditRepository(db)
+
+	// Criar os papéis padrão (admin, member, coordinator e volunteer) que ainda não existem
+	if err := roleRepo.EnsureDefaults(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	// Criar os índices das listagens paginadas e das restrições de unicidade
+	indexed := []interface {
+		EnsureIndexes(ctx context.Context) error
+	}{userRepo, sessionRepo, volunteerRepo, volunteerRevisionRepo, certificateRepo, passwordResetRepo}
+	for _, repo := range indexed {
+		if err := repo.EnsureIndexes(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Envio de emails: smtp, log (padrão) ou file
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Modo de cadastro: disabled, invite ou open (padrão, sempre como "member")
+	registrationMode, err := services.ParseRegistrationMode(cfg.RegistrationMode)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Inicializar serviços
-	authService := services.NewAuthService(userRepo)
-	volunteerService := services.NewVolunteerService(volunteerRepo)
+	authService := services.NewAuthService(userRepo, sessionRepo, inviteRepo, settingsRepo, roleRepo, auditRepo, registrationMode)
+	userService := services.NewUserService(userRepo, sessionRepo, roleRepo, volunteerRepo, auditRepo)
+	roleService := services.NewRoleService(roleRepo, userRepo, auditRepo)
+	passwordResetService := services.NewPasswordResetService(passwordResetRepo, userRepo, sessionRepo, auditRepo, mail, cfg.PasswordResetURL)
+	volunteerService := services.NewVolunteerService(volunteerRepo, volunteerRevisionRepo, workshopRepo, attendanceRepo, userRepo, auditRepo)
+	workshopService := services.NewWorkshopService(workshopRepo, volunteerRepo, userRepo, auditRepo)
+	attendanceService := services.NewAttendanceService(attendanceRepo, volunteerRepo, workshopRepo, auditRepo)
+	certificateService := services.NewCertificateService(certificateRepo, volunteerRepo, workshopRepo, attendanceRepo, auditRepo, cfg.PublicAPIURL)
+	auditService := services.NewAuditService(auditRepo)
+	portalService := services.NewPortalService(userRepo, volunteerRepo, workshopRepo, attendanceRepo, auditRepo, certificateService)
 
 	// Inicializar handlers
 	authHandler := handlers.NewAuthHandler(authService)
+	userHandler := handlers.NewUserHandler(userService)
+	roleHandler := handlers.NewRoleHandler(roleService)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
 	volunteerHandler := handlers.NewVolunteerHandler(volunteerService)
+	workshopHandler := handlers.NewWorkshopHandler(workshopService)
+	attendanceHandler := handlers.NewAttendanceHandler(attendanceService)
+	certificateHandler := handlers.NewCertificateHandler(certificateService)
+	portalHandler := handlers.NewPortalHandler(portalService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	jwksHandler := handlers.NewJWKSHandler(keyRing)
 
 	// Configurar router
 	r := gin.Default()
 
+	// Sem proxies confiáveis, o IP do cliente é o da conexão e o X-Forwarded-For
+	// é ignorado; do contrário, cada valor forjado ganharia um novo limite de requisições
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal(err)
+	}
+
 	// Aplicar middlewares globais
 	r.Use(middleware.CORSMiddleware())
 	r.Use(middleware.LoggingMiddleware())
 
 	// Inicializar middleware de autenticação
-	authMiddleware := middleware.NewAuthMiddleware(userRepo)
+	authMiddleware := middleware.NewAuthMiddleware(userRepo, roleRepo)
 
 	// Rotas de auth
-	routes.SetupAuthRoutes(r, authHandler)
+	routes.SetupAuthRoutes(r, authHandler, authMiddleware)
+	routes.SetupPasswordResetRoutes(r, passwordResetHandler)
+	routes.SetupJWKSRoutes(r, jwksHandler)
+
+	// Rotas de administração de usuários
+	routes.SetupUserRoutes(r, userHandler, authMiddleware)
+	routes.SetupRoleRoutes(r, roleHandler, authMiddleware)
 
 	// Rotas de voluntários
 	routes.SetupVolunteerRoutes(r, volunteerHandler, authMiddleware)
 
+	// Rotas de oficinas
+	routes.SetupWorkshopRoutes(r, workshopHandler, authMiddleware)
+
+	// Rotas de presença
+	routes.SetupAttendanceRoutes(r, attendanceHandler, authMiddleware)
+
+	// Rotas de certificados
+	routes.SetupCertificateRoutes(r, certificateHandler, authMiddleware)
+
+	// Portal do voluntário
+	routes.SetupPortalRoutes(r, portalHandler, authMiddleware)
+
+	// Trilha de auditoria
+	routes.SetupAuditRoutes(r, auditHandler, authMiddleware)
+
+
+	// Exclusão definitiva dos voluntários que passaram do prazo na lixeira
+	if cfg.TrashRetentionDays > 0 {
+		go purgeVolunteerTrash(volunteerService, cfg.TrashRetentionDays)
+	}
 
 	// Iniciar servidor
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	log.Printf("Servidor rodando na porta %s (%s)", cfg.Port, cfg.Env)
+	r.Run(":" + cfg.Port)
+}
+
+// purgeVolunteerTrash exclui definitivamente, uma vez por dia, os voluntários
+// que estão na lixeira há mais de retentionDays dias
+func purgeVolunteerTrash(volunteerService services.VolunteerService, retentionDays int) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		deletedBefore := time.Now().AddDate(0, 0, -retentionDays)
+		purged, err := volunteerService.PurgeTrash(context.Background(), deletedBefore)
+		if err != nil {
+			log.Printf("erro ao limpar a lixeira de voluntários: %v", err)
+		} else if purged > 0 {
+			log.Printf("%d voluntário(s) excluído(s) definitivamente da lixeira", purged)
+		}
+
+		<-ticker.C
 	}
-	
-	log.Printf("Servidor rodando na porta %s", port)
-	r.Run(":" + port)
 }
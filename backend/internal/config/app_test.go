@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// envLookup simula as variáveis de ambiente nos testes
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+const strongSecret = "um-segredo-forte-com-mais-de-32-caracteres"
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name: "development uses local defaults",
+			env:  map[string]string{"APP_ENV": "development"},
+		},
+		{
+			name:    "production requires secret and mongo uri",
+			env:     map[string]string{},
+			wantErr: "MONGO_URI é obrigatório",
+		},
+		{
+			name:    "production rejects development secret",
+			env:     map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": devJWTSecret},
+			wantErr: "segredo de desenvolvimento",
+		},
+		{
+			name:    "production rejects short secret",
+			env:     map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": "curto"},
+			wantErr: "pelo menos 32 caracteres",
+		},
+		{
+			name: "production with strong secret",
+			env:  map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": strongSecret, "SMTP_HOST": "smtp.example.com"},
+		},
+		{
+			name: "production with key directory",
+			env:  map[string]string{"MONGO_URI": "mongodb://db", "JWT_KEYS_DIR": "/keys", "SMTP_HOST": "smtp.example.com"},
+		},
+		{
+			name:    "production requires smtp host",
+			env:     map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": strongSecret},
+			wantErr: "SMTP_HOST é obrigatório",
+		},
+		{
+			name:    "production rejects log mailer",
+			env:     map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": strongSecret, "MAIL_DRIVER": "log"},
+			wantErr: "só é permitido em desenvolvimento",
+		},
+		{
+			name:    "production rejects file mailer",
+			env:     map[string]string{"MONGO_URI": "mongodb://db", "JWT_SECRET": strongSecret, "MAIL_DRIVER": "File"},
+			wantErr: "só é permitido em desenvolvimento",
+		},
+		{
+			name:    "invalid trash retention",
+			env:     map[string]string{"APP_ENV": "development", "TRASH_RETENTION_DAYS": "trinta"},
+			wantErr: "TRASH_RETENTION_DAYS",
+		},
+		{
+			name:    "negative trash retention",
+			env:     map[string]string{"APP_ENV": "development", "TRASH_RETENTION_DAYS": "-1"},
+			wantErr: "não pode ser negativo",
+		},
+		{
+			name:    "invalid trusted proxy",
+			env:     map[string]string{"APP_ENV": "development", "TRUSTED_PROXIES": "10.0.0.1, proxy.local"},
+			wantErr: "TRUSTED_PROXIES inválido",
+		},
+		{
+			name:    "unknown environment",
+			env:     map[string]string{"APP_ENV": "staging", "MONGO_URI": "mongodb://db", "JWT_SECRET": strongSecret},
+			wantErr: "APP_ENV inválido",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := load(envLookup(tt.env))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load() error = %v", err)
+			}
+			wantDriver := "smtp"
+			if cfg.IsDevelopment() {
+				wantDriver = "log"
+			}
+			if cfg.Port != "8080" || cfg.MongoDatabase != "ellp_db" || cfg.Mail.Driver != wantDriver || cfg.TrashRetentionDays != 0 {
+				t.Errorf("load() defaults = %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestLoad_DevelopmentDefaults(t *testing.T) {
+	cfg, err := load(envLookup(map[string]string{"APP_ENV": "Development"}))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if !cfg.IsDevelopment() || cfg.MongoURI != devMongoURI || cfg.JWT.Secret != devJWTSecret {
+		t.Errorf("load() = %+v, want development defaults", cfg)
+	}
+}
+
+func TestLoad_ConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{
+		"port": "9090",
+		"mongo_uri": "mongodb://arquivo",
+		"jwt": {"secret": "`+strongSecret+`"},
+		"mail": {"driver": "smtp", "smtp_host": "smtp.example.com"}
+	}`), 0o600)
+
+	// As variáveis de ambiente têm precedência sobre o arquivo
+	cfg, err := load(envLookup(map[string]string{
+		"CONFIG_FILE": path,
+		"MONGO_URI":   "mongodb://ambiente",
+	}))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if cfg.Port != "9090" || cfg.MongoURI != "mongodb://ambiente" || cfg.JWT.Secret != strongSecret {
+		t.Errorf("load() = %+v", cfg)
+	}
+	if cfg.Mail.Driver != "smtp" || cfg.Mail.Host != "smtp.example.com" || cfg.Mail.Port != "587" {
+		t.Errorf("load() mail = %+v", cfg.Mail)
+	}
+
+	// Campos desconhecidos no arquivo são rejeitados
+	os.WriteFile(path, []byte(`{"jwt_secret": "x"}`), 0o600)
+	if _, err := load(envLookup(map[string]string{"CONFIG_FILE": path})); err == nil {
+		t.Error("load() should reject unknown fields in the config file")
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	cfg, err := load(envLookup(map[string]string{"APP_ENV": "development"}))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if cfg.TrustedProxies != nil {
+		t.Errorf("load() trusted proxies = %v, want none by default", cfg.TrustedProxies)
+	}
+
+	cfg, err = load(envLookup(map[string]string{"APP_ENV": "development", "TRUSTED_PROXIES": "10.0.0.0/8, 172.16.0.1"}))
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[0] != "10.0.0.0/8" || cfg.TrustedProxies[1] != "172.16.0.1" {
+		t.Errorf("load() trusted proxies = %v", cfg.TrustedProxies)
+	}
+}
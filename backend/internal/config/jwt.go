@@ -2,65 +2,108 @@ package config
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	JWTSecret              = []byte(getJWTSecret())
 	TokenExpiration        = 24 * time.Hour
 	RefreshTokenExpiration = 7 * 24 * time.Hour
+	MFATokenExpiration     = 5 * time.Minute
 )
 
+// Tipos de token emitidos pela API (claim "typ")
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	TokenTypeInvite  = "invite"
+	TokenTypeMFA     = "mfa"
+)
+
+// Audiências dos tokens: access tokens valem para a API e refresh tokens
+// apenas para o endpoint de renovação
+const (
+	AccessTokenAudience  = "ellp-api"
+	RefreshTokenAudience = "ellp-auth-refresh"
+	InviteTokenAudience  = "ellp-auth-invite"
+	MFATokenAudience     = "ellp-auth-mfa"
+)
+
+// ErrWrongTokenType é retornado quando o token é válido mas de outro tipo
+var ErrWrongTokenType = errors.New("tipo de token inválido")
+
 // informações contidas no token JWT
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
-	Role   string `json:"role"`
+	Role   string `json:"role,omitempty"`
+	Type   string `json:"typ"`
 	jwt.RegisteredClaims
 }
 
-// getJWTSecret obtém o segredo JWT da variável de ambiente
-func getJWTSecret() string {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "tua-mae-aquela-ursa"
-	}
-	return secret
-}
-
 // GenerateToken gera um token JWT para um usuário
 func GenerateToken(userID, email, role string) (string, error) {
 	claims := Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
+		Type:   TokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{AccessTokenAudience},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(JWTSecret)
+	return Keys().Sign(claims)
+}
+
+// ValidateToken valida a assinatura e a expiração de um token JWT e retorna as
+// claims, sem verificar o tipo. Para autorizar requisições use ValidateAccessToken.
+func ValidateToken(tokenString string) (*Claims, error) {
+	return parseToken(tokenString)
+}
+
+// ValidateAccessToken valida um access token; refresh tokens são rejeitados
+func ValidateAccessToken(tokenString string) (*Claims, error) {
+	return validateTyped(tokenString, TokenTypeAccess, AccessTokenAudience)
+}
+
+// ValidateRefreshToken valida um refresh token; access tokens são rejeitados
+func ValidateRefreshToken(tokenString string) (*Claims, error) {
+	return validateTyped(tokenString, TokenTypeRefresh, RefreshTokenAudience)
+}
+
+// ValidateInviteToken valida o token de um convite de cadastro
+func ValidateInviteToken(tokenString string) (*Claims, error) {
+	return validateTyped(tokenString, TokenTypeInvite, InviteTokenAudience)
+}
+
+// ValidateMFAToken valida o token de desafio da segunda etapa do login
+func ValidateMFAToken(tokenString string) (*Claims, error) {
+	return validateTyped(tokenString, TokenTypeMFA, MFATokenAudience)
+}
+
+// validateTyped valida o token exigindo o tipo e a audiência informados
+func validateTyped(tokenString, tokenType, audience string) (*Claims, error) {
+	claims, err := parseToken(tokenString, jwt.WithAudience(audience))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if claims.Type != tokenType {
+		return nil, ErrWrongTokenType
 	}
 
-	return tokenString, nil
+	return claims, nil
 }
 
-// ValidateToken valida um token JWT e retorna as claims
-func ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("método de assinatura inválido")
-		}
-		return JWTSecret, nil
-	})
+// parseToken verifica a assinatura do token com a chave indicada pelo "kid" e
+// decodifica as claims
+func parseToken(tokenString string, opts ...jwt.ParserOption) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, Keys().keyFunc, opts...)
 
 	if err != nil {
 		return nil, err
@@ -73,34 +116,58 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	return nil, errors.New("token inválido")
 }
 
-// RefreshToken gera um novo token com base no token atual
-func RefreshToken(tokenString string) (string, error) {
-	claims, err := ValidateToken(tokenString)
-	if err != nil {
-		return "", err
+// GenerateRefreshToken gera um token de refresh com expiração mais longa.
+// O tokenID (JTI) identifica a sessão gravada no servidor.
+func GenerateRefreshToken(userID, email, tokenID string) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Email:  email,
+		Type:   TokenTypeRefresh,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Audience:  jwt.ClaimStrings{RefreshTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
 	}
 
-	return GenerateToken(claims.UserID, claims.Email, claims.Role)
+	return Keys().Sign(claims)
 }
 
-// GenerateRefreshToken gera um token de refresh com expiração mais longa
-func GenerateRefreshToken(userID, email string) (string, error) {
+// GenerateInviteToken gera o token assinado de um convite de cadastro. O papel
+// e o email permitido vão no token; o tokenID (JTI) identifica o convite gravado.
+func GenerateInviteToken(tokenID, email, role string, expiresAt time.Time) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   "refresh",
+		Email: email,
+		Role:  role,
+		Type:  TokenTypeInvite,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenExpiration)),
+			ID:        tokenID,
+			Audience:  jwt.ClaimStrings{InviteTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(JWTSecret)
-	if err != nil {
-		return "", err
+	return Keys().Sign(claims)
+}
+
+// GenerateMFAToken gera o token de desafio entregue no login quando falta o
+// segundo fator. Ele só vale para concluir o login e expira em poucos minutos.
+func GenerateMFAToken(userID, email string) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		Email:  email,
+		Type:   TokenTypeMFA,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{MFATokenAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(MFATokenExpiration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+		},
 	}
 
-	return tokenString, nil
+	return Keys().Sign(claims)
 }
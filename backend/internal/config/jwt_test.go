@@ -7,6 +7,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// testSecret é o segredo HS256 usado para montar tokens nos testes
+var testSecret = []byte("segredo-de-teste-com-32-caracteres")
+
 func TestGenerateToken(t *testing.T) {
 	userID := "507f1f77bcf86cd799439011"
 	email := "test@example.com"
@@ -41,6 +44,7 @@ func TestGenerateToken(t *testing.T) {
 }
 
 func TestValidateToken(t *testing.T) {
+	useKeyRing(t, NewSecretKeyRing(testSecret))
 	userID := "507f1f77bcf86cd799439011"
 	email := "test@example.com"
 	role := "admin"
@@ -59,7 +63,7 @@ func TestValidateToken(t *testing.T) {
 		},
 	}
 	expiredToken := jwt.NewWithClaims(jwt.SigningMethodHS256, expiredClaims)
-	expiredTokenString, _ := expiredToken.SignedString(JWTSecret)
+	expiredTokenString, _ := expiredToken.SignedString(testSecret)
 
 	// Token com assinatura inválida
 	invalidToken := validToken[:len(validToken)-5] + "xxxxx"
@@ -116,51 +120,67 @@ func TestValidateToken(t *testing.T) {
 	}
 }
 
-func TestRefreshToken(t *testing.T) {
+func TestTokenTypes(t *testing.T) {
+	useKeyRing(t, NewSecretKeyRing(testSecret))
 	userID := "507f1f77bcf86cd799439011"
 	email := "test@example.com"
-	role := "member"
 
-	// Gera um token inicial
-	originalToken, err := GenerateToken(userID, email, role)
+	accessToken, err := GenerateToken(userID, email, "admin")
 	if err != nil {
 		t.Fatalf("GenerateToken() error = %v", err)
 	}
 
-	// Renova o token
-	newToken, err := RefreshToken(originalToken)
+	refreshToken, err := GenerateRefreshToken(userID, email, "token-id")
 	if err != nil {
-		t.Fatalf("RefreshToken() error = %v", err)
+		t.Fatalf("GenerateRefreshToken() error = %v", err)
 	}
 
-	if newToken == "" {
-		t.Error("RefreshToken() returned empty token")
+	if _, err := ValidateAccessToken(accessToken); err != nil {
+		t.Errorf("ValidateAccessToken(access) error = %v", err)
 	}
 
-	// Valida o novo token
-	claims, err := ValidateToken(newToken)
+	claims, err := ValidateRefreshToken(refreshToken)
 	if err != nil {
-		t.Fatalf("ValidateToken() error = %v", err)
+		t.Fatalf("ValidateRefreshToken(refresh) error = %v", err)
 	}
-
-	if claims.UserID != userID {
-		t.Errorf("UserID = %v, want %v", claims.UserID, userID)
+	if claims.ID != "token-id" || claims.Role != "" {
+		t.Errorf("refresh claims = %+v, want JTI and no role", claims)
 	}
 
-	if claims.Email != email {
-		t.Errorf("Email = %v, want %v", claims.Email, email)
+	// Refresh tokens não podem ser usados como access tokens e vice-versa
+	if _, err := ValidateAccessToken(refreshToken); err == nil {
+		t.Error("ValidateAccessToken() should reject a refresh token")
 	}
-
-	if claims.Role != role {
-		t.Errorf("Role = %v, want %v", claims.Role, role)
+	if _, err := ValidateRefreshToken(accessToken); err == nil {
+		t.Error("ValidateRefreshToken() should reject an access token")
 	}
-}
 
-func TestRefreshTokenWithInvalidToken(t *testing.T) {
-	invalidToken := "invalid.token.string"
+	// Tokens antigos, sem tipo nem audiência, também são rejeitados
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID: userID,
+		Email:  email,
+		Role:   "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	legacyString, _ := legacy.SignedString(testSecret)
+	if _, err := ValidateAccessToken(legacyString); err == nil {
+		t.Error("ValidateAccessToken() should reject a token without type")
+	}
 
-	_, err := RefreshToken(invalidToken)
-	if err == nil {
-		t.Error("RefreshToken() should return error for invalid token")
+	// O desafio de MFA só serve para concluir o login
+	mfaToken, err := GenerateMFAToken(userID, email)
+	if err != nil {
+		t.Fatalf("GenerateMFAToken() error = %v", err)
+	}
+	if claims, err := ValidateMFAToken(mfaToken); err != nil || claims.UserID != userID {
+		t.Errorf("ValidateMFAToken() = %+v, %v", claims, err)
+	}
+	if _, err := ValidateAccessToken(mfaToken); err == nil {
+		t.Error("ValidateAccessToken() should reject an MFA challenge token")
+	}
+	if _, err := ValidateMFAToken(accessToken); err == nil {
+		t.Error("ValidateMFAToken() should reject an access token")
 	}
 }
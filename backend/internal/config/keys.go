@@ -0,0 +1,388 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Algoritmos de assinatura suportados
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// LegacyKeyID identifica a chave HS256 do segredo compartilhado (JWT_SECRET).
+// Tokens sem "kid", emitidos antes do chaveiro, são validados com ela.
+const LegacyKeyID = "legacy-hs256"
+
+// minRSAKeyBits é o tamanho mínimo aceito para chaves RSA
+const minRSAKeyBits = 2048
+
+var (
+	// ErrKeyNotFound é retornado quando o "kid" do token não está no chaveiro
+	ErrKeyNotFound = errors.New("chave de assinatura desconhecida")
+	// ErrNoSigningKey é retornado quando o chaveiro não tem chave para assinar
+	ErrNoSigningKey = errors.New("nenhuma chave de assinatura configurada")
+)
+
+// SigningKey representa uma chave do chaveiro. Chaves sem a parte privada
+// servem apenas para validar tokens já emitidos (chaves aposentadas).
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	private   interface{} // []byte, *rsa.PrivateKey ou ed25519.PrivateKey
+	public    interface{} // []byte, *rsa.PublicKey ou ed25519.PublicKey
+}
+
+// CanSign informa se a chave tem a parte privada
+func (k *SigningKey) CanSign() bool {
+	return k.private != nil
+}
+
+// method retorna o método de assinatura do jwt correspondente ao algoritmo
+func (k *SigningKey) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// NewHMACKey cria uma chave HS256 a partir de um segredo compartilhado
+func NewHMACKey(id string, secret []byte) *SigningKey {
+	return &SigningKey{ID: id, Algorithm: AlgorithmHS256, private: secret, public: secret}
+}
+
+// ParsePrivateKeyPEM lê uma chave privada RSA (RS256) ou Ed25519 (EdDSA) em PEM
+func ParsePrivateKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("chave %s: PEM inválido", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("chave %s: tipo de PEM não suportado: %s", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chave %s: %w", id, err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("chave %s: RSA precisa de pelo menos %d bits", id, minRSAKeyBits)
+		}
+		return &SigningKey{ID: id, Algorithm: AlgorithmRS256, private: key, public: &key.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: AlgorithmEdDSA, private: key, public: key.Public()}, nil
+	default:
+		return nil, fmt.Errorf("chave %s: algoritmo não suportado", id)
+	}
+}
+
+// ParsePublicKeyPEM lê uma chave pública RSA ou Ed25519 em PEM, usada apenas
+// para validar tokens assinados por uma chave aposentada
+func ParsePublicKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("chave %s: PEM de chave pública inválido", id)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("chave %s: %w", id, err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("chave %s: RSA precisa de pelo menos %d bits", id, minRSAKeyBits)
+		}
+		return &SigningKey{ID: id, Algorithm: AlgorithmRS256, public: key}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{ID: id, Algorithm: AlgorithmEdDSA, public: key}, nil
+	default:
+		return nil, fmt.Errorf("chave %s: algoritmo não suportado", id)
+	}
+}
+
+// KeyRing guarda as chaves aceitas na validação de tokens, identificadas pelo
+// "kid", e a chave atual usada para assinar os novos tokens. Para rotacionar,
+// adicione a nova chave e passe a assinar com ela; a anterior continua no
+// chaveiro até que os tokens emitidos com ela expirem.
+type KeyRing struct {
+	mu      sync.RWMutex
+	keys    map[string]*SigningKey
+	current string
+}
+
+// NewKeyRing cria um chaveiro vazio
+func NewKeyRing() *KeyRing {
+	return &KeyRing{keys: make(map[string]*SigningKey)}
+}
+
+// Add inclui uma chave no chaveiro
+func (r *KeyRing) Add(key *SigningKey) error {
+	if key.ID == "" {
+		return errors.New("chave sem identificador (kid)")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.keys[key.ID]; exists {
+		return fmt.Errorf("chave %s duplicada", key.ID)
+	}
+	r.keys[key.ID] = key
+	return nil
+}
+
+// Remove retira uma chave do chaveiro; tokens assinados com ela deixam de valer
+func (r *KeyRing) Remove(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id == r.current {
+		return fmt.Errorf("a chave %s está em uso para assinatura", id)
+	}
+	if _, exists := r.keys[id]; !exists {
+		return ErrKeyNotFound
+	}
+	delete(r.keys, id)
+	return nil
+}
+
+// SetCurrent define a chave usada para assinar os novos tokens
+func (r *KeyRing) SetCurrent(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, exists := r.keys[id]
+	if !exists {
+		return ErrKeyNotFound
+	}
+	if !key.CanSign() {
+		return fmt.Errorf("a chave %s não tem a parte privada", id)
+	}
+	r.current = id
+	return nil
+}
+
+// Current retorna o identificador da chave de assinatura atual
+func (r *KeyRing) Current() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Sign assina as claims com a chave atual, informando o "kid" no cabeçalho
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	r.mu.RLock()
+	key, exists := r.keys[r.current]
+	r.mu.RUnlock()
+
+	if !exists {
+		return "", ErrNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.private)
+}
+
+// keyFunc escolhe a chave de validação pelo "kid" do token e exige que o
+// algoritmo do token seja o da chave, evitando a troca de algoritmo
+func (r *KeyRing) keyFunc(token *jwt.Token) (interface{}, error) {
+	id, _ := token.Header["kid"].(string)
+	if id == "" {
+		id = LegacyKeyID
+	}
+
+	r.mu.RLock()
+	key, exists := r.keys[id]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrKeyNotFound
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, errors.New("método de assinatura inválido")
+	}
+
+	return key.public, nil
+}
+
+// JWK representa uma chave pública no formato JSON Web Key (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKSet representa o documento publicado em /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS retorna as chaves públicas do chaveiro. Chaves HS256 são segredos
+// compartilhados e nunca são publicadas.
+func (r *KeyRing) JWKS() JWKSet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range r.keys {
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+// LoadKeyRing monta o chaveiro a partir de um diretório de chaves em PEM.
+// Cada arquivo "<kid>.pem" contém uma chave privada e cada "<kid>.pub.pem" a
+// chave pública de uma chave aposentada. A chave de assinatura é a indicada em
+// signingKeyID ou, se vazio, a única chave privada do diretório. Se secret não
+// for vazio, a chave HS256 legada continua aceita na validação.
+func LoadKeyRing(dir, signingKeyID string, secret []byte) (*KeyRing, error) {
+	ring := NewKeyRing()
+
+	if len(secret) > 0 {
+		if err := ring.Add(NewHMACKey(LegacyKeyID, secret)); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	var private []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		name := filepath.Base(file)
+		var key *SigningKey
+		if strings.HasSuffix(name, ".pub.pem") {
+			key, err = ParsePublicKeyPEM(strings.TrimSuffix(name, ".pub.pem"), data)
+		} else {
+			key, err = ParsePrivateKeyPEM(strings.TrimSuffix(name, ".pem"), data)
+			if key != nil {
+				private = append(private, key.ID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ring.Add(key); err != nil {
+			return nil, err
+		}
+	}
+
+	if signingKeyID == "" {
+		if len(private) != 1 {
+			return nil, fmt.Errorf("JWT_SIGNING_KEY_ID é obrigatório com %d chaves privadas em %s", len(private), dir)
+		}
+		signingKeyID = private[0]
+	}
+
+	if err := ring.SetCurrent(signingKeyID); err != nil {
+		return nil, fmt.Errorf("chave de assinatura %s: %w", signingKeyID, err)
+	}
+
+	return ring, nil
+}
+
+// NewKeyRingFromConfig monta o chaveiro da configuração da aplicação. Com
+// KeysDir, os tokens são assinados com as chaves RS256/EdDSA do diretório; sem
+// ele, em HS256 com o segredo configurado.
+func NewKeyRingFromConfig(cfg JWTConfig) (*KeyRing, error) {
+	if cfg.KeysDir == "" {
+		if cfg.Secret == "" {
+			return nil, ErrNoSigningKey
+		}
+		return NewSecretKeyRing([]byte(cfg.Secret)), nil
+	}
+
+	return LoadKeyRing(cfg.KeysDir, cfg.SigningKeyID, []byte(cfg.Secret))
+}
+
+// NewSecretKeyRing cria um chaveiro que assina com a chave HS256 legada
+func NewSecretKeyRing(secret []byte) *KeyRing {
+	ring := NewKeyRing()
+	ring.keys[LegacyKeyID] = NewHMACKey(LegacyKeyID, secret)
+	ring.current = LegacyKeyID
+	return ring
+}
+
+// randomSecret gera um segredo descartável para o chaveiro padrão; tokens
+// assinados com ele deixam de valer quando o processo termina
+func randomSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	return secret
+}
+
+// keyRing é o chaveiro usado para emitir e validar os tokens da API. Até que
+// SetKeyRing seja chamado, ele usa um segredo aleatório e nunca um valor fixo.
+var (
+	keyRingMu sync.RWMutex
+	keyRing   = NewSecretKeyRing(randomSecret())
+)
+
+// SetKeyRing substitui o chaveiro usado pela API
+func SetKeyRing(ring *KeyRing) {
+	keyRingMu.Lock()
+	defer keyRingMu.Unlock()
+	keyRing = ring
+}
+
+// Keys retorna o chaveiro usado pela API
+func Keys() *KeyRing {
+	keyRingMu.RLock()
+	defer keyRingMu.RUnlock()
+	return keyRing
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// useKeyRing troca o chaveiro da API durante o teste
+func useKeyRing(t *testing.T, ring *KeyRing) {
+	previous := Keys()
+	SetKeyRing(ring)
+	t.Cleanup(func() { SetKeyRing(previous) })
+}
+
+func newEd25519Key(t *testing.T, id string) (*SigningKey, []byte) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	key, err := ParsePrivateKeyPEM(id, data)
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyPEM() error = %v", err)
+	}
+	return key, data
+}
+
+func newRSAKey(t *testing.T, id string) (*SigningKey, []byte) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+
+	key, err := ParsePrivateKeyPEM(id, data)
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyPEM() error = %v", err)
+	}
+	return key, data
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	ring := NewKeyRing()
+	oldKey, _ := newRSAKey(t, "2025-01")
+	newKey, _ := newEd25519Key(t, "2025-02")
+	if oldKey.Algorithm != AlgorithmRS256 || newKey.Algorithm != AlgorithmEdDSA {
+		t.Fatalf("algorithms = %s, %s", oldKey.Algorithm, newKey.Algorithm)
+	}
+
+	if err := ring.Add(oldKey); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := ring.SetCurrent("2025-01"); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+	useKeyRing(t, ring)
+
+	oldToken, err := GenerateToken("user-1", "user@example.com", "member")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(oldToken, &Claims{})
+	if parsed.Header["kid"] != "2025-01" || parsed.Method.Alg() != AlgorithmRS256 {
+		t.Errorf("header = %v, want kid 2025-01 and RS256", parsed.Header)
+	}
+
+	// Após a rotação, tokens da chave anterior continuam válidos
+	if err := ring.Add(newKey); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := ring.SetCurrent("2025-02"); err != nil {
+		t.Fatalf("SetCurrent() error = %v", err)
+	}
+
+	newToken, err := GenerateToken("user-1", "user@example.com", "member")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	for _, token := range []string{oldToken, newToken} {
+		if _, err := ValidateAccessToken(token); err != nil {
+			t.Errorf("ValidateAccessToken() error = %v", err)
+		}
+	}
+
+	// A chave atual não pode ser removida; a anterior sim, invalidando seus tokens
+	if err := ring.Remove("2025-02"); err == nil {
+		t.Error("Remove() should refuse the current signing key")
+	}
+	if err := ring.Remove("2025-01"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := ValidateAccessToken(oldToken); err == nil {
+		t.Error("ValidateAccessToken() should reject a token signed by a removed key")
+	}
+	if _, err := ValidateAccessToken(newToken); err != nil {
+		t.Errorf("ValidateAccessToken() error = %v", err)
+	}
+}
+
+func TestKeyRing_RejectsAlgorithmMismatch(t *testing.T) {
+	ring := NewKeyRing()
+	key, _ := newEd25519Key(t, "ed")
+	ring.Add(key)
+	ring.Add(NewHMACKey(LegacyKeyID, []byte("segredo")))
+	ring.SetCurrent("ed")
+	useKeyRing(t, ring)
+
+	claims := Claims{
+		UserID: "user-1",
+		Type:   TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience: jwt.ClaimStrings{AccessTokenAudience},
+		},
+	}
+
+	// Token HS256 que aponta para a chave EdDSA, assinado com a chave pública
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	forged.Header["kid"] = "ed"
+	forgedString, _ := forged.SignedString([]byte(key.public.(ed25519.PublicKey)))
+	if _, err := ValidateAccessToken(forgedString); err == nil {
+		t.Error("ValidateAccessToken() should reject a token whose algorithm differs from the key")
+	}
+
+	// Token com kid desconhecido
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	unknown.Header["kid"] = "outra"
+	unknownString, _ := unknown.SignedString([]byte("segredo"))
+	if _, err := ValidateAccessToken(unknownString); err == nil {
+		t.Error("ValidateAccessToken() should reject an unknown kid")
+	}
+
+	// Tokens sem kid usam a chave HS256 legada
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	legacyString, _ := legacy.SignedString([]byte("segredo"))
+	if _, err := ValidateAccessToken(legacyString); err != nil {
+		t.Errorf("ValidateAccessToken() legacy token error = %v", err)
+	}
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	ring := NewKeyRing()
+	rsaKey, _ := newRSAKey(t, "rsa")
+	edKey, _ := newEd25519Key(t, "ed")
+	ring.Add(rsaKey)
+	ring.Add(edKey)
+	ring.Add(NewHMACKey(LegacyKeyID, []byte("segredo")))
+
+	set := ring.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("JWKS() = %d keys, want 2 (HS256 must not be published)", len(set.Keys))
+	}
+
+	ed, rsaJWK := set.Keys[0], set.Keys[1]
+	if ed.KeyID != "ed" || ed.KeyType != "OKP" || ed.Curve != "Ed25519" || ed.Algorithm != AlgorithmEdDSA || ed.X == "" {
+		t.Errorf("Ed25519 JWK = %+v", ed)
+	}
+	if rsaJWK.KeyID != "rsa" || rsaJWK.KeyType != "RSA" || rsaJWK.Algorithm != AlgorithmRS256 || rsaJWK.N == "" || rsaJWK.E != "AQAB" {
+		t.Errorf("RSA JWK = %+v", rsaJWK)
+	}
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+
+	current, currentPEM := newEd25519Key(t, "2025-02")
+	retired, _ := newRSAKey(t, "2025-01")
+	der, _ := x509.MarshalPKIXPublicKey(retired.public)
+	retiredPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	os.WriteFile(filepath.Join(dir, "2025-02.pem"), currentPEM, 0o600)
+	os.WriteFile(filepath.Join(dir, "2025-01.pub.pem"), retiredPEM, 0o600)
+
+	// Com uma única chave privada, ela é a chave de assinatura
+	ring, err := LoadKeyRing(dir, "", []byte("segredo"))
+	if err != nil {
+		t.Fatalf("LoadKeyRing() error = %v", err)
+	}
+	if ring.Current() != current.ID {
+		t.Errorf("Current() = %q, want %q", ring.Current(), current.ID)
+	}
+	if got := len(ring.JWKS().Keys); got != 2 {
+		t.Errorf("JWKS() = %d keys, want 2", got)
+	}
+
+	// Chaves aposentadas não podem assinar
+	if _, err := LoadKeyRing(dir, "2025-01", nil); err == nil {
+		t.Error("LoadKeyRing() should reject a public-only signing key")
+	}
+
+	// Com mais de uma chave privada, a chave de assinatura é obrigatória
+	_, otherPEM := newEd25519Key(t, "2025-03")
+	os.WriteFile(filepath.Join(dir, "2025-03.pem"), otherPEM, 0o600)
+	if _, err := LoadKeyRing(dir, "", nil); err == nil {
+		t.Error("LoadKeyRing() should require the signing key id")
+	}
+	if ring, err := LoadKeyRing(dir, "2025-03", nil); err != nil || ring.Current() != "2025-03" {
+		t.Errorf("LoadKeyRing() = %v, %v", ring, err)
+	}
+}
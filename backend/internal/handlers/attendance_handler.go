@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttendanceHandler gerencia as requisições de presença e horas
+type AttendanceHandler struct {
+	attendanceService services.AttendanceService
+}
+
+// NewAttendanceHandler cria uma nova instância do handler
+func NewAttendanceHandler(attendanceService services.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{
+		attendanceService: attendanceService,
+	}
+}
+
+// attendanceErrorStatus mapeia os erros do serviço de presença para status HTTP
+func attendanceErrorStatus(err error) int {
+	switch err {
+	case repositories.ErrAttendanceNotFound, repositories.ErrVolunteerNotFound, repositories.ErrWorkshopNotFound:
+		return http.StatusNotFound
+	case services.ErrVolunteerNotEnrolled, services.ErrAlreadyCheckedIn, services.ErrNotCheckedIn:
+		return http.StatusConflict
+	case services.ErrForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// Record godoc
+// @Summary Lançar presença
+// @Description Registra as horas trabalhadas por um voluntário em uma sessão de oficina
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param attendance body models.RecordAttendanceRequest true "Dados da presença"
+// @Success 201 {object} models.AttendanceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/attendance [post]
+func (h *AttendanceHandler) Record(c *gin.Context) {
+	var req models.RecordAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := h.attendanceService.Record(c.Request.Context(), req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(attendanceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attendance)
+}
+
+// CheckIn godoc
+// @Summary Registrar check-in
+// @Description Registra a entrada de um voluntário em uma sessão de oficina
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param request body models.CheckInRequest true "Voluntário e oficina"
+// @Success 201 {object} models.AttendanceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/attendance/check-in [post]
+func (h *AttendanceHandler) CheckIn(c *gin.Context) {
+	var req models.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := h.attendanceService.CheckIn(c.Request.Context(), req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(attendanceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attendance)
+}
+
+// CheckOut godoc
+// @Summary Registrar check-out
+// @Description Registra a saída de um voluntário e calcula as horas trabalhadas
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do registro de presença"
+// @Param request body models.CheckOutRequest false "Horário de saída"
+// @Success 200 {object} models.AttendanceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/attendance/{id}/check-out [post]
+func (h *AttendanceHandler) CheckOut(c *gin.Context) {
+	id := c.Param("id")
+
+	// O corpo é opcional: sem horário informado, usa o horário atual
+	var req models.CheckOutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	attendance, err := h.attendanceService.CheckOut(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(attendanceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// GetByID godoc
+// @Summary Buscar registro de presença por ID
+// @Description Busca um registro de presença específico por ID
+// @Tags attendance
+// @Produce json
+// @Param id path string true "ID do registro de presença"
+// @Success 200 {object} models.AttendanceResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/attendance/{id} [get]
+func (h *AttendanceHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	attendance, err := h.attendanceService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// GetAll godoc
+// @Summary Listar registros de presença
+// @Description Lista registros de presença com filtros opcionais
+// @Tags attendance
+// @Produce json
+// @Param volunteer_id query string false "Filtrar por voluntário"
+// @Param workshop_id query string false "Filtrar por oficina"
+// @Param date_from query string false "Data inicial (RFC3339)"
+// @Param date_to query string false "Data final (RFC3339)"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.AttendanceResponse]
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/attendance [get]
+func (h *AttendanceHandler) GetAll(c *gin.Context) {
+	filter := repositories.AttendanceFilter{
+		VolunteerID: c.Query("volunteer_id"),
+		WorkshopID:  c.Query("workshop_id"),
+	}
+
+	// Parse date range parameters
+	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
+		dateFrom, err := time.Parse(time.RFC3339, dateFromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_from inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateFrom = &dateFrom
+	}
+
+	if dateToStr := c.Query("date_to"); dateToStr != "" {
+		dateTo, err := time.Parse(time.RFC3339, dateToStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_to inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateTo = &dateTo
+	}
+
+	// Parse pagination parameters
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+	}
+
+	records, err := h.attendanceService.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	records.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, records)
+}
+
+// Update godoc
+// @Summary Corrigir registro de presença
+// @Description Corrige data, horários, horas ou observações de um registro de presença
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do registro de presença"
+// @Param attendance body models.UpdateAttendanceRequest true "Dados corrigidos"
+// @Success 200 {object} models.AttendanceResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/attendance/{id} [put]
+func (h *AttendanceHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attendance, err := h.attendanceService.Update(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(attendanceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attendance)
+}
+
+// Delete godoc
+// @Summary Remover registro de presença
+// @Description Remove um registro de presença lançado por engano
+// @Tags attendance
+// @Produce json
+// @Param id path string true "ID do registro de presença"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /api/attendance/{id} [delete]
+func (h *AttendanceHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.attendanceService.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(attendanceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler gerencia as requisições da trilha de auditoria
+type AuditHandler struct {
+	auditService services.AuditService
+}
+
+// NewAuditHandler cria uma nova instância do handler
+func NewAuditHandler(auditService services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAll godoc
+// @Summary Consultar trilha de auditoria
+// @Description Lista as operações de alteração registradas, das mais recentes para as mais antigas
+// @Tags audit
+// @Produce json
+// @Param actor query string false "Filtrar pelo ID do usuário que executou a operação"
+// @Param entity query string false "Filtrar por entidade (volunteer, workshop, attendance, certificate, user, role, invite, settings)"
+// @Param entity_id query string false "Filtrar pelo ID da entidade"
+// @Param action query string false "Filtrar por ação"
+// @Param date_from query string false "Data inicial (RFC3339)"
+// @Param date_to query string false "Data final (RFC3339)"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.AuditEntry]
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/audit [get]
+func (h *AuditHandler) GetAll(c *gin.Context) {
+	filter := repositories.AuditFilter{
+		ActorID:  c.Query("actor"),
+		Entity:   c.Query("entity"),
+		EntityID: c.Query("entity_id"),
+		Action:   c.Query("action"),
+	}
+
+	// Parse date range parameters
+	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
+		dateFrom, err := time.Parse(time.RFC3339, dateFromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_from inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateFrom = &dateFrom
+	}
+
+	if dateToStr := c.Query("date_to"); dateToStr != "" {
+		dateTo, err := time.Parse(time.RFC3339, dateToStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_to inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateTo = &dateTo
+	}
+
+	// Parse pagination parameters
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+	}
+
+	entries, err := h.auditService.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, entries)
+}
@@ -4,7 +4,10 @@ import (
 	"ellp-volunter-platform/backend/internal/models"
 	"ellp-volunter-platform/backend/internal/repositories"
 	"ellp-volunter-platform/backend/internal/services"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,14 +26,17 @@ func NewAuthHandler(authService services.AuthService) *AuthHandler {
 
 // Login godoc
 // @Summary Login de usuário
-// @Description Autentica um usuário e retorna um token JWT
+// @Description Autentica um usuário e retorna um token JWT. Se o usuário usa autenticação em dois
+// @Description fatores, a resposta é um desafio (mfa_required) a ser concluído em /api/auth/mfa/verify.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body models.LoginRequest true "Credenciais de login"
 // @Success 200 {object} services.LoginResponse
+// @Success 200 {object} models.MFAChallengeResponse
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
+// @Failure 429 {object} gin.H
 // @Router /api/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
@@ -44,6 +50,28 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	response, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
+		// Falta o segundo fator: a resposta traz o desafio em vez dos tokens
+		var challenge *services.MFAChallengeError
+		if errors.As(err, &challenge) {
+			c.JSON(http.StatusOK, challenge.Response())
+			return
+		}
+
+		var blocked *services.LoginBlockedError
+		if errors.As(err, &blocked) {
+			message := "Muitas tentativas de login. Aguarde antes de tentar novamente"
+			if blocked.Locked {
+				message = "Conta bloqueada temporariamente por tentativas de login incorretas"
+			}
+			retryAfter := int(math.Ceil(blocked.RetryAfter.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       message,
+				"retry_after": retryAfter,
+			})
+			return
+		}
+
 		switch err {
 		case services.ErrInvalidCredentials:
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -66,13 +94,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // Register godoc
 // @Summary Registrar novo usuário
-// @Description Cria um novo usuário no sistema
+// @Description Cria um novo usuário conforme o modo de cadastro configurado. No cadastro aberto o
+// @Description usuário é sempre "member"; no cadastro por convite o papel vem do convite (invite_token).
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param user body models.CreateUserRequest true "Dados do usuário"
 // @Success 201 {object} models.UserResponse
 // @Failure 400 {object} gin.H
+// @Failure 403 {object} gin.H
 // @Failure 409 {object} gin.H
 // @Router /api/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -100,6 +130,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error": err.Error(),
 			})
+		case services.ErrRegistrationDisabled, services.ErrInviteRequired,
+			services.ErrInvalidInvite, services.ErrInviteEmailMismatch:
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error": "Erro ao registrar usuário",
@@ -111,112 +146,488 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	c.JSON(http.StatusCreated, user)
 }
 
+// CreateInvite godoc
+// @Summary Emitir convite de cadastro
+// @Description Emite um convite de uso único que define o papel do usuário convidado (requer users:manage).
+// @Description Se o email for informado, apenas ele poderá usar o convite.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param invite body models.CreateInviteRequest true "Dados do convite"
+// @Success 201 {object} models.InviteResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Router /api/auth/invites [post]
+func (h *AuthHandler) CreateInvite(c *gin.Context) {
+	var req models.CreateInviteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	invite, err := h.authService.CreateInvite(c.Request.Context(), &req, c.GetString("user_id"))
+	if err != nil {
+		if err == models.ErrInvalidEmail {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Email inválido",
+			})
+			return
+		}
+		if err == repositories.ErrRoleNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao emitir convite",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
 // Logout godoc
 // @Summary Logout de usuário
-// @Description Invalida o token do usuário (cliente deve remover o token)
+// @Description Revoga a sessão do refresh token informado; o access token expira naturalmente
 // @Tags auth
+// @Accept json
 // @Produce json
+// @Param request body models.RefreshTokenRequest true "Refresh token"
 // @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
 // @Router /api/auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// JWT stateless, o logout é feito no cliente
-	// apenas é confirmado a operação
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Refresh token não fornecido",
+		})
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		if err == services.ErrInvalidRefreshToken {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Refresh token inválido ou expirado",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao realizar logout",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logout realizado com sucesso",
 	})
 }
 
-// RefreshToken godoc
-// @Summary Renovar token
-// @Description Gera um novo token JWT a partir do token atual
+// RefreshTokenFromBody godoc
+// @Summary Renovar token usando refresh token
+// @Description Gera novo access token a partir de um refresh token válido. O refresh token
+// @Description é rotacionado: a resposta traz um novo e o anterior deixa de valer.
 // @Tags auth
+// @Accept json
 // @Produce json
-// @Success 200 {object} gin.H
+// @Param request body models.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} services.LoginResponse
+// @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
 // @Router /api/auth/refresh [post]
-func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Token não fornecido",
+func (h *AuthHandler) RefreshTokenFromBody(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Refresh token não fornecido",
 		})
 		return
 	}
 
-	tokenString := authHeader
-	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-		tokenString = authHeader[7:]
+	response, err := h.authService.RefreshTokenWithRefreshToken(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		switch err {
+		case services.ErrRefreshTokenReused:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Refresh token já utilizado. Faça login novamente",
+			})
+		case services.ErrInvalidRefreshToken:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Refresh token inválido ou expirado",
+			})
+		case services.ErrUserInactive:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Usuário inativo",
+			})
+		case services.ErrMFASetupRequired:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Autenticação em dois fatores obrigatória. Faça login novamente para cadastrá-la",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Erro ao renovar token",
+			})
+		}
+		return
 	}
 
-	newToken, err := h.authService.RefreshToken(tokenString)
+	c.JSON(http.StatusOK, response)
+}
+
+// Me godoc
+// @Summary Obter informações do usuário autenticado
+// @Description Retorna os dados atuais do usuário autenticado
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.UserResponse
+// @Failure 401 {object} gin.H
+// @Router /api/auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, err := h.authService.GetCurrentUser(c.Request.Context(), c.GetString("user_id"))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Token inválido ou expirado",
+		if err == repositories.ErrUserNotFound {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Não autenticado",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao buscar usuário",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token": newToken,
-	})
+	c.JSON(http.StatusOK, user)
 }
 
-// RefreshTokenFromBody godoc
-// @Summary Renovar token usando refresh token
-// @Description Gera novo access token a partir de um refresh token válido
+// UpdateMe godoc
+// @Summary Atualizar perfil
+// @Description Altera o nome e o email do usuário autenticado
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body map[string]string true "Refresh token"
-// @Success 200 {object} services.LoginResponse
+// @Security BearerAuth
+// @Param profile body models.UpdateProfileRequest true "Dados do perfil"
+// @Success 200 {object} models.UserResponse
 // @Failure 400 {object} gin.H
 // @Failure 401 {object} gin.H
-// @Router /api/auth/refresh [post]
-func (h *AuthHandler) RefreshTokenFromBody(c *gin.Context) {
-	var req map[string]string
+// @Failure 409 {object} gin.H
+// @Router /api/auth/me [put]
+func (h *AuthHandler) UpdateMe(c *gin.Context) {
+	var req models.UpdateProfileRequest
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Refresh token não fornecido",
+			"error": "Dados inválidos",
 		})
 		return
 	}
 
-	refreshToken, ok := req["refresh_token"]
-	if !ok || refreshToken == "" {
+	user, err := h.authService.UpdateProfile(c.Request.Context(), c.GetString("user_id"), &req)
+	if err != nil {
+		switch err {
+		case repositories.ErrUserAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Email já cadastrado",
+			})
+		case models.ErrInvalidEmail:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Email inválido",
+			})
+		case repositories.ErrUserNotFound:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Não autenticado",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Erro ao atualizar perfil",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword godoc
+// @Summary Trocar senha
+// @Description Troca a senha do usuário autenticado mediante a senha atual. As demais sessões
+// @Description são encerradas e a resposta traz novos tokens para a sessão atual.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ChangePasswordRequest true "Senha atual e nova senha"
+// @Success 200 {object} services.LoginResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Router /api/auth/me/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req models.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Refresh token não fornecido",
+			"error": "Dados inválidos",
 		})
 		return
 	}
 
-	response, err := h.authService.RefreshTokenWithRefreshToken(refreshToken)
+	response, err := h.authService.ChangePassword(c.Request.Context(), c.GetString("user_id"), req.CurrentPassword, req.NewPassword)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Refresh token inválido ou expirado",
+		switch err {
+		case services.ErrWrongPassword, models.ErrInvalidPassword, models.ErrPasswordTooWeak:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		case repositories.ErrUserNotFound:
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Não autenticado",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Erro ao trocar senha",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// mfaErrorStatus mapeia os erros de autenticação em dois fatores para status HTTP
+func mfaErrorStatus(err error) int {
+	switch err {
+	case services.ErrInvalidMFAToken, services.ErrUserInactive, repositories.ErrUserNotFound:
+		return http.StatusUnauthorized
+	case services.ErrInvalidMFACode, services.ErrWrongPassword:
+		return http.StatusBadRequest
+	case services.ErrMFAAlreadyEnabled, services.ErrMFANotEnabled, services.ErrMFASetupNotStarted:
+		return http.StatusConflict
+	case services.ErrMFARequiredByPolicy:
+		return http.StatusForbidden
+	default:
+		if errors.Is(err, services.ErrLoginBlocked) {
+			return http.StatusTooManyRequests
+		}
+		return http.StatusInternalServerError
+	}
+}
+
+// VerifyMFA godoc
+// @Summary Concluir login com segundo fator
+// @Description Conclui o login com o token de desafio e um código TOTP ou de recuperação. Se o
+// @Description autenticador estava sendo cadastrado, a resposta traz também os códigos de recuperação.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.MFAVerifyRequest true "Desafio e código"
+// @Success 200 {object} services.LoginResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 429 {object} gin.H
+// @Router /api/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req models.MFAVerifyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
 		})
 		return
 	}
 
+	response, err := h.authService.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
-// Me godoc
-// @Summary Obter informações do usuário autenticado
-// @Description Retorna as informações do usuário a partir do token JWT
+// MFAChallengeSetup godoc
+// @Summary Cadastrar autenticador durante o login
+// @Description Gera o segredo TOTP quando a política exige MFA de um usuário que ainda não o cadastrou.
+// @Description O login é concluído em /api/auth/mfa/verify com um código gerado pelo autenticador.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.MFASetupChallengeRequest true "Desafio do login"
+// @Success 200 {object} models.MFASetupResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Router /api/auth/mfa/challenge/setup [post]
+func (h *AuthHandler) MFAChallengeSetup(c *gin.Context) {
+	var req models.MFASetupChallengeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	setup, err := h.authService.BeginMFAChallengeSetup(c.Request.Context(), req.MFAToken)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// SetupMFA godoc
+// @Summary Iniciar cadastro do autenticador
+// @Description Gera um novo segredo TOTP e o endereço otpauth:// para o QR code. O MFA só é ativado
+// @Description após a confirmação com um código em /api/auth/mfa/enable.
 // @Tags auth
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} models.UserResponse
+// @Success 200 {object} models.MFASetupResponse
 // @Failure 401 {object} gin.H
-// @Router /api/auth/me [get]
-func (h *AuthHandler) Me(c *gin.Context) {
-	claims, exists := c.Get("claims")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Não autenticado",
+// @Failure 409 {object} gin.H
+// @Router /api/auth/mfa/setup [post]
+func (h *AuthHandler) SetupMFA(c *gin.Context) {
+	setup, err := h.authService.BeginMFASetup(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// EnableMFA godoc
+// @Summary Ativar autenticação em dois fatores
+// @Description Confirma o cadastro do autenticador com um código gerado por ele e retorna os
+// @Description códigos de recuperação, exibidos uma única vez
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MFACodeRequest true "Código do autenticador"
+// @Success 200 {object} models.MFARecoveryCodesResponse
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Router /api/auth/mfa/enable [post]
+func (h *AuthHandler) EnableMFA(c *gin.Context) {
+	var req models.MFACodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	codes, err := h.authService.EnableMFA(c.Request.Context(), c.GetString("user_id"), req.Code)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFA godoc
+// @Summary Desativar autenticação em dois fatores
+// @Description Desativa o segundo fator mediante a senha e um código válido. Não é permitido quando
+// @Description a política exige MFA para administradores.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DisableMFARequest true "Senha e código"
+// @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Failure 409 {object} gin.H
+// @Router /api/auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	var req models.DisableMFARequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	if err := h.authService.DisableMFA(c.Request.Context(), c.GetString("user_id"), req.Password, req.Code); err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Autenticação em dois fatores desativada",
+	})
+}
+
+// GetSecuritySettings godoc
+// @Summary Consultar políticas de segurança
+// @Description Retorna as políticas de segurança em vigor (requer settings:manage)
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.SecuritySettings
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Router /api/auth/security-settings [get]
+func (h *AuthHandler) GetSecuritySettings(c *gin.Context) {
+	settings, err := h.authService.GetSecuritySettings(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao buscar políticas de segurança",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSecuritySettings godoc
+// @Summary Alterar políticas de segurança
+// @Description Define se a autenticação em dois fatores é obrigatória para administradores (requer settings:manage).
+// @Description Administradores sem MFA precisam cadastrá-lo no próximo login.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param settings body models.UpdateSecuritySettingsRequest true "Políticas de segurança"
+// @Success 200 {object} models.SecuritySettings
+// @Failure 400 {object} gin.H
+// @Failure 401 {object} gin.H
+// @Failure 403 {object} gin.H
+// @Router /api/auth/security-settings [put]
+func (h *AuthHandler) UpdateSecuritySettings(c *gin.Context) {
+	var req models.UpdateSecuritySettingsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	settings, err := h.authService.UpdateSecuritySettings(c.Request.Context(), &req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao alterar políticas de segurança",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, claims)
+	c.JSON(http.StatusOK, settings)
 }
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CertificateHandler gerencia as requisições de certificados de participação
+type CertificateHandler struct {
+	certificateService services.CertificateService
+}
+
+// NewCertificateHandler cria uma nova instância do handler
+func NewCertificateHandler(certificateService services.CertificateService) *CertificateHandler {
+	return &CertificateHandler{
+		certificateService: certificateService,
+	}
+}
+
+// certificateErrorStatus mapeia os erros do serviço de certificados para status HTTP
+func certificateErrorStatus(err error) int {
+	switch err {
+	case repositories.ErrCertificateNotFound, repositories.ErrVolunteerNotFound:
+		return http.StatusNotFound
+	case repositories.ErrCertificateAlreadyRevoked:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Download godoc
+// @Summary Emitir certificado de participação
+// @Description Gera o certificado de participação do voluntário em PDF, com código de verificação
+// @Tags certificates
+// @Produce application/pdf
+// @Param id path string true "ID do voluntário"
+// @Success 200 {file} file
+// @Header 200 {string} X-Certificate-Code "Código de verificação do certificado"
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/certificate.pdf [get]
+func (h *CertificateHandler) Download(c *gin.Context) {
+	id := c.Param("id")
+
+	certificate, data, err := h.certificateService.Generate(c.Request.Context(), id, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="certificado-`+certificate.Code+`.pdf"`)
+	c.Header("X-Certificate-Code", certificate.Code)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// GetByVolunteer godoc
+// @Summary Listar certificados do voluntário
+// @Description Lista os certificados já emitidos para um voluntário
+// @Tags certificates
+// @Produce json
+// @Param id path string true "ID do voluntário"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.Certificate]
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/certificates [get]
+func (h *CertificateHandler) GetByVolunteer(c *gin.Context) {
+	id := c.Param("id")
+
+	page, limit := paginationQuery(c)
+
+	certificates, err := h.certificateService.GetByVolunteer(c.Request.Context(), id, page, limit)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	certificates.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, certificates)
+}
+
+// Verify godoc
+// @Summary Verificar certificado
+// @Description Confirma a autenticidade de um certificado emitido pela plataforma (rota pública)
+// @Tags certificates
+// @Produce json
+// @Param code path string true "Código de verificação"
+// @Success 200 {object} models.CertificateVerification
+// @Failure 404 {object} map[string]string
+// @Router /api/certificates/{code} [get]
+func (h *CertificateHandler) Verify(c *gin.Context) {
+	code := c.Param("code")
+
+	verification, err := h.certificateService.Verify(c.Request.Context(), code)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// Revoke godoc
+// @Summary Revogar certificado
+// @Description Revoga um certificado emitido (requer certificates:revoke)
+// @Tags certificates
+// @Accept json
+// @Produce json
+// @Param code path string true "Código de verificação"
+// @Param request body models.RevokeCertificateRequest true "Motivo da revogação"
+// @Success 200 {object} models.Certificate
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/certificates/{code}/revoke [post]
+func (h *CertificateHandler) Revoke(c *gin.Context) {
+	code := c.Param("code")
+
+	var req models.RevokeCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	certificate, err := h.certificateService.Revoke(c.Request.Context(), code, c.GetString("user_id"), req.Reason)
+	if err != nil {
+		c.JSON(certificateErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, certificate)
+}
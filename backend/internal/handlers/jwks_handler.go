@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/config"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler publica as chaves públicas usadas para assinar os tokens
+type JWKSHandler struct {
+	keys *config.KeyRing
+}
+
+// NewJWKSHandler cria uma nova instância do handler
+func NewJWKSHandler(keys *config.KeyRing) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// GetJWKS godoc
+// @Summary Chaves públicas de assinatura
+// @Description Retorna as chaves públicas (JWKS) para validar os tokens emitidos pela API.
+// @Description Chaves aposentadas continuam publicadas até serem removidas do chaveiro.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} config.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	// Clientes podem guardar as chaves por alguns minutos
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paginationQuery lê os parâmetros page e limit da consulta. Valores ausentes
+// ou inválidos ficam zerados e recebem o padrão no serviço.
+func paginationQuery(c *gin.Context) (page, limit int) {
+	if value, err := strconv.Atoi(c.Query("page")); err == nil && value > 0 {
+		page = value
+	}
+	if value, err := strconv.Atoi(c.Query("limit")); err == nil && value > 0 {
+		limit = value
+	}
+	return page, limit
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetHandler gerencia as requisições de recuperação de senha
+type PasswordResetHandler struct {
+	passwordResetService services.PasswordResetService
+}
+
+// NewPasswordResetHandler cria uma nova instância do handler
+func NewPasswordResetHandler(passwordResetService services.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+	}
+}
+
+// ForgotPassword godoc
+// @Summary Solicitar redefinição de senha
+// @Description Envia para o email informado um link de redefinição de senha de uso único.
+// @Description A resposta é a mesma para emails cadastrados ou não.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ForgotPasswordRequest true "Email da conta"
+// @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Router /api/auth/forgot-password [post]
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var req models.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Email inválido",
+		})
+		return
+	}
+
+	if err := h.passwordResetService.RequestReset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erro ao solicitar redefinição de senha",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Se o email estiver cadastrado, você receberá as instruções para redefinir a senha",
+	})
+}
+
+// ResetPassword godoc
+// @Summary Redefinir senha
+// @Description Define uma nova senha usando o token recebido por email e encerra todas as sessões do usuário
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ConfirmPasswordResetRequest true "Token e nova senha"
+// @Success 200 {object} gin.H
+// @Failure 400 {object} gin.H
+// @Router /api/auth/reset-password [post]
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var req models.ConfirmPasswordResetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Dados inválidos",
+		})
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		switch err {
+		case services.ErrInvalidResetToken, models.ErrInvalidPassword, models.ErrPasswordTooWeak:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Erro ao redefinir senha",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Senha redefinida com sucesso",
+	})
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PortalHandler gerencia as requisições do portal do voluntário
+type PortalHandler struct {
+	portalService services.PortalService
+}
+
+// NewPortalHandler cria uma nova instância do handler
+func NewPortalHandler(portalService services.PortalService) *PortalHandler {
+	return &PortalHandler{
+		portalService: portalService,
+	}
+}
+
+// portalErrorStatus mapeia os erros do serviço do portal para status HTTP
+func portalErrorStatus(err error) int {
+	switch err {
+	case services.ErrNoLinkedVolunteer:
+		return http.StatusForbidden
+	case repositories.ErrUserNotFound, repositories.ErrWorkshopNotFound:
+		return http.StatusNotFound
+	case services.ErrWorkshopNotOpen, services.ErrWorkshopCancelled, services.ErrVolunteerInactive:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Profile godoc
+// @Summary Meu cadastro de voluntário
+// @Description Retorna o cadastro do voluntário vinculado à conta, com o total de horas (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.VolunteerResponse
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/me [get]
+func (h *PortalHandler) Profile(c *gin.Context) {
+	volunteer, err := h.portalService.Profile(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteer)
+}
+
+// Hours godoc
+// @Summary Minhas horas
+// @Description Lista os registros de presença do voluntário vinculado à conta e o total de horas (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.PortalHoursResponse
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/hours [get]
+func (h *PortalHandler) Hours(c *gin.Context) {
+	hours, err := h.portalService.Hours(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// Workshops godoc
+// @Summary Minhas oficinas
+// @Description Lista as oficinas em que o voluntário está inscrito ou na fila de espera (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.PortalWorkshopResponse]
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/workshops [get]
+func (h *PortalHandler) Workshops(c *gin.Context) {
+	page, limit := paginationQuery(c)
+
+	workshops, err := h.portalService.Workshops(c.Request.Context(), c.GetString("user_id"), page, limit)
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	workshops.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, workshops)
+}
+
+// OpenWorkshops godoc
+// @Summary Oficinas abertas
+// @Description Lista as oficinas agendadas que aceitam inscrições (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.PortalWorkshopResponse]
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/workshops/open [get]
+func (h *PortalHandler) OpenWorkshops(c *gin.Context) {
+	page, limit := paginationQuery(c)
+
+	workshops, err := h.portalService.OpenWorkshops(c.Request.Context(), c.GetString("user_id"), page, limit)
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	workshops.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, workshops)
+}
+
+// Enroll godoc
+// @Summary Inscrever-se em oficina
+// @Description Inscreve o voluntário em uma oficina aberta ou o coloca na fila de espera se não houver vagas (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Param workshop_id path string true "ID da oficina"
+// @Success 200 {object} map[string]string
+// @Success 202 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/portal/workshops/{workshop_id} [post]
+func (h *PortalHandler) Enroll(c *gin.Context) {
+	status, err := h.portalService.Enroll(c.Request.Context(), c.GetString("user_id"), c.Param("workshop_id"))
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if status == models.EnrollmentWaitlisted {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Oficina lotada, você está na fila de espera",
+			"status":  status,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Inscrição realizada com sucesso",
+		"status":  status,
+	})
+}
+
+// Unenroll godoc
+// @Summary Cancelar inscrição em oficina
+// @Description Cancela a inscrição do voluntário em uma oficina que ainda não aconteceu (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Param workshop_id path string true "ID da oficina"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/portal/workshops/{workshop_id} [delete]
+func (h *PortalHandler) Unenroll(c *gin.Context) {
+	if err := h.portalService.Unenroll(c.Request.Context(), c.GetString("user_id"), c.Param("workshop_id")); err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Inscrição cancelada com sucesso"})
+}
+
+// Certificates godoc
+// @Summary Meus certificados
+// @Description Lista os certificados já emitidos para o voluntário vinculado à conta (requer portal:access)
+// @Tags portal
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.Certificate]
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/certificates [get]
+func (h *PortalHandler) Certificates(c *gin.Context) {
+	page, limit := paginationQuery(c)
+
+	certificates, err := h.portalService.Certificates(c.Request.Context(), c.GetString("user_id"), page, limit)
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	certificates.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, certificates)
+}
+
+// Certificate godoc
+// @Summary Baixar meu certificado
+// @Description Retorna em PDF o certificado de participação do voluntário vinculado à conta. Reaproveita o último certificado válido enquanto os dados não mudam (requer portal:access)
+// @Tags portal
+// @Produce application/pdf
+// @Security BearerAuth
+// @Success 200 {file} file
+// @Header 200 {string} X-Certificate-Code "Código de verificação do certificado"
+// @Failure 403 {object} map[string]string
+// @Router /api/portal/certificate.pdf [get]
+func (h *PortalHandler) Certificate(c *gin.Context) {
+	certificate, data, err := h.portalService.Certificate(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(portalErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="certificado-`+certificate.Code+`.pdf"`)
+	c.Header("X-Certificate-Code", certificate.Code)
+	c.Data(http.StatusOK, "application/pdf", data)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler gerencia as requisições de papéis e permissões
+type RoleHandler struct {
+	roleService services.RoleService
+}
+
+// NewRoleHandler cria uma nova instância do handler
+func NewRoleHandler(roleService services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// roleErrorStatus mapeia os erros do serviço de papéis para status HTTP
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repositories.ErrRoleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repositories.ErrRoleAlreadyExists), errors.Is(err, services.ErrSystemRole), errors.Is(err, services.ErrRoleInUse):
+		return http.StatusConflict
+	case errors.Is(err, models.ErrInvalidRoleName), errors.Is(err, models.ErrUnknownPermission):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// List godoc
+// @Summary Listar papéis
+// @Description Lista os papéis e as permissões de cada um (requer roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Role
+// @Failure 500 {object} map[string]string
+// @Router /api/roles [get]
+func (h *RoleHandler) List(c *gin.Context) {
+	roles, err := h.roleService.List(c.Request.Context())
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// ListPermissions godoc
+// @Summary Listar permissões
+// @Description Lista as permissões que podem ser atribuídas aos papéis (requer roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} string
+// @Router /api/roles/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, models.AllPermissions)
+}
+
+// GetByName godoc
+// @Summary Buscar papel
+// @Description Busca um papel pelo nome (requer roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome do papel"
+// @Success 200 {object} models.Role
+// @Failure 404 {object} map[string]string
+// @Router /api/roles/{name} [get]
+func (h *RoleHandler) GetByName(c *gin.Context) {
+	role, err := h.roleService.GetByName(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// Create godoc
+// @Summary Criar papel
+// @Description Cria um papel com as permissões informadas (requer roles:manage)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateRoleRequest true "Dados do papel"
+// @Success 201 {object} models.Role
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/roles [post]
+func (h *RoleHandler) Create(c *gin.Context) {
+	var req models.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleService.Create(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// Update godoc
+// @Summary Atualizar papel
+// @Description Altera a descrição e as permissões de um papel; as permissões do administrador são fixas (requer roles:manage)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome do papel"
+// @Param request body models.UpdateRoleRequest true "Dados do papel"
+// @Success 200 {object} models.Role
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/roles/{name} [put]
+func (h *RoleHandler) Update(c *gin.Context) {
+	var req models.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.roleService.Update(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// Delete godoc
+// @Summary Remover papel
+// @Description Remove um papel que não é padrão nem está atribuído a usuários (requer roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome do papel"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/roles/{name} [delete]
+func (h *RoleHandler) Delete(c *gin.Context) {
+	if err := h.roleService.Delete(c.Request.Context(), c.Param("name")); err != nil {
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Papel removido com sucesso"})
+}
@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler gerencia as requisições de administração de usuários
+type UserHandler struct {
+	userService services.UserService
+}
+
+// NewUserHandler cria uma nova instância do handler
+func NewUserHandler(userService services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// userErrorStatus mapeia os erros do serviço de usuários para status HTTP
+func userErrorStatus(err error) int {
+	switch err {
+	case repositories.ErrUserNotFound, repositories.ErrVolunteerNotFound:
+		return http.StatusNotFound
+	case services.ErrCannotModifySelf, services.ErrLastAdmin, services.ErrVolunteerAlreadyLinked:
+		return http.StatusConflict
+	case models.ErrInvalidPassword, models.ErrPasswordTooWeak, repositories.ErrRoleNotFound:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// List godoc
+// @Summary Listar usuários
+// @Description Lista os usuários do sistema com busca e paginação (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Buscar por nome ou email"
+// @Param role query string false "Filtrar por papel (ex.: admin, member)"
+// @Param is_active query bool false "Filtrar por status ativo"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.UserResponse]
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/users [get]
+func (h *UserHandler) List(c *gin.Context) {
+	filter := services.UserFilter{
+		Search: c.Query("search"),
+		Role:   c.Query("role"),
+	}
+
+	if filter.Role != "" && models.ValidateRoleName(filter.Role) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role inválido"})
+		return
+	}
+
+	// Parse is_active parameter
+	if isActiveStr := c.Query("is_active"); isActiveStr != "" {
+		isActive, err := strconv.ParseBool(isActiveStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "is_active inválido"})
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	// Parse pagination parameters
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+	}
+
+	users, err := h.userService.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	users.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, users)
+}
+
+// GetByID godoc
+// @Summary Buscar usuário por ID
+// @Description Busca um usuário específico por ID (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id} [get]
+func (h *UserHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangeRole godoc
+// @Summary Alterar papel do usuário
+// @Description Altera o papel de um usuário; o papel precisa existir (requer users:manage)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Param request body models.UpdateUserRoleRequest true "Novo papel"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/users/{id}/role [put]
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.ChangeRole(c.Request.Context(), id, req.Role, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Deactivate godoc
+// @Summary Desativar usuário
+// @Description Desativa um usuário e encerra suas sessões (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/users/{id}/deactivate [post]
+func (h *UserHandler) Deactivate(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.Deactivate(c.Request.Context(), id, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Reactivate godoc
+// @Summary Reativar usuário
+// @Description Reativa um usuário desativado (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/reactivate [post]
+func (h *UserHandler) Reactivate(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.Reactivate(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Unlock godoc
+// @Summary Desbloquear login do usuário
+// @Description Remove o bloqueio de login por tentativas incorretas e zera o contador de falhas (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/unlock [post]
+func (h *UserHandler) Unlock(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.Unlock(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ResetMFA godoc
+// @Summary Remover autenticação em dois fatores do usuário
+// @Description Remove o autenticador e os códigos de recuperação do usuário e encerra suas sessões (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/mfa/reset [post]
+func (h *UserHandler) ResetMFA(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.ResetMFA(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ResetPassword godoc
+// @Summary Redefinir senha do usuário
+// @Description Define uma nova senha para o usuário e encerra suas sessões (requer users:manage)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Param request body models.ResetPasswordRequest true "Nova senha"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/reset-password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), id, req.Password); err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Senha redefinida com sucesso"})
+}
+
+// LinkVolunteer godoc
+// @Summary Vincular voluntário ao usuário
+// @Description Vincula a conta a um voluntário para que ele acesse os próprios dados no portal (requer users:manage)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Param request body models.LinkVolunteerRequest true "Voluntário"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/users/{id}/volunteer [put]
+func (h *UserHandler) LinkVolunteer(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.LinkVolunteerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.LinkVolunteer(c.Request.Context(), id, req.VolunteerID)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UnlinkVolunteer godoc
+// @Summary Desvincular voluntário do usuário
+// @Description Remove o vínculo entre a conta e o voluntário (requer users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} models.UserResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/volunteer [delete]
+func (h *UserHandler) UnlinkVolunteer(c *gin.Context) {
+	id := c.Param("id")
+
+	user, err := h.userService.UnlinkVolunteer(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(userErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
@@ -6,6 +6,7 @@ import (
 	"ellp-volunter-platform/backend/internal/services"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,6 +23,20 @@ func NewVolunteerHandler(volunteerService services.VolunteerService) *VolunteerH
 	}
 }
 
+// volunteerErrorStatus mapeia os erros do serviço de voluntários para status HTTP
+func volunteerErrorStatus(err error) int {
+	switch err {
+	case repositories.ErrVolunteerNotFound, repositories.ErrWorkshopNotFound, repositories.ErrVolunteerRevisionNotFound:
+		return http.StatusNotFound
+	case services.ErrWorkshopCancelled, services.ErrVolunteerNotInTrash, models.ErrVolunteerAlreadyInactive, models.ErrVolunteerAlreadyActive:
+		return http.StatusConflict
+	case services.ErrForbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 // Create godoc
 // @Summary Criar novo voluntário
 // @Description Cria um novo voluntário no sistema
@@ -51,17 +66,36 @@ func (h *VolunteerHandler) Create(c *gin.Context) {
 
 // GetByID godoc
 // @Summary Buscar voluntário por ID
-// @Description Busca um voluntário específico por ID
+// @Description Busca um voluntário específico por ID. Com as_of, retorna o cadastro como estava na data informada, sem as oficinas (workshops vem nulo)
 // @Tags volunteers
 // @Produce json
 // @Param id path string true "ID do voluntário"
+// @Param as_of query string false "Data da consulta (RFC3339)"
 // @Success 200 {object} models.VolunteerResponse
+// @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/volunteers/{id} [get]
 func (h *VolunteerHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
 
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		asOf, err := time.Parse(time.RFC3339, asOfStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "as_of inválido, use o formato RFC3339"})
+			return
+		}
+
+		volunteer, err := h.volunteerService.GetAsOf(c.Request.Context(), id, asOf)
+		if err != nil {
+			c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, volunteer)
+		return
+	}
+
 	volunteer, err := h.volunteerService.GetByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -71,16 +105,49 @@ func (h *VolunteerHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, volunteer)
 }
 
+// History godoc
+// @Summary Histórico do voluntário
+// @Description Lista as versões do cadastro do voluntário, da mais recente à mais antiga
+// @Tags volunteers
+// @Produce json
+// @Param id path string true "ID do voluntário"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.VolunteerRevision]
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/history [get]
+func (h *VolunteerHandler) History(c *gin.Context) {
+	id := c.Param("id")
+
+	page, limit := paginationQuery(c)
+
+	revisions, err := h.volunteerService.History(c.Request.Context(), id, page, limit)
+	if err != nil {
+		if err == repositories.ErrVolunteerNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	revisions.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, revisions)
+}
+
 // GetAll godoc
 // @Summary Listar todos os voluntários
-// @Description Lista todos os voluntários com filtros opcionais
+// @Description Lista os voluntários com filtros opcionais, por número de página ou, para exportações e rolagem infinita, pelo cursor next_cursor da página anterior
 // @Tags volunteers
 // @Produce json
 // @Param name query string false "Filtrar por nome"
 // @Param is_active query bool false "Filtrar por status ativo"
 // @Param page query int false "Número da página" default(1)
-// @Param limit query int false "Itens por página" default(10)
-// @Success 200 {array} models.VolunteerResponse
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Param cursor query string false "Cursor next_cursor da página anterior; substitui page"
+// @Success 200 {object} models.Page[models.VolunteerResponse]
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/volunteers [get]
 func (h *VolunteerHandler) GetAll(c *gin.Context) {
@@ -107,12 +174,23 @@ func (h *VolunteerHandler) GetAll(c *gin.Context) {
 		}
 	}
 
+	// Parse cursor parameter
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := models.DecodeCursor(cursorStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.After = cursor
+	}
+
 	volunteers, err := h.volunteerService.GetAll(c.Request.Context(), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	volunteers.SetLinks(c.Request.URL)
 	c.JSON(http.StatusOK, volunteers)
 }
 
@@ -149,7 +227,7 @@ func (h *VolunteerHandler) Update(c *gin.Context) {
 
 // Delete godoc
 // @Summary Deletar voluntário
-// @Description Remove um voluntário do sistema
+// @Description Move um voluntário para a lixeira, de onde pode ser restaurado
 // @Tags volunteers
 // @Produce json
 // @Param id path string true "ID do voluntário"
@@ -160,7 +238,7 @@ func (h *VolunteerHandler) Update(c *gin.Context) {
 func (h *VolunteerHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.volunteerService.Delete(c.Request.Context(), id); err != nil {
+	if err := h.volunteerService.Delete(c.Request.Context(), id, c.GetString("user_id")); err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
 	}
@@ -168,9 +246,105 @@ func (h *VolunteerHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// GetTrash godoc
+// @Summary Listar lixeira de voluntários
+// @Description Lista os voluntários que estão na lixeira
+// @Tags volunteers
+// @Produce json
+// @Param name query string false "Filtrar por nome"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Param cursor query string false "Cursor next_cursor da página anterior; substitui page"
+// @Success 200 {object} models.Page[models.VolunteerResponse]
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/trash [get]
+func (h *VolunteerHandler) GetTrash(c *gin.Context) {
+	filter := repositories.VolunteerFilter{
+		Name:    c.Query("name"),
+		Deleted: true,
+	}
+
+	// Parse pagination parameters
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+	}
+
+	// Parse cursor parameter
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := models.DecodeCursor(cursorStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.After = cursor
+	}
+
+	volunteers, err := h.volunteerService.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	volunteers.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, volunteers)
+}
+
+// Restore godoc
+// @Summary Restaurar voluntário
+// @Description Retira um voluntário da lixeira
+// @Tags volunteers
+// @Produce json
+// @Param id path string true "ID do voluntário"
+// @Success 200 {object} models.VolunteerResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/restore [post]
+func (h *VolunteerHandler) Restore(c *gin.Context) {
+	id := c.Param("id")
+
+	volunteer, err := h.volunteerService.Restore(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteer)
+}
+
+// Purge godoc
+// @Summary Excluir voluntário definitivamente
+// @Description Remove definitivamente um voluntário da lixeira, com o histórico de versões, as inscrições em oficinas, o vínculo com a conta de usuário e os registros de presença
+// @Tags volunteers
+// @Produce json
+// @Param id path string true "ID do voluntário"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/purge [delete]
+func (h *VolunteerHandler) Purge(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.volunteerService.Purge(c.Request.Context(), id); err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // Inactivate godoc
 // @Summary Inativar voluntário
-// @Description Marca um voluntário como inativo e registra data de saída
+// @Description Marca um voluntário como inativo e encerra o período de atuação com a data de saída
 // @Tags volunteers
 // @Accept json
 // @Produce json
@@ -179,6 +353,7 @@ func (h *VolunteerHandler) Delete(c *gin.Context) {
 // @Success 200 {object} models.VolunteerResponse
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/volunteers/{id}/inactivate [post]
 func (h *VolunteerHandler) Inactivate(c *gin.Context) {
@@ -192,39 +367,85 @@ func (h *VolunteerHandler) Inactivate(c *gin.Context) {
 
 	volunteer, err := h.volunteerService.Inactivate(c.Request.Context(), id, req)
 	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, volunteer)
+}
+
+// Reactivate godoc
+// @Summary Reativar voluntário
+// @Description Reativa um voluntário inativo, abrindo um novo período de atuação a partir da data de retorno
+// @Tags volunteers
+// @Accept json
+// @Produce json
+// @Param id path string true "ID do voluntário"
+// @Param request body models.ReactivateVolunteerRequest true "Data de retorno"
+// @Success 200 {object} models.VolunteerResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/volunteers/{id}/reactivate [post]
+func (h *VolunteerHandler) Reactivate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.ReactivateVolunteerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	volunteer, err := h.volunteerService.Reactivate(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, volunteer)
 }
 
 // AddWorkshop godoc
 // @Summary Adicionar oficina ao voluntário
-// @Description Adiciona uma oficina ao histórico do voluntário
+// @Description Adiciona uma oficina ao histórico do voluntário ou o coloca na fila de espera se não houver vagas
 // @Tags volunteers
 // @Produce json
 // @Param id path string true "ID do voluntário"
 // @Param workshop_id path string true "ID da oficina"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Router /api/volunteers/{id}/workshops/{workshop_id} [post]
 func (h *VolunteerHandler) AddWorkshop(c *gin.Context) {
 	volunteerID := c.Param("id")
 	workshopID := c.Param("workshop_id")
 
-	if err := h.volunteerService.AddWorkshop(c.Request.Context(), volunteerID, workshopID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	status, err := h.volunteerService.AddWorkshop(c.Request.Context(), volunteerID, workshopID)
+	if err != nil {
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Oficina adicionada com sucesso"})
+	if status == models.EnrollmentWaitlisted {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Oficina lotada, voluntário adicionado à fila de espera",
+			"status":  status,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Oficina adicionada com sucesso",
+		"status":  status,
+	})
 }
 
 // RemoveWorkshop godoc
 // @Summary Remover oficina do voluntário
-// @Description Remove uma oficina do histórico do voluntário
+// @Description Remove uma oficina do histórico do voluntário e promove o primeiro da fila de espera
 // @Tags volunteers
 // @Produce json
 // @Param id path string true "ID do voluntário"
@@ -238,7 +459,7 @@ func (h *VolunteerHandler) RemoveWorkshop(c *gin.Context) {
 	workshopID := c.Param("workshop_id")
 
 	if err := h.volunteerService.RemoveWorkshop(c.Request.Context(), volunteerID, workshopID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(volunteerErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"ellp-volunter-platform/backend/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkshopHandler gerencia as requisições de oficinas
+type WorkshopHandler struct {
+	workshopService services.WorkshopService
+}
+
+// NewWorkshopHandler cria uma nova instância do handler
+func NewWorkshopHandler(workshopService services.WorkshopService) *WorkshopHandler {
+	return &WorkshopHandler{
+		workshopService: workshopService,
+	}
+}
+
+// workshopErrorStatus mapeia os erros do serviço de oficinas para status HTTP
+func workshopErrorStatus(err error) int {
+	switch err {
+	case repositories.ErrWorkshopNotFound, repositories.ErrUserNotFound:
+		return http.StatusNotFound
+	case services.ErrWorkshopCancelled, services.ErrWorkshopAlreadyCancelled, services.ErrCapacityBelowEnrollment:
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// Create godoc
+// @Summary Criar nova oficina
+// @Description Cria uma nova oficina no sistema
+// @Tags workshops
+// @Accept json
+// @Produce json
+// @Param workshop body models.CreateWorkshopRequest true "Dados da oficina"
+// @Success 201 {object} models.WorkshopResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workshops [post]
+func (h *WorkshopHandler) Create(c *gin.Context) {
+	var req models.CreateWorkshopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workshop, err := h.workshopService.Create(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, workshop)
+}
+
+// GetByID godoc
+// @Summary Buscar oficina por ID
+// @Description Busca uma oficina específica por ID
+// @Tags workshops
+// @Produce json
+// @Param id path string true "ID da oficina"
+// @Success 200 {object} models.WorkshopResponse
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workshops/{id} [get]
+func (h *WorkshopHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	workshop, err := h.workshopService.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workshop)
+}
+
+// GetAll godoc
+// @Summary Listar todas as oficinas
+// @Description Lista todas as oficinas com filtros opcionais
+// @Tags workshops
+// @Produce json
+// @Param title query string false "Filtrar por título"
+// @Param instructor query string false "Filtrar por instrutor"
+// @Param status query string false "Filtrar por status (scheduled, completed, cancelled)"
+// @Param coordinator query string false "Filtrar pelo ID do usuário coordenador"
+// @Param volunteer query string false "Filtrar pelo ID do voluntário inscrito ou na fila de espera"
+// @Param date_from query string false "Data inicial (RFC3339)"
+// @Param date_to query string false "Data final (RFC3339)"
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Itens por página (máximo 100)" default(10)
+// @Success 200 {object} models.Page[models.WorkshopResponse]
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workshops [get]
+func (h *WorkshopHandler) GetAll(c *gin.Context) {
+	filter := repositories.WorkshopFilter{
+		Title:       c.Query("title"),
+		Instructor:  c.Query("instructor"),
+		Status:      c.Query("status"),
+		Coordinator: c.Query("coordinator"),
+		Volunteer:   c.Query("volunteer"),
+	}
+
+	if filter.Status != "" && !models.IsValidWorkshopStatus(filter.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido"})
+		return
+	}
+
+	// Parse date range parameters
+	if dateFromStr := c.Query("date_from"); dateFromStr != "" {
+		dateFrom, err := time.Parse(time.RFC3339, dateFromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_from inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateFrom = &dateFrom
+	}
+
+	if dateToStr := c.Query("date_to"); dateToStr != "" {
+		dateTo, err := time.Parse(time.RFC3339, dateToStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date_to inválido, use o formato RFC3339"})
+			return
+		}
+		filter.DateTo = &dateTo
+	}
+
+	// Parse pagination parameters
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			filter.Page = page
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			filter.Limit = limit
+		}
+	}
+
+	workshops, err := h.workshopService.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	workshops.SetLinks(c.Request.URL)
+	c.JSON(http.StatusOK, workshops)
+}
+
+// Update godoc
+// @Summary Atualizar oficina
+// @Description Atualiza os dados de uma oficina existente
+// @Tags workshops
+// @Accept json
+// @Produce json
+// @Param id path string true "ID da oficina"
+// @Param workshop body models.UpdateWorkshopRequest true "Dados atualizados"
+// @Success 200 {object} models.WorkshopResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/workshops/{id} [put]
+func (h *WorkshopHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.UpdateWorkshopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workshop, err := h.workshopService.Update(c.Request.Context(), id, req)
+	if err != nil {
+		c.JSON(workshopErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workshop)
+}
+
+// Delete godoc
+// @Summary Deletar oficina
+// @Description Remove uma oficina do sistema e das inscrições dos voluntários; os registros de presença são mantidos
+// @Tags workshops
+// @Produce json
+// @Param id path string true "ID da oficina"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workshops/{id} [delete]
+func (h *WorkshopHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.workshopService.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Cancel godoc
+// @Summary Cancelar oficina
+// @Description Marca uma oficina como cancelada
+// @Tags workshops
+// @Produce json
+// @Param id path string true "ID da oficina"
+// @Success 200 {object} models.WorkshopResponse
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/workshops/{id}/cancel [post]
+func (h *WorkshopHandler) Cancel(c *gin.Context) {
+	id := c.Param("id")
+
+	workshop, err := h.workshopService.Cancel(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(workshopErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workshop)
+}
+
+// AddCoordinator godoc
+// @Summary Adicionar coordenador
+// @Description Vincula um usuário à coordenação da oficina; com a permissão workshops:coordinate ele gerencia inscrições e presenças dela (requer workshops:manage)
+// @Tags workshops
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da oficina"
+// @Param user_id path string true "ID do usuário"
+// @Success 200 {object} models.WorkshopResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/workshops/{id}/coordinators/{user_id} [post]
+func (h *WorkshopHandler) AddCoordinator(c *gin.Context) {
+	workshop, err := h.workshopService.AddCoordinator(c.Request.Context(), c.Param("id"), c.Param("user_id"))
+	if err != nil {
+		c.JSON(workshopErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workshop)
+}
+
+// RemoveCoordinator godoc
+// @Summary Remover coordenador
+// @Description Desvincula um usuário da coordenação da oficina (requer workshops:manage)
+// @Tags workshops
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da oficina"
+// @Param user_id path string true "ID do usuário"
+// @Success 200 {object} models.WorkshopResponse
+// @Failure 404 {object} map[string]string
+// @Router /api/workshops/{id}/coordinators/{user_id} [delete]
+func (h *WorkshopHandler) RemoveCoordinator(c *gin.Context) {
+	workshop, err := h.workshopService.RemoveCoordinator(c.Request.Context(), c.Param("id"), c.Param("user_id"))
+	if err != nil {
+		c.JSON(workshopErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, workshop)
+}
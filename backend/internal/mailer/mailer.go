@@ -0,0 +1,203 @@
+// Package mailer envia os emails da aplicação. O envio é feito por SMTP em
+// produção; em desenvolvimento as mensagens podem ser apenas registradas no
+// log ou gravadas em arquivos.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Drivers de envio suportados
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+	DriverFile = "file"
+)
+
+// ErrInvalidMessage é retornado quando a mensagem não tem destinatário ou assunto
+var ErrInvalidMessage = errors.New("mensagem sem destinatário ou assunto")
+
+// Message representa um email em texto simples
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer define a interface de envio de emails
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config reúne as configurações do envio de emails
+type Config struct {
+	Driver   string `json:"driver"` // smtp, log ou file
+	From     string `json:"from"`
+	Host     string `json:"smtp_host"`
+	Port     string `json:"smtp_port"`
+	Username string `json:"smtp_username"`
+	Password string `json:"smtp_password"`
+	Dir      string `json:"dir"` // diretório usado pelo driver file
+}
+
+// New cria o Mailer do driver configurado
+func New(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		if cfg.Host == "" {
+			return nil, errors.New("SMTP_HOST é obrigatório para o driver smtp")
+		}
+		return NewSMTPMailer(cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.From), nil
+	case DriverLog:
+		return NewLogMailer(cfg.From), nil
+	case DriverFile:
+		return NewFileMailer(cfg.Dir, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("driver de email inválido: %q (use smtp, log ou file)", cfg.Driver)
+	}
+}
+
+// SMTPMailer envia emails por um servidor SMTP
+type SMTPMailer struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer cria um Mailer SMTP; sem usuário, o envio é feito sem autenticação
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		host: host,
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send envia a mensagem pelo servidor SMTP
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	data, err := format(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(m.addr, m.auth, address(m.from), []string{msg.To}, data)
+}
+
+// LogMailer apenas registra as mensagens no log; útil em desenvolvimento
+type LogMailer struct {
+	from   string
+	logger *log.Logger
+}
+
+// NewLogMailer cria um Mailer que escreve as mensagens no log padrão
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{
+		from:   from,
+		logger: log.Default(),
+	}
+}
+
+// Send registra a mensagem no log
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	data, err := format(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	m.logger.Printf("email não enviado (driver log):\n%s", data)
+	return nil
+}
+
+// FileMailer grava cada mensagem em um arquivo .eml; útil em desenvolvimento
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer cria um Mailer que grava as mensagens no diretório informado
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send grava a mensagem em um novo arquivo no diretório
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := format(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000000"), sanitize(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o600)
+}
+
+// format monta a mensagem no formato RFC 5322, em texto simples UTF-8
+func format(from string, msg Message, date time.Time) ([]byte, error) {
+	if msg.To == "" || msg.Subject == "" {
+		return nil, ErrInvalidMessage
+	}
+
+	// Impede a injeção de cabeçalhos pelos campos da mensagem
+	if strings.ContainsAny(msg.To+msg.Subject+from, "\r\n") {
+		return nil, ErrInvalidMessage
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return []byte(b.String()), nil
+}
+
+// address extrai o endereço de um remetente no formato "Nome <email>"
+func address(from string) string {
+	if start := strings.LastIndex(from, "<"); start >= 0 {
+		if end := strings.LastIndex(from, ">"); end > start {
+			return from[start+1 : end]
+		}
+	}
+	return from
+}
+
+// sanitize mantém apenas caracteres seguros para nomes de arquivo
+func sanitize(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
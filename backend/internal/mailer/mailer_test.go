@@ -0,0 +1,71 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+	msg := Message{To: "user@example.com", Subject: "Redefinição de senha", Body: "linha 1\nlinha 2"}
+
+	data, err := format("ELLP <no-reply@ellp.local>", msg, date)
+	if err != nil {
+		t.Fatalf("format() error = %v", err)
+	}
+
+	text := string(data)
+	for _, want := range []string{
+		"From: ELLP <no-reply@ellp.local>\r\n",
+		"To: user@example.com\r\n",
+		"Subject: =?utf-8?q?Redefini=C3=A7=C3=A3o_de_senha?=\r\n",
+		"\r\n\r\nlinha 1\r\nlinha 2",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("format() = %q, want it to contain %q", text, want)
+		}
+	}
+
+	// Quebras de linha nos cabeçalhos permitiriam injetar outros cabeçalhos
+	msg.To = "user@example.com\r\nBcc: other@example.com"
+	if _, err := format("no-reply@ellp.local", msg, date); err != ErrInvalidMessage {
+		t.Errorf("format() with header injection error = %v, want %v", err, ErrInvalidMessage)
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir, "no-reply@ellp.local")
+
+	if err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Teste", Body: "Olá"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("Send() wrote %d files, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if !strings.Contains(string(data), "To: user@example.com") {
+		t.Errorf("written message = %q", data)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Driver: DriverSMTP}); err == nil {
+		t.Error("New() with smtp driver and no host should fail")
+	}
+	if _, err := New(Config{Driver: "pombo"}); err == nil {
+		t.Error("New() with unknown driver should fail")
+	}
+	if m, err := New(Config{Driver: DriverLog}); err != nil || m == nil {
+		t.Errorf("New() with log driver = %v, %v", m, err)
+	}
+	if got := address("ELLP <no-reply@ellp.local>"); got != "no-reply@ellp.local" {
+		t.Errorf("address() = %v", got)
+	}
+}
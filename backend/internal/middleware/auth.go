@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"ellp-volunter-platform/backend/internal/config"
+	"ellp-volunter-platform/backend/internal/models"
 	"ellp-volunter-platform/backend/internal/repositories"
 	"net/http"
 	"strings"
@@ -9,15 +11,20 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// actorKey é a chave do usuário autenticado (*models.Actor) no contexto do gin
+const actorKey = "actor"
+
 // AuthMiddleware é a estrutura que gerencia autenticação
 type AuthMiddleware struct {
 	userRepo repositories.UserRepository
+	roleRepo repositories.RoleRepository
 }
 
 // NewAuthMiddleware cria uma nova instância do middleware de autenticação
-func NewAuthMiddleware(userRepo repositories.UserRepository) *AuthMiddleware {
+func NewAuthMiddleware(userRepo repositories.UserRepository, roleRepo repositories.RoleRepository) *AuthMiddleware {
 	return &AuthMiddleware{
 		userRepo: userRepo,
+		roleRepo: roleRepo,
 	}
 }
 
@@ -44,7 +51,7 @@ func (am *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		claims, err := config.ValidateToken(tokenString)
+		claims, err := config.ValidateAccessToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Token inválido ou expirado",
@@ -53,11 +60,31 @@ func (am *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Usuários desativados perdem o acesso imediatamente, e alterações
+		// de papel valem sem esperar o token expirar
+		if am.userRepo != nil {
+			user, err := am.userRepo.FindByID(c.Request.Context(), claims.UserID)
+			if err != nil || !user.IsActive {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Usuário inativo ou inexistente",
+				})
+				c.Abort()
+				return
+			}
+			claims.Role = user.Role
+		}
+
+		permissions, err := am.permissions(c.Request.Context(), claims.Role)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Erro ao verificar permissões",
+			})
+			c.Abort()
+			return
+		}
+
 		// Adiciona as claims ao contexto para uso posterior
-		c.Set("claims", claims)
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		setActor(c, claims, permissions)
 
 		c.Next()
 	}
@@ -86,7 +113,7 @@ func AuthMiddlewareFunc() gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		claims, err := config.ValidateToken(tokenString)
+		claims, err := config.ValidateAccessToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Token inválido ou expirado",
@@ -96,15 +123,121 @@ func AuthMiddlewareFunc() gin.HandlerFunc {
 		}
 
 		// Adiciona as claims ao contexto para uso posterior
-		c.Set("claims", claims)
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		setActor(c, claims, defaultPermissions(claims.Role))
+
+		c.Next()
+	}
+}
+
+// permissions retorna as permissões do papel gravado no banco; sem o
+// repositório ou sem o papel gravado, valem as permissões dos papéis padrão
+func (am *AuthMiddleware) permissions(ctx context.Context, roleName string) ([]string, error) {
+	if am.roleRepo == nil {
+		return defaultPermissions(roleName), nil
+	}
+
+	role, err := am.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		if err == repositories.ErrRoleNotFound {
+			return defaultPermissions(roleName), nil
+		}
+		return nil, err
+	}
+
+	return role.Permissions, nil
+}
+
+// defaultPermissions retorna as permissões do papel padrão com o nome informado
+func defaultPermissions(roleName string) []string {
+	if role, ok := models.DefaultRole(roleName); ok {
+		return role.Permissions
+	}
+	return nil
+}
+
+// setActor grava o usuário autenticado no contexto do gin e no contexto da
+// requisição, onde os serviços podem consultá-lo com models.ActorFromContext
+func setActor(c *gin.Context, claims *config.Claims, permissions []string) {
+	actor := &models.Actor{
+		UserID:      claims.UserID,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		Permissions: permissions,
+	}
+
+	c.Set("claims", claims)
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+	c.Set(actorKey, actor)
+	c.Request = c.Request.WithContext(models.WithActor(c.Request.Context(), actor))
+}
+
+// CurrentActor retorna o usuário autenticado da requisição
+func CurrentActor(c *gin.Context) (*models.Actor, bool) {
+	value, exists := c.Get(actorKey)
+	if !exists {
+		return nil, false
+	}
+	actor, ok := value.(*models.Actor)
+	return actor, ok
+}
+
+// RequirePermission middleware que exige todas as permissões informadas no
+// papel do usuário autenticado
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, exists := CurrentActor(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Não autenticado",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !actor.Can(permission) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "Acesso negado. Permissão insuficiente",
+				})
+				c.Abort()
+				return
+			}
+		}
 
 		c.Next()
 	}
 }
 
+// RequireAnyPermission middleware que exige ao menos uma das permissões
+// informadas no papel do usuário autenticado. Usado em rotas cuja verificação
+// final depende do recurso, como as de coordenadores de oficinas.
+func RequireAnyPermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, exists := CurrentActor(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Não autenticado",
+			})
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if actor.Can(permission) {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Acesso negado. Permissão insuficiente",
+		})
+		c.Abort()
+	}
+}
+
 // RequireRole middleware que verifica se o usuário tem uma role específica
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -163,12 +296,9 @@ func OptionalAuth() gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		claims, err := config.ValidateToken(tokenString)
+		claims, err := config.ValidateAccessToken(tokenString)
 		if err == nil {
-			c.Set("claims", claims)
-			c.Set("user_id", claims.UserID)
-			c.Set("user_email", claims.Email)
-			c.Set("user_role", claims.Role)
+			setActor(c, claims, defaultPermissions(claims.Role))
 		}
 
 		c.Next()
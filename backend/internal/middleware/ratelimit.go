@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter limita as requisições por chave (ex.: IP) com um token bucket
+// em memória: cada chave tem até limit requisições, repostas gradualmente ao
+// longo de window.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     float64
+	rate      float64 // requisições repostas por segundo
+	window    time.Duration
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimiter cria um limitador de limit requisições por window
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   float64(limit),
+		rate:    float64(limit) / window.Seconds(),
+		window:  window,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow consome uma requisição da chave. Quando o limite foi atingido, retorna
+// false e o tempo até a próxima requisição permitida.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: l.limit, updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.limit, b.tokens+now.Sub(b.updated).Seconds()*l.rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// sweep remove as chaves que já recuperaram todo o limite, para que o mapa
+// não cresça indefinidamente
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.window {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit limita as requisições por IP do cliente; excedido o limite,
+// responde 429 com o cabeçalho Retry-After. O IP vem de c.ClientIP, que só
+// considera o X-Forwarded-For enviado pelos proxies configurados no router
+// com SetTrustedProxies.
+func RateLimit(limiter *RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, wait := limiter.Allow(c.ClientIP())
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       "Muitas requisições. Tente novamente mais tarde",
+				"retry_after": retryAfter,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
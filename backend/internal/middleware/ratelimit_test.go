@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(3, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := limiter.Allow("10.0.0.1"); !ok {
+			t.Fatalf("Allow() request %d = false, want true", i+1)
+		}
+	}
+
+	ok, wait := limiter.Allow("10.0.0.1")
+	if ok {
+		t.Fatal("Allow() over the limit = true, want false")
+	}
+	if wait != 20*time.Second {
+		t.Errorf("Allow() wait = %v, want 20s", wait)
+	}
+
+	// Cada IP tem seu próprio limite
+	if ok, _ := limiter.Allow("10.0.0.2"); !ok {
+		t.Error("Allow() for another key = false, want true")
+	}
+
+	// O limite é reposto aos poucos
+	now = now.Add(20 * time.Second)
+	if ok, _ := limiter.Allow("10.0.0.1"); !ok {
+		t.Error("Allow() after refill = false, want true")
+	}
+
+	// Chaves ociosas são descartadas
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("10.0.0.3")
+	if len(limiter.buckets) != 1 {
+		t.Errorf("buckets = %d, want 1 after sweep", len(limiter.buckets))
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/login", RateLimit(NewRateLimiter(1, time.Minute)), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	codes := []int{}
+	var retryAfter string
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+		retryAfter = w.Header().Get("Retry-After")
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("status codes = %v, want [200 429]", codes)
+	}
+	if retryAfter != "60" {
+		t.Errorf("Retry-After = %q, want 60", retryAfter)
+	}
+}
+
+func TestRateLimit_ForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	request := func(router *gin.Engine, remoteAddr, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		router := gin.New()
+		if err := router.SetTrustedProxies(trustedProxies); err != nil {
+			t.Fatalf("SetTrustedProxies() error = %v", err)
+		}
+		router.POST("/login", RateLimit(NewRateLimiter(1, time.Minute)), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	// Sem proxies confiáveis, trocar o X-Forwarded-For não gera um novo limite
+	router := newRouter(nil)
+	codes := []int{
+		request(router, "192.0.2.1:1234", "203.0.113.1"),
+		request(router, "192.0.2.1:1234", "203.0.113.2"),
+		request(router, "192.0.2.1:1234", "203.0.113.3"),
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("status codes with rotating X-Forwarded-For = %v, want [200 429 429]", codes)
+	}
+
+	// Atrás de um proxy confiável, cada cliente encaminhado tem seu próprio limite
+	router = newRouter([]string{"192.0.2.1"})
+	codes = []int{
+		request(router, "192.0.2.1:1234", "203.0.113.1"),
+		request(router, "192.0.2.1:1234", "203.0.113.2"),
+		request(router, "192.0.2.1:1234", "203.0.113.1"),
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("status codes behind trusted proxy = %v, want [200 200 429]", codes)
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import "context"
+
+// Actor representa o usuário autenticado que executa a requisição, com as
+// permissões concedidas pelo seu papel
+type Actor struct {
+	UserID      string
+	Email       string
+	Role        string
+	Permissions []string
+}
+
+// Can verifica se o usuário tem a permissão
+func (a *Actor) Can(permission string) bool {
+	if a == nil {
+		return false
+	}
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// actorKey é a chave do Actor no context.Context da requisição
+type actorKey struct{}
+
+// WithActor retorna uma cópia do contexto com o usuário autenticado
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext retorna o usuário autenticado gravado no contexto, se houver
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(*Actor)
+	return actor, ok && actor != nil
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxAttendanceHours é o limite de horas de um único registro de presença
+const MaxAttendanceHours = 24
+
+// Attendance representa a presença de um voluntário em uma sessão de oficina
+type Attendance struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VolunteerID string             `json:"volunteer_id" bson:"volunteer_id"`
+	WorkshopID  string             `json:"workshop_id" bson:"workshop_id"`
+	Date        time.Time          `json:"date" bson:"date"` // dia da sessão
+	CheckIn     *time.Time         `json:"check_in,omitempty" bson:"check_in,omitempty"`
+	CheckOut    *time.Time         `json:"check_out,omitempty" bson:"check_out,omitempty"`
+	Hours       float64            `json:"hours" bson:"hours"`
+	Notes       string             `json:"notes" bson:"notes"`
+	RecordedBy  string             `json:"recorded_by" bson:"recorded_by"` // ID do usuário que registrou
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// RecordAttendanceRequest representa o payload para lançar horas manualmente
+// (informando hours) ou a partir dos horários de entrada e saída
+type RecordAttendanceRequest struct {
+	VolunteerID string     `json:"volunteer_id" binding:"required"`
+	WorkshopID  string     `json:"workshop_id" binding:"required"`
+	Date        time.Time  `json:"date"`
+	CheckIn     *time.Time `json:"check_in"`
+	CheckOut    *time.Time `json:"check_out"`
+	Hours       *float64   `json:"hours" binding:"omitempty,gte=0"`
+	Notes       string     `json:"notes"`
+}
+
+// CheckInRequest representa o payload para registrar a entrada de um voluntário
+type CheckInRequest struct {
+	VolunteerID string     `json:"volunteer_id" binding:"required"`
+	WorkshopID  string     `json:"workshop_id" binding:"required"`
+	Time        *time.Time `json:"time"` // padrão: agora
+}
+
+// CheckOutRequest representa o payload para registrar a saída de um voluntário
+type CheckOutRequest struct {
+	Time *time.Time `json:"time"` // padrão: agora
+}
+
+// UpdateAttendanceRequest representa o payload para corrigir um registro de presença
+type UpdateAttendanceRequest struct {
+	Date     time.Time  `json:"date"`
+	CheckIn  *time.Time `json:"check_in"`
+	CheckOut *time.Time `json:"check_out"`
+	Hours    *float64   `json:"hours" binding:"omitempty,gte=0"`
+	Notes    *string    `json:"notes"`
+}
+
+// AttendanceResponse representa a resposta da API
+type AttendanceResponse struct {
+	ID          primitive.ObjectID `json:"id"`
+	VolunteerID string             `json:"volunteer_id"`
+	WorkshopID  string             `json:"workshop_id"`
+	Date        time.Time          `json:"date"`
+	CheckIn     *time.Time         `json:"check_in,omitempty"`
+	CheckOut    *time.Time         `json:"check_out,omitempty"`
+	Hours       float64            `json:"hours"`
+	IsOpen      bool               `json:"is_open"`
+	Notes       string             `json:"notes"`
+	RecordedBy  string             `json:"recorded_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// IsOpen indica se o voluntário fez check-in e ainda não fez check-out
+func (a *Attendance) IsOpen() bool {
+	return a.CheckIn != nil && a.CheckOut == nil
+}
+
+// ComputeHours recalcula as horas a partir dos horários de entrada e saída, se houver
+func (a *Attendance) ComputeHours() {
+	if a.CheckIn != nil && a.CheckOut != nil {
+		a.Hours = RoundHours(a.CheckOut.Sub(*a.CheckIn).Hours())
+	}
+}
+
+// Validate valida os dados do registro de presença
+func (a *Attendance) Validate() error {
+	if a.VolunteerID == "" {
+		return errors.New("voluntário é obrigatório")
+	}
+
+	if a.WorkshopID == "" {
+		return errors.New("oficina é obrigatória")
+	}
+
+	if a.Date.IsZero() {
+		return errors.New("data da sessão é obrigatória")
+	}
+
+	if a.CheckOut != nil && a.CheckIn == nil {
+		return errors.New("check-out exige um check-in")
+	}
+
+	if a.CheckIn != nil && a.CheckOut != nil && !a.CheckOut.After(*a.CheckIn) {
+		return errors.New("check-out deve ser posterior ao check-in")
+	}
+
+	if a.Hours < 0 || a.Hours > MaxAttendanceHours {
+		return errors.New("horas devem estar entre 0 e 24")
+	}
+
+	return nil
+}
+
+// ToResponse converte um Attendance para AttendanceResponse
+func (a *Attendance) ToResponse() AttendanceResponse {
+	return AttendanceResponse{
+		ID:          a.ID,
+		VolunteerID: a.VolunteerID,
+		WorkshopID:  a.WorkshopID,
+		Date:        a.Date,
+		CheckIn:     a.CheckIn,
+		CheckOut:    a.CheckOut,
+		Hours:       a.Hours,
+		IsOpen:      a.IsOpen(),
+		Notes:       a.Notes,
+		RecordedBy:  a.RecordedBy,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+// NewAttendanceFromRequest cria um novo registro de presença a partir do request
+func NewAttendanceFromRequest(req RecordAttendanceRequest, recordedBy string) *Attendance {
+	now := time.Now()
+	attendance := &Attendance{
+		VolunteerID: req.VolunteerID,
+		WorkshopID:  req.WorkshopID,
+		Date:        req.Date,
+		CheckIn:     req.CheckIn,
+		CheckOut:    req.CheckOut,
+		Notes:       req.Notes,
+		RecordedBy:  recordedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	// Sem data explícita, a sessão é o dia do check-in
+	if attendance.Date.IsZero() && attendance.CheckIn != nil {
+		attendance.Date = *attendance.CheckIn
+	}
+
+	if req.Hours != nil {
+		attendance.Hours = RoundHours(*req.Hours)
+	} else {
+		attendance.ComputeHours()
+	}
+
+	return attendance
+}
+
+// RoundHours arredonda as horas para duas casas decimais
+func RoundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ações registradas na trilha de auditoria
+const (
+	AuditActionCreate     = "create"
+	AuditActionUpdate     = "update"
+	AuditActionDelete     = "delete"
+	AuditActionInactivate = "inactivate"
+	AuditActionReactivate = "reactivate"
+	AuditActionCancel     = "cancel"
+	AuditActionEnroll     = "enroll"
+	AuditActionUnenroll   = "unenroll"
+	AuditActionRestore    = "restore"
+	AuditActionPurge      = "purge"
+
+	AuditActionChangePassword = "change_password"
+)
+
+// Entidades registradas na trilha de auditoria
+const (
+	AuditEntityVolunteer   = "volunteer"
+	AuditEntityWorkshop    = "workshop"
+	AuditEntityAttendance  = "attendance"
+	AuditEntityCertificate = "certificate"
+	AuditEntityUser        = "user"
+	AuditEntityRole        = "role"
+	AuditEntityInvite      = "invite"
+	AuditEntitySettings    = "settings"
+)
+
+// AuditEntry representa um registro da trilha de auditoria. Os registros são
+// apenas inseridos, nunca alterados ou removidos.
+type AuditEntry struct {
+	ID         primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
+	ActorID    string                 `json:"actor_id" bson:"actor_id"` // vazio em operações internas
+	ActorEmail string                 `json:"actor_email" bson:"actor_email"`
+	ActorRole  string                 `json:"actor_role" bson:"actor_role"`
+	Action     string                 `json:"action" bson:"action"`
+	Entity     string                 `json:"entity" bson:"entity"`
+	EntityID   string                 `json:"entity_id" bson:"entity_id"`
+	Before     map[string]interface{} `json:"before,omitempty" bson:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty" bson:"after,omitempty"`
+	Changes    []AuditChange          `json:"changes" bson:"changes"`
+	CreatedAt  time.Time              `json:"created_at" bson:"created_at"`
+}
+
+// AuditChange representa a alteração de um campo entre o antes e o depois
+type AuditChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+// AuditSnapshot converte um valor na sua representação JSON, a mesma exposta
+// pela API. Campos ocultos no JSON, como senhas, não entram na auditoria.
+// Retorna nil para valores nulos.
+func AuditSnapshot(value interface{}) map[string]interface{} {
+	if value == nil {
+		return nil
+	}
+	if snapshot, ok := value.(map[string]interface{}); ok {
+		return snapshot
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var snapshot map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// NewAuditEntry cria um registro de auditoria com o antes, o depois e a lista
+// de campos alterados. O usuário pode ser nil em operações internas.
+func NewAuditEntry(actor *Actor, action, entity, entityID string, before, after interface{}) *AuditEntry {
+	entry := &AuditEntry{
+		Action:    action,
+		Entity:    entity,
+		EntityID:  entityID,
+		Before:    AuditSnapshot(before),
+		After:     AuditSnapshot(after),
+		CreatedAt: time.Now(),
+	}
+	if actor != nil {
+		entry.ActorID = actor.UserID
+		entry.ActorEmail = actor.Email
+		entry.ActorRole = actor.Role
+	}
+	entry.Changes = DiffSnapshots(entry.Before, entry.After)
+	return entry
+}
+
+// DiffSnapshots lista os campos que diferem entre dois snapshots, em ordem alfabética
+func DiffSnapshots(before, after map[string]interface{}) []AuditChange {
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+
+	changes := []AuditChange{}
+	for _, field := range names {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, AuditChange{
+				Field:  field,
+				Before: before[field],
+				After:  after[field],
+			})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,19 @@
+package models
+
+import "testing"
+
+func TestDiffSnapshots(t *testing.T) {
+	before := map[string]interface{}{"name": "A", "email": "a@example.com", "phone": "1"}
+	after := map[string]interface{}{"name": "B", "email": "a@example.com", "course": "BCC"}
+
+	changes := DiffSnapshots(before, after)
+	fields := []string{"course", "name", "phone"}
+	if len(changes) != len(fields) {
+		t.Fatalf("DiffSnapshots() = %+v, want fields %v", changes, fields)
+	}
+	for i, field := range fields {
+		if changes[i].Field != field {
+			t.Errorf("changes[%d].Field = %q, want %q", i, changes[i].Field, field)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status de verificação de um certificado
+const (
+	CertificateStatusValid   = "valid"
+	CertificateStatusRevoked = "revoked"
+)
+
+// Certificate representa um certificado de participação emitido para um voluntário.
+// Os dados são gravados no momento da emissão e não são recalculados depois.
+type Certificate struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Code             string             `json:"code" bson:"code"` // código único de verificação
+	VolunteerID      string             `json:"volunteer_id" bson:"volunteer_id"`
+	VolunteerName    string             `json:"volunteer_name" bson:"volunteer_name"`
+	IsAcademic       bool               `json:"is_academic" bson:"is_academic"`
+	Course           string             `json:"course,omitempty" bson:"course,omitempty"`
+	RA               string             `json:"ra,omitempty" bson:"ra,omitempty"`
+	EntryDate        time.Time          `json:"entry_date" bson:"entry_date"`
+	ExitDate         *time.Time         `json:"exit_date,omitempty" bson:"exit_date,omitempty"`
+	Periods          []ServicePeriod    `json:"service_periods,omitempty" bson:"service_periods,omitempty"` // vazio em certificados anteriores aos períodos
+	TotalHours       float64            `json:"total_hours" bson:"total_hours"`                             // soma de todos os períodos
+	Workshops        []string           `json:"workshops" bson:"workshops"`                                 // títulos das oficinas
+	IssuedAt         time.Time          `json:"issued_at" bson:"issued_at"`
+	IssuedBy         string             `json:"issued_by" bson:"issued_by"` // ID do usuário que emitiu
+	RevokedAt        *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	RevokedBy        string             `json:"revoked_by,omitempty" bson:"revoked_by,omitempty"`
+	RevocationReason string             `json:"revocation_reason,omitempty" bson:"revocation_reason,omitempty"`
+}
+
+// RevokeCertificateRequest representa o payload para revogar um certificado
+type RevokeCertificateRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CertificateVerification representa a resposta pública de verificação de um
+// certificado, com apenas os dados necessários para confirmar sua autenticidade
+type CertificateVerification struct {
+	Code          string          `json:"code"`
+	Status        string          `json:"status"` // valid, revoked
+	VolunteerName string          `json:"volunteer_name"`
+	EntryDate     time.Time       `json:"entry_date"`
+	ExitDate      *time.Time      `json:"exit_date,omitempty"`
+	Periods       []ServicePeriod `json:"service_periods"`
+	TotalHours    float64         `json:"total_hours"`
+	IssuedAt      time.Time       `json:"issued_at"`
+	RevokedAt     *time.Time      `json:"revoked_at,omitempty"`
+}
+
+// ServicePeriods retorna os períodos de atuação impressos no certificado.
+// Certificados emitidos antes da lista de períodos têm um único período.
+func (c *Certificate) ServicePeriods() []ServicePeriod {
+	if len(c.Periods) > 0 {
+		return c.Periods
+	}
+	return []ServicePeriod{{EntryDate: c.EntryDate, ExitDate: c.ExitDate}}
+}
+
+// IsRevoked verifica se o certificado foi revogado
+func (c *Certificate) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
+
+// SameContent verifica se dois certificados trazem os mesmos dados impressos,
+// ignorando código, emissão e revogação
+func (c *Certificate) SameContent(other *Certificate) bool {
+	if c.VolunteerID != other.VolunteerID || c.VolunteerName != other.VolunteerName ||
+		c.IsAcademic != other.IsAcademic || c.Course != other.Course || c.RA != other.RA ||
+		c.TotalHours != other.TotalHours || !c.EntryDate.Equal(other.EntryDate) || !sameDate(c.ExitDate, other.ExitDate) {
+		return false
+	}
+
+	periods, otherPeriods := c.ServicePeriods(), other.ServicePeriods()
+	if len(periods) != len(otherPeriods) {
+		return false
+	}
+	for i := range periods {
+		if !periods[i].EntryDate.Equal(otherPeriods[i].EntryDate) || !sameDate(periods[i].ExitDate, otherPeriods[i].ExitDate) {
+			return false
+		}
+	}
+
+	if len(c.Workshops) != len(other.Workshops) {
+		return false
+	}
+	for i := range c.Workshops {
+		if c.Workshops[i] != other.Workshops[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameDate compara datas opcionais; duas datas vazias são iguais
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// ToVerification converte um Certificate para CertificateVerification
+func (c *Certificate) ToVerification() CertificateVerification {
+	status := CertificateStatusValid
+	if c.IsRevoked() {
+		status = CertificateStatusRevoked
+	}
+
+	return CertificateVerification{
+		Code:          c.Code,
+		Status:        status,
+		VolunteerName: c.VolunteerName,
+		EntryDate:     c.EntryDate,
+		ExitDate:      c.ExitDate,
+		Periods:       c.ServicePeriods(),
+		TotalHours:    c.TotalHours,
+		IssuedAt:      c.IssuedAt,
+		RevokedAt:     c.RevokedAt,
+	}
+}
+
+// NewCertificate cria um certificado a partir dos dados atuais do voluntário
+func NewCertificate(code string, volunteer *Volunteer, totalHours float64, workshops []string, issuedBy string) *Certificate {
+	return &Certificate{
+		Code:          code,
+		VolunteerID:   volunteer.ID.Hex(),
+		VolunteerName: volunteer.Name,
+		IsAcademic:    volunteer.IsAcademic,
+		Course:        volunteer.Course,
+		RA:            volunteer.RA,
+		EntryDate:     volunteer.EntryDate,
+		ExitDate:      volunteer.ExitDate,
+		Periods:       volunteer.ServicePeriods(),
+		TotalHours:    totalHours,
+		Workshops:     workshops,
+		IssuedAt:      time.Now(),
+		IssuedBy:      issuedBy,
+	}
+}
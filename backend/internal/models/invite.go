@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Invite representa um convite de cadastro emitido por um administrador. O
+// convite é de uso único e define o papel do usuário que se cadastrar com ele.
+type Invite struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TokenID   string             `json:"token_id" bson:"token_id"`               // JTI do token do convite
+	Email     string             `json:"email,omitempty" bson:"email,omitempty"` // se informado, só este email pode usar o convite
+	Role      string             `json:"role" bson:"role"`
+	CreatedBy string             `json:"created_by" bson:"created_by"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	UsedAt    *time.Time         `json:"used_at,omitempty" bson:"used_at,omitempty"`
+	UsedBy    string             `json:"used_by,omitempty" bson:"used_by,omitempty"` // ID do usuário cadastrado
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CreateInviteRequest representa os dados para emitir um convite
+type CreateInviteRequest struct {
+	Email          string `json:"email" binding:"omitempty,email"`
+	Role           string `json:"role" binding:"required"`
+	ExpiresInHours int    `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+// InviteResponse representa o convite emitido, com o token a ser enviado ao convidado
+type InviteResponse struct {
+	Invite
+	Token string `json:"token"`
+}
+
+// IsUsed indica se o convite já foi utilizado
+func (i *Invite) IsUsed() bool {
+	return i.UsedAt != nil
+}
+
+// IsExpired indica se o convite expirou
+func (i *Invite) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// SecuritySettings reúne as políticas de segurança ajustáveis pelos administradores
+type SecuritySettings struct {
+	RequireAdminMFA bool      `json:"require_admin_mfa" bson:"require_admin_mfa"` // exige TOTP de todos os administradores
+	UpdatedAt       time.Time `json:"updated_at" bson:"updated_at"`
+	UpdatedBy       string    `json:"updated_by,omitempty" bson:"updated_by,omitempty"`
+}
+
+// UpdateSecuritySettingsRequest representa a alteração das políticas de segurança
+type UpdateSecuritySettingsRequest struct {
+	RequireAdminMFA *bool `json:"require_admin_mfa" binding:"required"`
+}
+
+// MFAChallengeResponse é a resposta do login quando falta o segundo fator
+type MFAChallengeResponse struct {
+	MFARequired      bool   `json:"mfa_required"`
+	MFASetupRequired bool   `json:"mfa_setup_required"` // o usuário precisa cadastrar o autenticador antes
+	MFAToken         string `json:"mfa_token"`
+	ExpiresIn        int    `json:"expires_in"` // segundos
+}
+
+// MFAVerifyRequest representa a segunda etapa do login
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"` // código TOTP ou de recuperação
+}
+
+// MFASetupChallengeRequest representa o cadastro do autenticador durante o login
+type MFASetupChallengeRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// MFASetupResponse traz o segredo a ser cadastrado no aplicativo autenticador
+type MFASetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // conteúdo do QR code
+}
+
+// MFACodeRequest representa a confirmação de uma operação com um código TOTP
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFARecoveryCodesResponse traz os códigos de recuperação, exibidos uma única vez
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableMFARequest representa a desativação do segundo fator
+type DisableMFARequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Parâmetros de paginação das listagens
+const (
+	DefaultPage  = 1
+	DefaultLimit = 10
+	MaxLimit     = 100 // limite máximo de itens por página, independente do pedido
+)
+
+// ErrInvalidCursor é retornado quando o cursor de paginação não pode ser decodificado
+var ErrInvalidCursor = errors.New("cursor inválido")
+
+// Page representa uma página de uma listagem, no envelope comum a todas as
+// listagens paginadas da API. Nas listagens por cursor não há número de
+// página; a próxima é indicada por NextCursor.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // cursor do último item, ausente na última página
+	Next       string `json:"next,omitempty"`        // link para a próxima página, ausente na última
+	Prev       string `json:"prev,omitempty"`        // link para a página anterior, ausente na primeira
+}
+
+// Cursor identifica a posição de um registro na ordenação estável por
+// (created_at, _id), do mais recente para o mais antigo. Ao contrário do
+// número de página, a posição não muda quando novos registros são inseridos.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
+
+// NewCursor cria o cursor que aponta para depois do registro informado
+func NewCursor(createdAt time.Time, id primitive.ObjectID) *Cursor {
+	return &Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// Encode converte o cursor no valor opaco usado no parâmetro cursor
+func (c *Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "." + c.ID.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor lê o valor opaco recebido no parâmetro cursor
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, hex, found := strings.Cut(string(raw), ".")
+	if !found {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(hex)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return NewCursor(time.Unix(0, createdAt), id), nil
+}
+
+// NormalizePagination aplica os valores padrão de página e limite e restringe
+// o limite ao máximo permitido
+func NormalizePagination(page, limit int) (int, int) {
+	if page <= 0 {
+		page = DefaultPage
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return page, limit
+}
+
+// NewPage monta uma página com os itens já buscados e o total de registros
+// que correspondem ao filtro
+func NewPage[T any](items []T, total int64, page, limit int) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+
+	return &Page[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}
+}
+
+// SetLinks preenche os links da próxima página e da anterior a partir da URL
+// da requisição, mantendo os demais parâmetros da consulta. Nas listagens por
+// cursor há apenas o link da próxima página.
+func (p *Page[T]) SetLinks(requestURL *url.URL) {
+	p.Next, p.Prev = "", ""
+
+	if p.Page == 0 {
+		if p.NextCursor != "" {
+			p.Next = cursorLink(requestURL, p.NextCursor, p.Limit)
+		}
+		return
+	}
+
+	if p.Page < p.TotalPages {
+		p.Next = pageLink(requestURL, p.Page+1, p.Limit)
+	}
+	if p.Page > 1 {
+		// Uma página além da última volta para a última
+		prev := p.Page - 1
+		if prev > p.TotalPages {
+			prev = p.TotalPages
+		}
+		if prev >= 1 {
+			p.Prev = pageLink(requestURL, prev, p.Limit)
+		}
+	}
+}
+
+// pageLink monta o link relativo de uma página da mesma listagem
+func pageLink(requestURL *url.URL, page, limit int) string {
+	query := requestURL.Query()
+	query.Del("cursor")
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+
+	link := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return link.String()
+}
+
+// cursorLink monta o link relativo da página seguinte ao cursor
+func cursorLink(requestURL *url.URL, cursor string, limit int) string {
+	query := requestURL.Query()
+	query.Del("page")
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(limit))
+
+	link := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+	return link.String()
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNormalizePagination(t *testing.T) {
+	tests := []struct {
+		name      string
+		page      int
+		limit     int
+		wantPage  int
+		wantLimit int
+	}{
+		{"defaults", 0, 0, DefaultPage, DefaultLimit},
+		{"negative", -2, -5, DefaultPage, DefaultLimit},
+		{"requested", 3, 25, 3, 25},
+		{"above max", 1, MaxLimit + 1, 1, MaxLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := NormalizePagination(tt.page, tt.limit)
+			if page != tt.wantPage || limit != tt.wantLimit {
+				t.Errorf("NormalizePagination(%d, %d) = %d, %d; want %d, %d", tt.page, tt.limit, page, limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestPage_SetLinks(t *testing.T) {
+	requestURL, _ := url.Parse("/api/volunteers?name=ana&page=2&limit=5")
+
+	tests := []struct {
+		name     string
+		total    int64
+		page     int
+		wantNext string
+		wantPrev string
+	}{
+		{"middle", 12, 2, "/api/volunteers?limit=5&name=ana&page=3", "/api/volunteers?limit=5&name=ana&page=1"},
+		{"first", 12, 1, "/api/volunteers?limit=5&name=ana&page=2", ""},
+		{"last", 12, 3, "", "/api/volunteers?limit=5&name=ana&page=2"},
+		{"beyond last", 12, 7, "", "/api/volunteers?limit=5&name=ana&page=3"},
+		{"empty", 0, 1, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPage([]string{}, tt.total, tt.page, 5)
+			page.SetLinks(requestURL)
+			if page.Next != tt.wantNext || page.Prev != tt.wantPrev {
+				t.Errorf("SetLinks() next = %q, prev = %q; want %q, %q", page.Next, page.Prev, tt.wantNext, tt.wantPrev)
+			}
+		})
+	}
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := NewCursor(time.Date(2024, 3, 10, 14, 30, 0, 123000000, time.UTC), primitive.NewObjectID())
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID {
+		t.Errorf("DecodeCursor() = %+v, want %+v", decoded, cursor)
+	}
+
+	for _, value := range []string{"???", "c2VtLXBvbnRv", "YWJjLjEyMw"} {
+		if _, err := DecodeCursor(value); err != ErrInvalidCursor {
+			t.Errorf("DecodeCursor(%q) error = %v, want %v", value, err, ErrInvalidCursor)
+		}
+	}
+}
+
+func TestPage_SetLinksCursor(t *testing.T) {
+	requestURL, _ := url.Parse("/api/volunteers?page=2&limit=5")
+
+	page := NewPage([]string{}, 12, 0, 5)
+	page.NextCursor = "abc"
+	page.SetLinks(requestURL)
+	if page.Next != "/api/volunteers?cursor=abc&limit=5" || page.Prev != "" {
+		t.Errorf("SetLinks() next = %q, prev = %q; want cursor link only", page.Next, page.Prev)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PasswordReset representa um token de redefinição de senha enviado por email.
+// O token é de uso único e tem validade curta; apenas seu hash é gravado.
+type PasswordReset struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    string             `json:"user_id" bson:"user_id"`
+	TokenHash string             `json:"-" bson:"token_hash"`
+	ExpiresAt time.Time          `json:"expires_at" bson:"expires_at"`
+	UsedAt    *time.Time         `json:"used_at,omitempty" bson:"used_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// ForgotPasswordRequest representa o pedido de redefinição de senha
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmPasswordResetRequest representa a redefinição de senha com o token recebido por email
+type ConfirmPasswordResetRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// IsUsed indica se o token já foi utilizado
+func (p *PasswordReset) IsUsed() bool {
+	return p.UsedAt != nil
+}
+
+// IsExpired indica se o token expirou
+func (p *PasswordReset) IsExpired() bool {
+	return time.Now().After(p.ExpiresAt)
+}
+
+// NewPasswordReset cria o registro de um token de redefinição recém-gerado
+func NewPasswordReset(userID, token string, expiresAt time.Time) *PasswordReset {
+	return &PasswordReset{
+		UserID:    userID,
+		TokenHash: HashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PortalWorkshopResponse representa uma oficina vista pelo voluntário no portal,
+// sem os dados dos demais participantes
+type PortalWorkshopResponse struct {
+	ID             primitive.ObjectID `json:"id"`
+	Title          string             `json:"title"`
+	Description    string             `json:"description"`
+	Instructor     string             `json:"instructor"`
+	Date           time.Time          `json:"date"`
+	Location       string             `json:"location"`
+	Status         string             `json:"status"`
+	AvailableSeats *int               `json:"available_seats,omitempty"` // omitido quando não há limite de vagas
+	Enrollment     EnrollmentStatus   `json:"enrollment,omitempty"`      // situação do voluntário, se inscrito
+}
+
+// PortalHoursResponse representa as horas registradas do voluntário no portal
+type PortalHoursResponse struct {
+	TotalHours float64               `json:"total_hours"`
+	Records    []*AttendanceResponse `json:"records"`
+}
+
+// ToPortalResponse converte a oficina para a visão do voluntário informado
+func (w *Workshop) ToPortalResponse(volunteerID string) PortalWorkshopResponse {
+	var availableSeats *int
+	if !w.HasUnlimitedCapacity() {
+		seats := w.AvailableSeats()
+		availableSeats = &seats
+	}
+
+	var enrollment EnrollmentStatus
+	switch {
+	case w.IsEnrolled(volunteerID):
+		enrollment = EnrollmentEnrolled
+	case w.IsWaitlisted(volunteerID):
+		enrollment = EnrollmentWaitlisted
+	}
+
+	return PortalWorkshopResponse{
+		ID:             w.ID,
+		Title:          w.Title,
+		Description:    w.Description,
+		Instructor:     w.Instructor,
+		Date:           w.Date,
+		Location:       w.Location,
+		Status:         w.Status,
+		AvailableSeats: availableSeats,
+		Enrollment:     enrollment,
+	}
+}
+
+// IsOpenForEnrollment indica se a oficina aceita inscrições pelo portal:
+// agendada e ainda não realizada
+func (w *Workshop) IsOpenForEnrollment() bool {
+	return w.Status == WorkshopStatusScheduled && w.Date.After(time.Now())
+}
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Permissões verificadas nas rotas da API
+const (
+	PermVolunteersRead      = "volunteers:read"
+	PermVolunteersWrite     = "volunteers:write"
+	PermVolunteersDelete    = "volunteers:delete"
+	PermVolunteersPurge     = "volunteers:purge" // exclusão definitiva da lixeira
+	PermWorkshopsRead       = "workshops:read"
+	PermWorkshopsManage     = "workshops:manage"
+	PermWorkshopsCoordinate = "workshops:coordinate" // inscrições e presenças só nas oficinas que coordena
+	PermAttendanceRead      = "attendance:read"
+	PermAttendanceWrite     = "attendance:write"
+	PermCertificatesIssue   = "certificates:issue"
+	PermCertificatesRevoke  = "certificates:revoke"
+	PermUsersManage         = "users:manage"
+	PermRolesManage         = "roles:manage"
+	PermSettingsManage      = "settings:manage"
+	PermAuditRead           = "audit:read"
+	PermPortalAccess        = "portal:access" // portal do voluntário, restrito aos próprios dados
+)
+
+// AllPermissions lista todas as permissões conhecidas
+var AllPermissions = []string{
+	PermVolunteersRead,
+	PermVolunteersWrite,
+	PermVolunteersDelete,
+	PermVolunteersPurge,
+	PermWorkshopsRead,
+	PermWorkshopsManage,
+	PermWorkshopsCoordinate,
+	PermAttendanceRead,
+	PermAttendanceWrite,
+	PermCertificatesIssue,
+	PermCertificatesRevoke,
+	PermUsersManage,
+	PermRolesManage,
+	PermSettingsManage,
+	PermAuditRead,
+	PermPortalAccess,
+}
+
+// Papéis padrão, criados na inicialização se ainda não existirem
+const (
+	RoleAdmin       = "admin"
+	RoleMember      = "member"
+	RoleCoordinator = "coordinator"
+	RoleVolunteer   = "volunteer"
+)
+
+var (
+	// ErrInvalidRoleName é retornado quando o nome do papel tem formato inválido
+	ErrInvalidRoleName = errors.New("nome do papel deve ter de 2 a 32 caracteres: letras minúsculas, números, '-' ou '_'")
+	// ErrUnknownPermission é retornado quando o papel usa uma permissão inexistente
+	ErrUnknownPermission = errors.New("permissão desconhecida")
+)
+
+var roleNameRegex = regexp.MustCompile(`^[a-z0-9_-]{2,32}$`)
+
+// Role representa um papel de usuário e as permissões que ele concede
+type Role struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"` // identificador gravado em User.Role
+	Description string             `json:"description" bson:"description"`
+	Permissions []string           `json:"permissions" bson:"permissions"`
+	System      bool               `json:"system" bson:"system"` // papéis padrão não podem ser removidos
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// CreateRoleRequest representa os dados para criar um papel
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateRoleRequest representa os dados para alterar um papel
+type UpdateRoleRequest struct {
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// HasPermission verifica se o papel concede a permissão
+func (r *Role) HasPermission(permission string) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRoleName valida o formato do nome de um papel
+func ValidateRoleName(name string) error {
+	if !roleNameRegex.MatchString(name) {
+		return ErrInvalidRoleName
+	}
+	return nil
+}
+
+// ValidatePermissions verifica se todas as permissões são conhecidas
+func ValidatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		known := false
+		for _, p := range AllPermissions {
+			if p == permission {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %s", ErrUnknownPermission, permission)
+		}
+	}
+	return nil
+}
+
+// DefaultRoles retorna os papéis padrão do sistema. O administrador tem todas
+// as permissões; o membro consulta voluntários e oficinas e lança presenças,
+// mas não remove voluntários nem administra oficinas, usuários ou o sistema;
+// o coordenador gerencia inscrições e presenças apenas das oficinas que coordena
+// e o voluntário acessa somente o portal com os próprios dados.
+func DefaultRoles() []Role {
+	return []Role{
+		{
+			Name:        RoleAdmin,
+			Description: "Administrador",
+			Permissions: append([]string{}, AllPermissions...),
+			System:      true,
+		},
+		{
+			Name:        RoleMember,
+			Description: "Membro da equipe",
+			Permissions: []string{
+				PermVolunteersRead,
+				PermVolunteersWrite,
+				PermWorkshopsRead,
+				PermAttendanceRead,
+				PermAttendanceWrite,
+				PermCertificatesIssue,
+			},
+			System: true,
+		},
+		{
+			Name:        RoleCoordinator,
+			Description: "Coordenador de oficinas",
+			Permissions: []string{
+				PermVolunteersRead,
+				PermWorkshopsRead,
+				PermWorkshopsCoordinate,
+				PermAttendanceRead,
+			},
+			System: true,
+		},
+		{
+			Name:        RoleVolunteer,
+			Description: "Voluntário (portal)",
+			Permissions: []string{PermPortalAccess},
+			System:      true,
+		},
+	}
+}
+
+// DefaultRole retorna o papel padrão com o nome informado
+func DefaultRole(name string) (*Role, bool) {
+	for _, role := range DefaultRoles() {
+		if role.Name == name {
+			return &role, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session representa um refresh token emitido. Cada uso do token gera um novo
+// (rotação); todos os tokens originados do mesmo login formam uma família.
+type Session struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TokenID    string             `json:"token_id" bson:"token_id"`   // JTI do refresh token
+	FamilyID   string             `json:"family_id" bson:"family_id"` // JTI do primeiro token da família
+	UserID     string             `json:"user_id" bson:"user_id"`
+	TokenHash  string             `json:"-" bson:"token_hash"` // SHA-256 do token; o token em si não é gravado
+	ExpiresAt  time.Time          `json:"expires_at" bson:"expires_at"`
+	RotatedAt  *time.Time         `json:"rotated_at,omitempty" bson:"rotated_at,omitempty"`
+	ReplacedBy string             `json:"replaced_by,omitempty" bson:"replaced_by,omitempty"` // JTI do token seguinte
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// IsRotated indica se o token já foi trocado por um novo
+func (s *Session) IsRotated() bool {
+	return s.RotatedAt != nil
+}
+
+// IsRevoked indica se a sessão foi revogada
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsExpired indica se o refresh token já expirou
+func (s *Session) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// HashToken calcula o hash SHA-256 de um token (refresh ou redefinição de senha)
+// para que o token em si não seja gravado
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewSession cria a sessão de um refresh token recém-emitido
+func NewSession(tokenID, familyID, userID, token string, expiresAt time.Time) *Session {
+	return &Session{
+		TokenID:   tokenID,
+		FamilyID:  familyID,
+		UserID:    userID,
+		TokenHash: HashToken(token),
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+}
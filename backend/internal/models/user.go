@@ -15,10 +15,25 @@ type User struct {
 	Name      string             `json:"name" bson:"name" binding:"required"`
 	Email     string             `json:"email" bson:"email" binding:"required,email"`
 	Password  string             `json:"-" bson:"password" binding:"required"`
-	Role      string             `json:"role" bson:"role" binding:"required"` // "admin", "member"
+	Role      string             `json:"role" bson:"role" binding:"required"` // nome do papel (Role.Name)
 	IsActive  bool               `json:"is_active" bson:"is_active"`
 	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+
+	// Voluntário vinculado à conta, que acessa o portal do voluntário
+	VolunteerID string `json:"volunteer_id,omitempty" bson:"volunteer_id,omitempty"`
+
+	// Proteção contra força bruta no login
+	FailedLoginAttempts int        `json:"failed_login_attempts" bson:"failed_login_attempts"`
+	LastFailedLoginAt   *time.Time `json:"last_failed_login_at,omitempty" bson:"last_failed_login_at,omitempty"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty" bson:"locked_until,omitempty"`
+
+	// Autenticação em dois fatores (TOTP)
+	MFAEnabled       bool     `json:"mfa_enabled" bson:"mfa_enabled"`
+	MFASecret        string   `json:"-" bson:"mfa_secret,omitempty"`
+	MFAPendingSecret string   `json:"-" bson:"mfa_pending_secret,omitempty"` // segredo aguardando confirmação
+	MFARecoveryCodes []string `json:"-" bson:"mfa_recovery_codes,omitempty"` // hashes dos códigos de recuperação
+	MFALastUsedStep  int64    `json:"-" bson:"mfa_last_used_step,omitempty"` // impede reutilizar o mesmo código
 }
 
 // UserResponse representa a resposta da API sem expor a senha
@@ -30,6 +45,11 @@ type UserResponse struct {
 	IsActive  bool               `json:"is_active"`
 	CreatedAt time.Time          `json:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at"`
+
+	FailedLoginAttempts int        `json:"failed_login_attempts"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty"` // presente apenas durante o bloqueio
+	MFAEnabled          bool       `json:"mfa_enabled"`
+	VolunteerID         string     `json:"volunteer_id,omitempty"`
 }
 
 // LoginRequest representa os dados de login
@@ -38,12 +58,19 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
-// CreateUserRequest representa os dados para criar um usuário
+// RefreshTokenRequest representa o refresh token enviado para renovar ou encerrar a sessão
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// CreateUserRequest representa os dados para criar um usuário. O papel enviado
+// pelo cliente é ignorado no auto-cadastro: ele vem do convite ou é "member".
 type CreateUserRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
-	Role     string `json:"role" binding:"required,oneof=admin member"`
+	Name        string `json:"name" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+	Password    string `json:"password" binding:"required,min=8"`
+	Role        string `json:"role"`
+	InviteToken string `json:"invite_token"`
 }
 
 // UpdateUserRequest representa os dados para atualizar um usuário
@@ -51,10 +78,37 @@ type UpdateUserRequest struct {
 	Name     string `json:"name"`
 	Email    string `json:"email" binding:"omitempty,email"`
 	Password string `json:"password" binding:"omitempty,min=8"`
-	Role     string `json:"role" binding:"omitempty,oneof=admin member"`
+	Role     string `json:"role"`
 	IsActive *bool  `json:"is_active"`
 }
 
+// UpdateProfileRequest representa os dados que o próprio usuário pode alterar
+type UpdateProfileRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+// ChangePasswordRequest representa a troca de senha pelo próprio usuário
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// UpdateUserRoleRequest representa os dados para alterar o papel de um usuário
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// LinkVolunteerRequest representa o voluntário a ser vinculado à conta do usuário
+type LinkVolunteerRequest struct {
+	VolunteerID string `json:"volunteer_id" binding:"required"`
+}
+
+// ResetPasswordRequest representa a nova senha definida por um administrador
+type ResetPasswordRequest struct {
+	Password string `json:"password" binding:"required,min=8"`
+}
+
 var (
 	// ErrInvalidEmail é retornado quando o email é inválido
 	ErrInvalidEmail = errors.New("email inválido")
@@ -122,9 +176,27 @@ func (u *User) ToResponse() UserResponse {
 		IsActive:  u.IsActive,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+
+		FailedLoginAttempts: u.FailedLoginAttempts,
+		LockedUntil:         u.activeLock(),
+		MFAEnabled:          u.MFAEnabled,
+		VolunteerID:         u.VolunteerID,
 	}
 }
 
+// IsLocked indica se o login do usuário está temporariamente bloqueado
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && time.Now().Before(*u.LockedUntil)
+}
+
+// activeLock retorna o fim do bloqueio, se ele ainda estiver em vigor
+func (u *User) activeLock() *time.Time {
+	if !u.IsLocked() {
+		return nil
+	}
+	return u.LockedUntil
+}
+
 // Validate valida os campos do usuário
 func (u *User) Validate() error {
 	if err := ValidateEmail(u.Email); err != nil {
@@ -138,8 +210,9 @@ func (u *User) Validate() error {
 		}
 	}
 
-	if u.Role != "admin" && u.Role != "member" {
-		return errors.New("role deve ser 'admin' ou 'member'")
+	// A existência do papel é verificada pelos serviços, pois os papéis ficam no banco
+	if err := ValidateRoleName(u.Role); err != nil {
+		return err
 	}
 
 	return nil
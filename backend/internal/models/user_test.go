@@ -169,7 +169,7 @@ func TestUser_Validate(t *testing.T) {
 				Name:     "Test User",
 				Email:    "test@example.com",
 				Password: "TestPass123",
-				Role:     "invalid",
+				Role:     "Papel Inválido",
 			},
 			wantErr: true,
 		},
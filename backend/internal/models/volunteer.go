@@ -8,7 +8,22 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-// Volunteer representa um voluntário do projeto ELLP
+var (
+	// ErrVolunteerAlreadyInactive é retornado ao inativar um voluntário inativo
+	ErrVolunteerAlreadyInactive = errors.New("voluntário já está inativo")
+	// ErrVolunteerAlreadyActive é retornado ao reativar um voluntário ativo
+	ErrVolunteerAlreadyActive = errors.New("voluntário já está ativo")
+)
+
+// ServicePeriod representa um período de atuação do voluntário no projeto
+type ServicePeriod struct {
+	EntryDate time.Time  `json:"entry_date" bson:"entry_date"`
+	ExitDate  *time.Time `json:"exit_date,omitempty" bson:"exit_date,omitempty"` // vazio no período em andamento
+}
+
+// Volunteer representa um voluntário do projeto ELLP. EntryDate é a primeira
+// entrada no projeto e ExitDate a saída do último período; os períodos de
+// atuação ficam em ServicePeriods, em ordem cronológica.
 type Volunteer struct {
 	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Name       string             `json:"name" bson:"name" binding:"required"`
@@ -20,7 +35,10 @@ type Volunteer struct {
 	EntryDate  time.Time          `json:"entry_date" bson:"entry_date" binding:"required"`
 	ExitDate   *time.Time         `json:"exit_date,omitempty" bson:"exit_date,omitempty"`
 	IsActive   bool               `json:"is_active" bson:"is_active"`
-	Workshops  []string           `json:"workshops" bson:"workshops"` // IDs das oficinas
+	Periods    []ServicePeriod    `json:"service_periods" bson:"service_periods,omitempty"`
+	Workshops  []string           `json:"workshops" bson:"workshops"`                       // IDs das oficinas
+	DeletedAt  *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"` // preenchido enquanto está na lixeira
+	DeletedBy  string             `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"` // ID do usuário que moveu para a lixeira
 	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -52,6 +70,11 @@ type InactivateVolunteerRequest struct {
 	ExitDate time.Time `json:"exit_date" binding:"required"`
 }
 
+// ReactivateVolunteerRequest representa o payload para reativar um voluntário
+type ReactivateVolunteerRequest struct {
+	EntryDate time.Time `json:"entry_date" binding:"required"`
+}
+
 // VolunteerResponse representa a resposta da API
 type VolunteerResponse struct {
 	ID         primitive.ObjectID `json:"id"`
@@ -64,7 +87,11 @@ type VolunteerResponse struct {
 	EntryDate  time.Time          `json:"entry_date"`
 	ExitDate   *time.Time         `json:"exit_date,omitempty"`
 	IsActive   bool               `json:"is_active"`
+	Periods    []ServicePeriod    `json:"service_periods"`
 	Workshops  []string           `json:"workshops"`
+	TotalHours float64            `json:"total_hours"` // soma das horas registradas em presenças
+	DeletedAt  *time.Time         `json:"deleted_at,omitempty"`
+	DeletedBy  string             `json:"deleted_by,omitempty"`
 	CreatedAt  time.Time          `json:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at"`
 }
@@ -109,9 +136,83 @@ func (v *Volunteer) Validate() error {
 		return errors.New("data de saída deve ser posterior à data de entrada")
 	}
 
+	// Cada período termina depois de começar e começa depois do anterior terminar
+	periods := v.ServicePeriods()
+	for i, period := range periods {
+		if period.ExitDate != nil && period.ExitDate.Before(period.EntryDate) {
+			return errors.New("data de saída deve ser posterior à data de entrada")
+		}
+		if i > 0 && (periods[i-1].ExitDate == nil || period.EntryDate.Before(*periods[i-1].ExitDate)) {
+			return errors.New("períodos de atuação não podem se sobrepor")
+		}
+	}
+
 	return nil
 }
 
+// ServicePeriods retorna os períodos de atuação do voluntário. Cadastros
+// anteriores à lista de períodos têm um único período, com as datas de
+// entrada e saída do cadastro.
+func (v *Volunteer) ServicePeriods() []ServicePeriod {
+	if len(v.Periods) > 0 {
+		return append([]ServicePeriod{}, v.Periods...)
+	}
+	return []ServicePeriod{{EntryDate: v.EntryDate, ExitDate: v.ExitDate}}
+}
+
+// SetEntryDate altera a data de entrada no projeto, que abre o primeiro período
+func (v *Volunteer) SetEntryDate(entryDate time.Time) {
+	periods := v.ServicePeriods()
+	periods[0].EntryDate = entryDate
+	v.EntryDate = entryDate
+	v.Periods = periods
+}
+
+// EndServicePeriod encerra o período em andamento e inativa o voluntário
+func (v *Volunteer) EndServicePeriod(exitDate time.Time) error {
+	periods := v.ServicePeriods()
+	current := &periods[len(periods)-1]
+
+	if !v.IsActive || current.ExitDate != nil {
+		return ErrVolunteerAlreadyInactive
+	}
+	if exitDate.Before(current.EntryDate) {
+		return errors.New("data de saída deve ser posterior à data de entrada")
+	}
+
+	current.ExitDate = &exitDate
+	v.Periods = periods
+	v.ExitDate = &exitDate
+	v.IsActive = false
+	return nil
+}
+
+// StartServicePeriod abre um novo período de atuação e reativa o voluntário
+func (v *Volunteer) StartServicePeriod(entryDate time.Time) error {
+	periods := v.ServicePeriods()
+	last := periods[len(periods)-1]
+
+	if v.IsActive || last.ExitDate == nil {
+		return ErrVolunteerAlreadyActive
+	}
+	if entryDate.Before(*last.ExitDate) {
+		return errors.New("data de retorno deve ser posterior à última data de saída")
+	}
+	if entryDate.After(time.Now()) {
+		return errors.New("data de retorno não pode ser futura")
+	}
+
+	v.Periods = append(periods, ServicePeriod{EntryDate: entryDate})
+	v.ExitDate = nil
+	v.IsActive = true
+	return nil
+}
+
+// IsDeleted verifica se o voluntário está na lixeira
+func (v *Volunteer) IsDeleted() bool {
+	return v.DeletedAt != nil
+}
+
 // ToResponse converte um Volunteer para VolunteerResponse
 func (v *Volunteer) ToResponse() VolunteerResponse {
 	return VolunteerResponse{
@@ -125,7 +226,10 @@ func (v *Volunteer) ToResponse() VolunteerResponse {
 		EntryDate:  v.EntryDate,
 		ExitDate:   v.ExitDate,
 		IsActive:   v.IsActive,
+		Periods:    v.ServicePeriods(),
 		Workshops:  v.Workshops,
+		DeletedAt:  v.DeletedAt,
+		DeletedBy:  v.DeletedBy,
 		CreatedAt:  v.CreatedAt,
 		UpdatedAt:  v.UpdatedAt,
 	}
@@ -143,6 +247,7 @@ func NewVolunteerFromRequest(req CreateVolunteerRequest) *Volunteer {
 		RA:         req.RA,
 		EntryDate:  req.EntryDate,
 		IsActive:   true,
+		Periods:    []ServicePeriod{{EntryDate: req.EntryDate}},
 		Workshops:  []string{},
 		CreatedAt:  now,
 		UpdatedAt:  now,
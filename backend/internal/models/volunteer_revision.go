@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VolunteerRevision representa uma versão do cadastro de um voluntário. Cada
+// criação ou alteração do cadastro grava uma nova revisão com o documento
+// completo, para consultar o voluntário como era em qualquer data.
+type VolunteerRevision struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	VolunteerID string             `json:"volunteer_id" bson:"volunteer_id"`
+	Version     int                `json:"version" bson:"version"` // começa em 1 e cresce a cada alteração
+	Volunteer   Volunteer          `json:"volunteer" bson:"volunteer"`
+	ChangedBy   string             `json:"changed_by,omitempty" bson:"changed_by,omitempty"` // ID do usuário; vazio em operações internas
+	RecordedAt  time.Time          `json:"recorded_at" bson:"recorded_at"`                   // momento a partir do qual a versão vale
+}
+
+// NewVolunteerRevision cria a revisão com o estado atual do voluntário. A
+// versão vale a partir da última alteração gravada no cadastro.
+func NewVolunteerRevision(volunteer *Volunteer, version int, changedBy string) *VolunteerRevision {
+	recordedAt := volunteer.UpdatedAt
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+
+	snapshot := *volunteer
+	snapshot.Workshops = append([]string{}, volunteer.Workshops...)
+
+	return &VolunteerRevision{
+		VolunteerID: volunteer.ID.Hex(),
+		Version:     version,
+		Volunteer:   snapshot,
+		ChangedBy:   changedBy,
+		RecordedAt:  recordedAt,
+	}
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Status possíveis de uma oficina
+const (
+	WorkshopStatusScheduled = "scheduled"
+	WorkshopStatusCompleted = "completed"
+	WorkshopStatusCancelled = "cancelled"
+)
+
+// EnrollmentStatus representa a situação de um voluntário em uma oficina
+type EnrollmentStatus string
+
+// Situações possíveis de inscrição
+const (
+	EnrollmentEnrolled   EnrollmentStatus = "enrolled"
+	EnrollmentWaitlisted EnrollmentStatus = "waitlisted"
+)
+
+// Workshop representa uma oficina do projeto ELLP
+type Workshop struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Title        string             `json:"title" bson:"title" binding:"required"`
+	Description  string             `json:"description" bson:"description"`
+	Instructor   string             `json:"instructor" bson:"instructor"`
+	Date         time.Time          `json:"date" bson:"date" binding:"required"`
+	Location     string             `json:"location" bson:"location"`
+	Capacity     int                `json:"capacity" bson:"capacity"`
+	Status       string             `json:"status" bson:"status"`             // "scheduled", "completed", "cancelled"
+	Enrolled     []string           `json:"enrolled" bson:"enrolled"`         // IDs dos voluntários inscritos
+	Waitlist     []string           `json:"waitlist" bson:"waitlist"`         // IDs dos voluntários na fila de espera, em ordem
+	Coordinators []string           `json:"coordinators" bson:"coordinators"` // IDs dos usuários que coordenam a oficina
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// CreateWorkshopRequest representa o payload para criar uma oficina
+type CreateWorkshopRequest struct {
+	Title       string    `json:"title" binding:"required"`
+	Description string    `json:"description"`
+	Instructor  string    `json:"instructor"`
+	Date        time.Time `json:"date" binding:"required"`
+	Location    string    `json:"location"`
+	Capacity    int       `json:"capacity" binding:"omitempty,min=0"`
+}
+
+// UpdateWorkshopRequest representa o payload para atualizar uma oficina
+type UpdateWorkshopRequest struct {
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	Instructor  string    `json:"instructor"`
+	Date        time.Time `json:"date"`
+	Location    string    `json:"location"`
+	Capacity    *int      `json:"capacity" binding:"omitempty,min=0"`
+	Status      string    `json:"status" binding:"omitempty,oneof=scheduled completed"`
+}
+
+// WorkshopResponse representa a resposta da API
+type WorkshopResponse struct {
+	ID             primitive.ObjectID `json:"id"`
+	Title          string             `json:"title"`
+	Description    string             `json:"description"`
+	Instructor     string             `json:"instructor"`
+	Date           time.Time          `json:"date"`
+	Location       string             `json:"location"`
+	Capacity       int                `json:"capacity"`
+	Status         string             `json:"status"`
+	Enrolled       []string           `json:"enrolled"`
+	Waitlist       []string           `json:"waitlist"`
+	Coordinators   []string           `json:"coordinators"`
+	AvailableSeats *int               `json:"available_seats,omitempty"` // omitido quando não há limite de vagas
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// IsValidWorkshopStatus verifica se o status informado é conhecido
+func IsValidWorkshopStatus(status string) bool {
+	switch status {
+	case WorkshopStatusScheduled, WorkshopStatusCompleted, WorkshopStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Validate valida os dados da oficina
+func (w *Workshop) Validate() error {
+	if w.Title == "" {
+		return errors.New("título é obrigatório")
+	}
+
+	if w.Date.IsZero() {
+		return errors.New("data da oficina é obrigatória")
+	}
+
+	// Capacidade 0 significa sem limite de vagas
+	if w.Capacity < 0 {
+		return errors.New("capacidade não pode ser negativa")
+	}
+
+	if !IsValidWorkshopStatus(w.Status) {
+		return errors.New("status deve ser 'scheduled', 'completed' ou 'cancelled'")
+	}
+
+	return nil
+}
+
+// IsCancelled indica se a oficina foi cancelada
+func (w *Workshop) IsCancelled() bool {
+	return w.Status == WorkshopStatusCancelled
+}
+
+// HasUnlimitedCapacity indica se a oficina não possui limite de vagas
+func (w *Workshop) HasUnlimitedCapacity() bool {
+	return w.Capacity <= 0
+}
+
+// AvailableSeats retorna o número de vagas livres (ignorado se não há limite)
+func (w *Workshop) AvailableSeats() int {
+	available := w.Capacity - len(w.Enrolled)
+	if available < 0 {
+		return 0
+	}
+	return available
+}
+
+// IsEnrolled indica se o voluntário ocupa uma vaga na oficina
+func (w *Workshop) IsEnrolled(volunteerID string) bool {
+	return containsString(w.Enrolled, volunteerID)
+}
+
+// IsWaitlisted indica se o voluntário está na fila de espera da oficina
+func (w *Workshop) IsWaitlisted(volunteerID string) bool {
+	return containsString(w.Waitlist, volunteerID)
+}
+
+// HasCoordinator indica se o usuário coordena a oficina
+func (w *Workshop) HasCoordinator(userID string) bool {
+	return containsString(w.Coordinators, userID)
+}
+
+// containsString verifica se o valor está presente na lista
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ToResponse converte um Workshop para WorkshopResponse
+func (w *Workshop) ToResponse() WorkshopResponse {
+	enrolled := w.Enrolled
+	if enrolled == nil {
+		enrolled = []string{}
+	}
+	waitlist := w.Waitlist
+	if waitlist == nil {
+		waitlist = []string{}
+	}
+	coordinators := w.Coordinators
+	if coordinators == nil {
+		coordinators = []string{}
+	}
+
+	var availableSeats *int
+	if !w.HasUnlimitedCapacity() {
+		seats := w.AvailableSeats()
+		availableSeats = &seats
+	}
+
+	return WorkshopResponse{
+		ID:             w.ID,
+		Title:          w.Title,
+		Description:    w.Description,
+		Instructor:     w.Instructor,
+		Date:           w.Date,
+		Location:       w.Location,
+		Capacity:       w.Capacity,
+		Status:         w.Status,
+		Enrolled:       enrolled,
+		Waitlist:       waitlist,
+		Coordinators:   coordinators,
+		AvailableSeats: availableSeats,
+		CreatedAt:      w.CreatedAt,
+		UpdatedAt:      w.UpdatedAt,
+	}
+}
+
+// NewWorkshopFromRequest cria uma nova oficina a partir do request
+func NewWorkshopFromRequest(req CreateWorkshopRequest) *Workshop {
+	now := time.Now()
+	return &Workshop{
+		Title:        req.Title,
+		Description:  req.Description,
+		Instructor:   req.Instructor,
+		Date:         req.Date,
+		Location:     req.Location,
+		Capacity:     req.Capacity,
+		Status:       WorkshopStatusScheduled,
+		Enrolled:     []string{},
+		Waitlist:     []string{},
+		Coordinators: []string{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
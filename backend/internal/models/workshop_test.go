@@ -0,0 +1,106 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkshop_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		workshop Workshop
+		wantErr  bool
+	}{
+		{
+			name: "Valid workshop",
+			workshop: Workshop{
+				Title:    "Lógica com Scratch",
+				Date:     time.Now().AddDate(0, 0, 7),
+				Capacity: 20,
+				Status:   WorkshopStatusScheduled,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Valid workshop without capacity limit",
+			workshop: Workshop{
+				Title:  "Robótica",
+				Date:   time.Now(),
+				Status: WorkshopStatusCompleted,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Missing title",
+			workshop: Workshop{
+				Date:   time.Now(),
+				Status: WorkshopStatusScheduled,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing date",
+			workshop: Workshop{
+				Title:  "Robótica",
+				Status: WorkshopStatusScheduled,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Negative capacity",
+			workshop: Workshop{
+				Title:    "Robótica",
+				Date:     time.Now(),
+				Capacity: -1,
+				Status:   WorkshopStatusScheduled,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid status",
+			workshop: Workshop{
+				Title:  "Robótica",
+				Date:   time.Now(),
+				Status: "postponed",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workshop.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Workshop.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWorkshopFromRequest(t *testing.T) {
+	req := CreateWorkshopRequest{
+		Title:      "Introdução à Programação",
+		Instructor: "Maria",
+		Date:       time.Now().AddDate(0, 0, 3),
+		Location:   "Sala 101",
+		Capacity:   25,
+	}
+
+	workshop := NewWorkshopFromRequest(req)
+
+	if workshop.Status != WorkshopStatusScheduled {
+		t.Errorf("Status = %v, want %v", workshop.Status, WorkshopStatusScheduled)
+	}
+
+	if workshop.CreatedAt.IsZero() || workshop.UpdatedAt.IsZero() {
+		t.Error("NewWorkshopFromRequest() didn't set timestamps")
+	}
+
+	if workshop.Title != req.Title || workshop.Capacity != req.Capacity {
+		t.Error("NewWorkshopFromRequest() didn't copy request fields")
+	}
+
+	if workshop.IsCancelled() {
+		t.Error("New workshop should not be cancelled")
+	}
+}
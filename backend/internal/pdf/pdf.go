@@ -0,0 +1,206 @@
+// Package pdf implementa um gerador mínimo de documentos PDF, suficiente para
+// os documentos emitidos pela plataforma (textos em Helvetica, linhas e
+// retângulos). As coordenadas são em pontos, a partir do canto superior esquerdo.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tamanhos de página A4 em pontos
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font identifica uma das fontes padrão suportadas
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// Align define o alinhamento horizontal do texto em relação a x
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// Color representa uma cor RGB
+type Color struct {
+	R, G, B uint8
+}
+
+// Black é a cor padrão de textos e linhas
+var Black = Color{0, 0, 0}
+
+// Document representa um documento PDF em construção
+type Document struct {
+	width   float64
+	height  float64
+	title   string
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+}
+
+// NewDocument cria um documento cujas páginas têm as dimensões informadas
+func NewDocument(width, height float64) *Document {
+	return &Document{
+		width:  width,
+		height: height,
+	}
+}
+
+// Width retorna a largura das páginas do documento
+func (d *Document) Width() float64 {
+	return d.width
+}
+
+// Height retorna a altura das páginas do documento
+func (d *Document) Height() float64 {
+	return d.height
+}
+
+// SetTitle define o título gravado nos metadados do documento
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// AddPage inicia uma nova página; os desenhos seguintes são feitos nela
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+// page retorna a página atual, criando a primeira se necessário
+func (d *Document) page() *bytes.Buffer {
+	if d.current == nil {
+		d.AddPage()
+	}
+	return d.current
+}
+
+// Text escreve uma linha de texto com a base em y
+func (d *Document) Text(x, y float64, font Font, size float64, color Color, align Align, text string) {
+	switch align {
+	case AlignCenter:
+		x -= TextWidth(font, size, text) / 2
+	case AlignRight:
+		x -= TextWidth(font, size, text)
+	}
+
+	fmt.Fprintf(d.page(), "q %s rg BT /F%d %s Tf %s %s Td (%s) Tj ET Q\n",
+		color.operands(), font+1, number(size), number(x), number(d.height-y), escape(encode(text)))
+}
+
+// Line desenha uma linha reta entre dois pontos
+func (d *Document) Line(x1, y1, x2, y2, lineWidth float64, color Color) {
+	fmt.Fprintf(d.page(), "q %s RG %s w %s %s m %s %s l S Q\n",
+		color.operands(), number(lineWidth),
+		number(x1), number(d.height-y1), number(x2), number(d.height-y2))
+}
+
+// Rect desenha o contorno de um retângulo a partir do canto superior esquerdo
+func (d *Document) Rect(x, y, w, h, lineWidth float64, color Color) {
+	fmt.Fprintf(d.page(), "q %s RG %s w %s %s %s %s re S Q\n",
+		color.operands(), number(lineWidth),
+		number(x), number(d.height-y-h), number(w), number(h))
+}
+
+// FillRect preenche um retângulo a partir do canto superior esquerdo
+func (d *Document) FillRect(x, y, w, h float64, color Color) {
+	fmt.Fprintf(d.page(), "q %s rg %s %s %s %s re f Q\n",
+		color.operands(), number(x), number(d.height-y-h), number(w), number(h))
+}
+
+// Bytes serializa o documento no formato PDF
+func (d *Document) Bytes() ([]byte, error) {
+	d.page()
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objetos 1 e 2 (catálogo e árvore de páginas) referenciam as páginas,
+	// que são numeradas a partir do 5 (depois das duas fontes)
+	pageIDs := make([]string, len(d.pages))
+	for i := range d.pages {
+		pageIDs[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIDs, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for _, content := range d.pages {
+		var compressed bytes.Buffer
+		writer := zlib.NewWriter(&compressed)
+		if _, err := writer.Write(content.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+
+		pageID := len(offsets) + 1
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(d.width), number(d.height), pageID+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	info := object(fmt.Sprintf("<< /Title (%s) /Producer (ELLP) /CreationDate (D:%s) >>",
+		escape(encode(d.title)), time.Now().UTC().Format("20060102150405Z")))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+
+	return out.Bytes(), nil
+}
+
+// operands formata a cor como operandos de rg/RG
+func (c Color) operands() string {
+	return fmt.Sprintf("%s %s %s", number(float64(c.R)/255), number(float64(c.G)/255), number(float64(c.B)/255))
+}
+
+// number formata um número com no máximo duas casas decimais
+func number(value float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", value), "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escape escapa os caracteres especiais de strings literais do PDF
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []byte
+	}{
+		{"ascii", "ELLP 2025", []byte("ELLP 2025")},
+		{"latin1", "Participação", []byte("Participa\xe7\xe3o")},
+		{"winansi extras", "“ok” – fim", []byte("\x93ok\x94 \x96 fim")},
+		{"unsupported", "日", []byte("?")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encode(tt.text); !bytes.Equal(got, tt.want) {
+				t.Errorf("encode(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	// "Hi" = H(722) + i(222) em Helvetica
+	if got := TextWidth(Helvetica, 10, "Hi"); got != 9.44 {
+		t.Errorf("TextWidth(Helvetica) = %v, want 9.44", got)
+	}
+
+	// Letras acentuadas têm a largura da letra base
+	if TextWidth(HelveticaBold, 12, "ção") != TextWidth(HelveticaBold, 12, "cao") {
+		t.Error("TextWidth() should measure accented letters as their base letter")
+	}
+}
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := NewDocument(A4Height, A4Width)
+	doc.SetTitle("Certificado")
+	doc.Text(100, 100, HelveticaBold, 20, Black, AlignCenter, "Certificado (teste)")
+	doc.Rect(10, 10, 50, 50, 1, Black)
+	doc.AddPage()
+	doc.Line(0, 0, 100, 100, 0.5, Black)
+
+	data, err := doc.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("Bytes() should produce a PDF header and trailer")
+	}
+
+	if !bytes.Contains(data, []byte("/Count 2")) {
+		t.Error("Bytes() should contain two pages")
+	}
+
+	// As entradas da tabela xref devem apontar para o início de cada objeto
+	xref := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(data, -1)
+	if len(xref) == 0 {
+		t.Fatal("Bytes() should contain xref entries")
+	}
+	for i, entry := range xref {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		prefix := []byte(strconv.Itoa(i+1) + " 0 obj")
+		if !bytes.HasPrefix(data[offset:], prefix) {
+			t.Errorf("xref entry %d points to %q", i+1, data[offset:offset+10])
+		}
+	}
+
+	// O conteúdo da primeira página deve conter o texto com parênteses escapados
+	stream := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindSubmatch(data)
+	if stream == nil {
+		t.Fatal("Bytes() should contain a content stream")
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(stream[1]))
+	if err != nil {
+		t.Fatalf("zlib.NewReader() error = %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	if !bytes.Contains(content, []byte(`(Certificado \(teste\)) Tj`)) {
+		t.Errorf("content stream = %q, want escaped text", content)
+	}
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrAttendanceNotFound é retornado quando o registro de presença não é encontrado
+	ErrAttendanceNotFound = errors.New("registro de presença não encontrado")
+)
+
+// AttendanceRepository define a interface para operações de presença
+type AttendanceRepository interface {
+	Create(ctx context.Context, attendance *models.Attendance) error
+	FindByID(ctx context.Context, id string) (*models.Attendance, error)
+	FindAll(ctx context.Context, filter AttendanceFilter) ([]*models.Attendance, error)
+	Count(ctx context.Context, filter AttendanceFilter) (int64, error)
+	FindOpen(ctx context.Context, volunteerID string, workshopID string) (*models.Attendance, error)
+	Update(ctx context.Context, id string, attendance *models.Attendance) error
+	Delete(ctx context.Context, id string) error
+	DeleteByVolunteer(ctx context.Context, volunteerID string) (int64, error)
+	SumHoursByVolunteer(ctx context.Context, volunteerIDs []string) (map[string]float64, error)
+}
+
+// AttendanceFilter representa os filtros para busca de registros de presença
+type AttendanceFilter struct {
+	VolunteerID string
+	WorkshopID  string
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	Page        int
+	Limit       int
+}
+
+// query monta o filtro BSON compartilhado pela listagem e pela contagem
+func (f AttendanceFilter) query() bson.M {
+	bsonFilter := bson.M{}
+
+	if f.VolunteerID != "" {
+		bsonFilter["volunteer_id"] = f.VolunteerID
+	}
+
+	if f.WorkshopID != "" {
+		bsonFilter["workshop_id"] = f.WorkshopID
+	}
+
+	if f.DateFrom != nil || f.DateTo != nil {
+		dateFilter := bson.M{}
+		if f.DateFrom != nil {
+			dateFilter["$gte"] = *f.DateFrom
+		}
+		if f.DateTo != nil {
+			dateFilter["$lte"] = *f.DateTo
+		}
+		bsonFilter["date"] = dateFilter
+	}
+
+	return bsonFilter
+}
+
+// MongoAttendanceRepository implementa AttendanceRepository usando MongoDB
+type MongoAttendanceRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAttendanceRepository cria uma nova instância do repositório
+func NewMongoAttendanceRepository(db *mongo.Database) AttendanceRepository {
+	return &MongoAttendanceRepository{
+		collection: db.Collection("attendance"),
+	}
+}
+
+// Create cria um novo registro de presença
+func (r *MongoAttendanceRepository) Create(ctx context.Context, attendance *models.Attendance) error {
+	result, err := r.collection.InsertOne(ctx, attendance)
+	if err != nil {
+		return err
+	}
+
+	attendance.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID busca um registro de presença por ID
+func (r *MongoAttendanceRepository) FindByID(ctx context.Context, id string) (*models.Attendance, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrAttendanceNotFound
+	}
+
+	var attendance models.Attendance
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&attendance)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrAttendanceNotFound
+		}
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
+// FindAll busca registros de presença com filtros opcionais
+func (r *MongoAttendanceRepository) FindAll(ctx context.Context, filter AttendanceFilter) ([]*models.Attendance, error) {
+	bsonFilter := filter.query()
+
+	// Configurar paginação
+	findOptions := options.Find()
+	if filter.Limit > 0 {
+		findOptions.SetLimit(int64(filter.Limit))
+		if filter.Page > 0 {
+			skip := (filter.Page - 1) * filter.Limit
+			findOptions.SetSkip(int64(skip))
+		}
+	}
+	findOptions.SetSort(bson.D{{Key: "date", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []*models.Attendance
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Count conta os registros de presença que correspondem ao filtro, sem considerar a paginação
+func (r *MongoAttendanceRepository) Count(ctx context.Context, filter AttendanceFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter.query())
+}
+
+// FindOpen busca o registro com check-in sem check-out de um voluntário em uma oficina
+func (r *MongoAttendanceRepository) FindOpen(ctx context.Context, volunteerID string, workshopID string) (*models.Attendance, error) {
+	filter := bson.M{
+		"volunteer_id": volunteerID,
+		"workshop_id":  workshopID,
+		"check_in":     bson.M{"$exists": true},
+		"check_out":    bson.M{"$exists": false},
+	}
+
+	var attendance models.Attendance
+	err := r.collection.FindOne(ctx, filter).Decode(&attendance)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attendance, nil
+}
+
+// Update atualiza um registro de presença
+func (r *MongoAttendanceRepository) Update(ctx context.Context, id string, attendance *models.Attendance) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrAttendanceNotFound
+	}
+
+	attendance.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"date":       attendance.Date,
+		"hours":      attendance.Hours,
+		"notes":      attendance.Notes,
+		"updated_at": attendance.UpdatedAt,
+	}
+	unset := bson.M{}
+	if attendance.CheckIn != nil {
+		set["check_in"] = attendance.CheckIn
+	} else {
+		unset["check_in"] = ""
+	}
+	if attendance.CheckOut != nil {
+		set["check_out"] = attendance.CheckOut
+	} else {
+		unset["check_out"] = ""
+	}
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrAttendanceNotFound
+	}
+
+	return nil
+}
+
+// Delete deleta um registro de presença
+func (r *MongoAttendanceRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrAttendanceNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrAttendanceNotFound
+	}
+
+	return nil
+}
+
+// DeleteByVolunteer remove todos os registros de presença de um voluntário
+func (r *MongoAttendanceRepository) DeleteByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"volunteer_id": volunteerID})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// SumHoursByVolunteer soma as horas registradas de cada voluntário informado
+func (r *MongoAttendanceRepository) SumHoursByVolunteer(ctx context.Context, volunteerIDs []string) (map[string]float64, error) {
+	totals := make(map[string]float64, len(volunteerIDs))
+	if len(volunteerIDs) == 0 {
+		return totals, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"volunteer_id": bson.M{"$in": volunteerIDs}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$volunteer_id", "total": bson.M{"$sum": "$hours"}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		VolunteerID string  `bson:"_id"`
+		Total       float64 `bson:"total"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		totals[result.VolunteerID] = models.RoundHours(result.Total)
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditRepository define a interface da trilha de auditoria. Não há operações
+// de alteração ou remoção: os registros são apenas inseridos.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	FindAll(ctx context.Context, filter AuditFilter) ([]*models.AuditEntry, error)
+	Count(ctx context.Context, filter AuditFilter) (int64, error)
+}
+
+// AuditFilter representa os filtros para busca na trilha de auditoria
+type AuditFilter struct {
+	ActorID  string
+	Entity   string
+	EntityID string
+	Action   string
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Page     int
+	Limit    int
+}
+
+// query monta o filtro BSON compartilhado pela listagem e pela contagem
+func (f AuditFilter) query() bson.M {
+	bsonFilter := bson.M{}
+
+	if f.ActorID != "" {
+		bsonFilter["actor_id"] = f.ActorID
+	}
+
+	if f.Entity != "" {
+		bsonFilter["entity"] = f.Entity
+	}
+
+	if f.EntityID != "" {
+		bsonFilter["entity_id"] = f.EntityID
+	}
+
+	if f.Action != "" {
+		bsonFilter["action"] = f.Action
+	}
+
+	if f.DateFrom != nil || f.DateTo != nil {
+		dateFilter := bson.M{}
+		if f.DateFrom != nil {
+			dateFilter["$gte"] = *f.DateFrom
+		}
+		if f.DateTo != nil {
+			dateFilter["$lte"] = *f.DateTo
+		}
+		bsonFilter["created_at"] = dateFilter
+	}
+
+	return bsonFilter
+}
+
+// MongoAuditRepository implementa AuditRepository usando MongoDB
+type MongoAuditRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAuditRepository cria uma nova instância do repositório
+func NewMongoAuditRepository(db *mongo.Database) AuditRepository {
+	return &MongoAuditRepository{
+		collection: db.Collection("audit_log"),
+	}
+}
+
+// Create insere um registro na trilha de auditoria
+func (r *MongoAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindAll busca registros de auditoria com filtros opcionais, dos mais recentes
+// para os mais antigos
+func (r *MongoAuditRepository) FindAll(ctx context.Context, filter AuditFilter) ([]*models.AuditEntry, error) {
+	bsonFilter := filter.query()
+
+	// Configurar paginação
+	findOptions := options.Find()
+	if filter.Limit > 0 {
+		findOptions.SetLimit(int64(filter.Limit))
+		if filter.Page > 0 {
+			skip := (filter.Page - 1) * filter.Limit
+			findOptions.SetSkip(int64(skip))
+		}
+	}
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*models.AuditEntry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Count conta os registros de auditoria que correspondem ao filtro, sem considerar a paginação
+func (r *MongoAuditRepository) Count(ctx context.Context, filter AuditFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter.query())
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrCertificateNotFound é retornado quando o certificado não é encontrado
+	ErrCertificateNotFound = errors.New("certificado não encontrado")
+	// ErrCertificateAlreadyRevoked é retornado ao revogar um certificado já revogado
+	ErrCertificateAlreadyRevoked = errors.New("certificado já está revogado")
+)
+
+// CertificateRepository define a interface para operações de certificados emitidos
+type CertificateRepository interface {
+	Create(ctx context.Context, certificate *models.Certificate) error
+	FindByCode(ctx context.Context, code string) (*models.Certificate, error)
+	FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.Certificate, error)
+	CountByVolunteer(ctx context.Context, volunteerID string) (int64, error)
+	FindLatestValid(ctx context.Context, volunteerID string) (*models.Certificate, error)
+	Revoke(ctx context.Context, code string, revokedBy string, reason string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+// MongoCertificateRepository implementa CertificateRepository usando MongoDB
+type MongoCertificateRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoCertificateRepository cria uma nova instância do repositório
+func NewMongoCertificateRepository(db *mongo.Database) CertificateRepository {
+	return &MongoCertificateRepository{
+		collection: db.Collection("certificates"),
+	}
+}
+
+// Create grava um certificado emitido
+func (r *MongoCertificateRepository) Create(ctx context.Context, certificate *models.Certificate) error {
+	result, err := r.collection.InsertOne(ctx, certificate)
+	if err != nil {
+		return err
+	}
+
+	certificate.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByCode busca um certificado pelo código de verificação
+func (r *MongoCertificateRepository) FindByCode(ctx context.Context, code string) (*models.Certificate, error) {
+	var certificate models.Certificate
+	err := r.collection.FindOne(ctx, bson.M{"code": code}).Decode(&certificate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// FindByVolunteer lista uma página dos certificados emitidos para um
+// voluntário, do mais recente ao mais antigo
+func (r *MongoCertificateRepository) FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.Certificate, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "issued_at", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+		if page > 0 {
+			findOptions.SetSkip(int64((page - 1) * limit))
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"volunteer_id": volunteerID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	certificates := []*models.Certificate{}
+	if err = cursor.All(ctx, &certificates); err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+// CountByVolunteer conta os certificados emitidos para um voluntário
+func (r *MongoCertificateRepository) CountByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"volunteer_id": volunteerID})
+}
+
+// FindLatestValid busca o certificado não revogado mais recente de um voluntário
+func (r *MongoCertificateRepository) FindLatestValid(ctx context.Context, volunteerID string) (*models.Certificate, error) {
+	filter := bson.M{
+		"volunteer_id": volunteerID,
+		"revoked_at":   bson.M{"$exists": false},
+	}
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "issued_at", Value: -1}})
+
+	var certificate models.Certificate
+	err := r.collection.FindOne(ctx, filter, findOptions).Decode(&certificate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrCertificateNotFound
+		}
+		return nil, err
+	}
+
+	return &certificate, nil
+}
+
+// Revoke marca o certificado como revogado, se ainda não estiver
+func (r *MongoCertificateRepository) Revoke(ctx context.Context, code string, revokedBy string, reason string) error {
+	filter := bson.M{
+		"code":       code,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at":        time.Now(),
+			"revoked_by":        revokedBy,
+			"revocation_reason": reason,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		// Distingue certificado inexistente de certificado já revogado
+		if _, err := r.FindByCode(ctx, code); err != nil {
+			return err
+		}
+		return ErrCertificateAlreadyRevoked
+	}
+
+	return nil
+}
+
+// EnsureIndexes cria o índice único do código de verificação
+func (r *MongoCertificateRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
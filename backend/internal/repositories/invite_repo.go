@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	// ErrInviteNotFound é retornado quando o convite não é encontrado
+	ErrInviteNotFound = errors.New("convite não encontrado")
+	// ErrInviteAlreadyUsed é retornado quando o convite já foi utilizado
+	ErrInviteAlreadyUsed = errors.New("convite já utilizado")
+)
+
+// InviteRepository define a interface para operações de convites de cadastro
+type InviteRepository interface {
+	Create(ctx context.Context, invite *models.Invite) error
+	FindByTokenID(ctx context.Context, tokenID string) (*models.Invite, error)
+	MarkUsed(ctx context.Context, tokenID string, userID string) error
+	Release(ctx context.Context, tokenID string) error
+}
+
+// MongoInviteRepository implementa InviteRepository usando MongoDB
+type MongoInviteRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoInviteRepository cria uma nova instância do repositório
+func NewMongoInviteRepository(db *mongo.Database) InviteRepository {
+	return &MongoInviteRepository{
+		collection: db.Collection("invites"),
+	}
+}
+
+// Create grava um novo convite
+func (r *MongoInviteRepository) Create(ctx context.Context, invite *models.Invite) error {
+	result, err := r.collection.InsertOne(ctx, invite)
+	if err != nil {
+		return err
+	}
+
+	invite.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByTokenID busca um convite pelo JTI do token
+func (r *MongoInviteRepository) FindByTokenID(ctx context.Context, tokenID string) (*models.Invite, error) {
+	var invite models.Invite
+	err := r.collection.FindOne(ctx, bson.M{"token_id": tokenID}).Decode(&invite)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrInviteNotFound
+		}
+		return nil, err
+	}
+
+	return &invite, nil
+}
+
+// MarkUsed marca o convite como utilizado. A operação é atômica: um convite
+// só pode ser consumido uma vez, mesmo com cadastros simultâneos.
+func (r *MongoInviteRepository) MarkUsed(ctx context.Context, tokenID string, userID string) error {
+	filter := bson.M{
+		"token_id": tokenID,
+		"used_at":  bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"used_at": time.Now(),
+			"used_by": userID,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrInviteAlreadyUsed
+	}
+
+	return nil
+}
+
+// Release libera um convite consumido cujo cadastro não pôde ser concluído
+func (r *MongoInviteRepository) Release(ctx context.Context, tokenID string) error {
+	update := bson.M{"$unset": bson.M{"used_at": "", "used_by": ""}}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"token_id": tokenID}, update)
+	return err
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrPasswordResetNotFound é retornado quando o token de redefinição não é encontrado
+	ErrPasswordResetNotFound = errors.New("token de redefinição não encontrado")
+	// ErrPasswordResetAlreadyUsed é retornado quando o token de redefinição já foi usado
+	ErrPasswordResetAlreadyUsed = errors.New("token de redefinição já utilizado")
+)
+
+// PasswordResetRepository define a interface para operações de tokens de redefinição de senha
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *models.PasswordReset) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error)
+	MarkUsed(ctx context.Context, id primitive.ObjectID) error
+	InvalidateByUser(ctx context.Context, userID string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+// MongoPasswordResetRepository implementa PasswordResetRepository usando MongoDB
+type MongoPasswordResetRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoPasswordResetRepository cria uma nova instância do repositório
+func NewMongoPasswordResetRepository(db *mongo.Database) PasswordResetRepository {
+	return &MongoPasswordResetRepository{
+		collection: db.Collection("password_resets"),
+	}
+}
+
+// Create grava um novo token de redefinição
+func (r *MongoPasswordResetRepository) Create(ctx context.Context, reset *models.PasswordReset) error {
+	result, err := r.collection.InsertOne(ctx, reset)
+	if err != nil {
+		return err
+	}
+
+	reset.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByTokenHash busca um token de redefinição pelo hash
+func (r *MongoPasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error) {
+	var reset models.PasswordReset
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&reset)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrPasswordResetNotFound
+		}
+		return nil, err
+	}
+
+	return &reset, nil
+}
+
+// MarkUsed marca o token como utilizado. A operação é atômica para que o
+// mesmo token não redefina a senha duas vezes.
+func (r *MongoPasswordResetRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":     id,
+		"used_at": bson.M{"$exists": false},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"used_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrPasswordResetAlreadyUsed
+	}
+
+	return nil
+}
+
+// InvalidateByUser invalida todos os tokens ainda não usados de um usuário
+func (r *MongoPasswordResetRepository) InvalidateByUser(ctx context.Context, userID string) error {
+	filter := bson.M{
+		"user_id": userID,
+		"used_at": bson.M{"$exists": false},
+	}
+
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"used_at": time.Now()}})
+	return err
+}
+
+// EnsureIndexes cria o índice único do hash do token, usado por FindByTokenHash
+func (r *MongoPasswordResetRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrRoleNotFound é retornado quando o papel não é encontrado
+	ErrRoleNotFound = errors.New("papel não encontrado")
+	// ErrRoleAlreadyExists é retornado ao criar um papel com nome já usado
+	ErrRoleAlreadyExists = errors.New("já existe um papel com este nome")
+)
+
+// RoleRepository define a interface para operações de papéis e permissões
+type RoleRepository interface {
+	Create(ctx context.Context, role *models.Role) error
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	List(ctx context.Context) ([]*models.Role, error)
+	Update(ctx context.Context, role *models.Role) error
+	Delete(ctx context.Context, name string) error
+	EnsureDefaults(ctx context.Context) error
+}
+
+// MongoRoleRepository implementa RoleRepository usando MongoDB
+type MongoRoleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRoleRepository cria uma nova instância do repositório
+func NewMongoRoleRepository(db *mongo.Database) RoleRepository {
+	return &MongoRoleRepository{
+		collection: db.Collection("roles"),
+	}
+}
+
+// Create grava um novo papel
+func (r *MongoRoleRepository) Create(ctx context.Context, role *models.Role) error {
+	if _, err := r.FindByName(ctx, role.Name); err == nil {
+		return ErrRoleAlreadyExists
+	} else if err != ErrRoleNotFound {
+		return err
+	}
+
+	role.CreatedAt = time.Now()
+	role.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	role.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByName busca um papel pelo nome
+func (r *MongoRoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := r.collection.FindOne(ctx, bson.M{"name": name}).Decode(&role)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// List lista os papéis em ordem alfabética
+func (r *MongoRoleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	roles := []*models.Role{}
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// Update altera a descrição e as permissões de um papel
+func (r *MongoRoleRepository) Update(ctx context.Context, role *models.Role) error {
+	role.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"description": role.Description,
+			"permissions": role.Permissions,
+			"updated_at":  role.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"name": role.Name}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// Delete remove um papel
+func (r *MongoRoleRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"name": name})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrRoleNotFound
+	}
+
+	return nil
+}
+
+// EnsureDefaults cria os papéis padrão que ainda não existem. Papéis já
+// gravados mantêm as permissões ajustadas, exceto o administrador.
+func (r *MongoRoleRepository) EnsureDefaults(ctx context.Context) error {
+	for _, role := range models.DefaultRoles() {
+		now := time.Now()
+		insert := bson.M{
+			"name":        role.Name,
+			"description": role.Description,
+			"permissions": role.Permissions,
+			"system":      role.System,
+			"created_at":  now,
+			"updated_at":  now,
+		}
+		update := bson.M{"$setOnInsert": insert}
+
+		// O administrador sempre recebe todas as permissões, inclusive as novas
+		if role.Name == models.RoleAdmin {
+			delete(insert, "permissions")
+			update["$set"] = bson.M{"permissions": role.Permissions}
+		}
+
+		_, err := r.collection.UpdateOne(ctx, bson.M{"name": role.Name}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrSessionNotFound é retornado quando a sessão não é encontrada
+	ErrSessionNotFound = errors.New("sessão não encontrada")
+	// ErrSessionAlreadyRotated é retornado quando o refresh token já foi usado
+	ErrSessionAlreadyRotated = errors.New("refresh token já utilizado")
+)
+
+// SessionRepository define a interface para operações de sessões (refresh tokens)
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindByTokenID(ctx context.Context, tokenID string) (*models.Session, error)
+	Rotate(ctx context.Context, tokenID string, replacedBy string) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUser(ctx context.Context, userID string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+// MongoSessionRepository implementa SessionRepository usando MongoDB
+type MongoSessionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSessionRepository cria uma nova instância do repositório
+func NewMongoSessionRepository(db *mongo.Database) SessionRepository {
+	return &MongoSessionRepository{
+		collection: db.Collection("sessions"),
+	}
+}
+
+// Create grava uma nova sessão
+func (r *MongoSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	result, err := r.collection.InsertOne(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	session.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByTokenID busca uma sessão pelo JTI do refresh token
+func (r *MongoSessionRepository) FindByTokenID(ctx context.Context, tokenID string) (*models.Session, error) {
+	var session models.Session
+	err := r.collection.FindOne(ctx, bson.M{"token_id": tokenID}).Decode(&session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Rotate marca o token como trocado pelo token seguinte. A operação é atômica:
+// se duas requisições usarem o mesmo token, apenas uma consegue rotacioná-lo.
+func (r *MongoSessionRepository) Rotate(ctx context.Context, tokenID string, replacedBy string) error {
+	filter := bson.M{
+		"token_id":   tokenID,
+		"rotated_at": bson.M{"$exists": false},
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"rotated_at":  time.Now(),
+			"replaced_by": replacedBy,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrSessionAlreadyRotated
+	}
+
+	return nil
+}
+
+// RevokeFamily revoga todos os tokens de uma família
+func (r *MongoSessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.revokeMany(ctx, bson.M{"family_id": familyID})
+}
+
+// RevokeByUser revoga todas as sessões de um usuário
+func (r *MongoSessionRepository) RevokeByUser(ctx context.Context, userID string) error {
+	return r.revokeMany(ctx, bson.M{"user_id": userID})
+}
+
+// revokeMany revoga as sessões ainda não revogadas que atendem ao filtro
+func (r *MongoSessionRepository) revokeMany(ctx context.Context, filter bson.M) error {
+	filter["revoked_at"] = bson.M{"$exists": false}
+
+	_, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	return err
+}
+
+// EnsureIndexes cria o índice único do hash do refresh token
+func (r *MongoSessionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "token_hash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
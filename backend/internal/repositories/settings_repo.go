@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// securitySettingsID é o ID do documento único com as políticas de segurança
+const securitySettingsID = "security"
+
+// SettingsRepository define a interface para as configurações do sistema
+type SettingsRepository interface {
+	GetSecurity(ctx context.Context) (*models.SecuritySettings, error)
+	SaveSecurity(ctx context.Context, settings *models.SecuritySettings) error
+}
+
+// MongoSettingsRepository implementa SettingsRepository usando MongoDB
+type MongoSettingsRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoSettingsRepository cria uma nova instância do repositório
+func NewMongoSettingsRepository(db *mongo.Database) SettingsRepository {
+	return &MongoSettingsRepository{
+		collection: db.Collection("settings"),
+	}
+}
+
+// GetSecurity busca as políticas de segurança; sem documento gravado,
+// retorna os valores padrão
+func (r *MongoSettingsRepository) GetSecurity(ctx context.Context) (*models.SecuritySettings, error) {
+	var settings models.SecuritySettings
+	err := r.collection.FindOne(ctx, bson.M{"_id": securitySettingsID}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return &models.SecuritySettings{}, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SaveSecurity grava as políticas de segurança
+func (r *MongoSettingsRepository) SaveSecurity(ctx context.Context, settings *models.SecuritySettings) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": securitySettingsID}, settings, options.Replace().SetUpsert(true))
+	return err
+}
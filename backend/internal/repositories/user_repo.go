@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var (
@@ -16,6 +17,12 @@ var (
 	ErrUserNotFound = errors.New("usuário não encontrado")
 	// ErrUserAlreadyExists é retornado quando o email já está em uso
 	ErrUserAlreadyExists = errors.New("email já está em uso")
+	// ErrLastActiveAdmin é retornado quando a alteração deixaria o sistema sem administradores ativos
+	ErrLastActiveAdmin = errors.New("o sistema precisa de pelo menos um administrador ativo")
+	// ErrVolunteerAlreadyLinked é retornado quando o voluntário já está vinculado a outro usuário
+	ErrVolunteerAlreadyLinked = errors.New("voluntário já está vinculado a outro usuário")
+	// ErrLoginAttemptConflict é retornado quando outra tentativa de login alterou o contador de falhas antes
+	ErrLoginAttemptConflict = errors.New("outra tentativa de login alterou o contador de falhas")
 )
 
 // UserRepository define a interface para operações de usuário
@@ -23,10 +30,23 @@ type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	FindByID(ctx context.Context, id string) (*models.User, error)
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
-	Update(ctx context.Context, user *models.User) error
-	Delete(ctx context.Context, id string) error
+	SetProfile(ctx context.Context, id string, name, email string) error
+	SetPassword(ctx context.Context, id string, password string) error
+	SetRole(ctx context.Context, id string, role string) error
+	SetActive(ctx context.Context, id string, active bool) error
 	List(ctx context.Context, filter bson.M, limit, offset int) ([]*models.User, error)
 	Count(ctx context.Context, filter bson.M) (int64, error)
+	ReserveLoginAttempt(ctx context.Context, user *models.User, at time.Time) (int, error)
+	ReleaseLoginAttempt(ctx context.Context, id string, at time.Time, lastFailure *time.Time) error
+	MarkLoginFailure(ctx context.Context, id string, at time.Time) error
+	Lock(ctx context.Context, id string, until time.Time) error
+	ClearLoginFailures(ctx context.Context, id string) error
+	UpdateMFA(ctx context.Context, user *models.User) error
+	ConsumeMFAStep(ctx context.Context, id string, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error)
+	FindByVolunteerID(ctx context.Context, volunteerID string) (*models.User, error)
+	SetVolunteer(ctx context.Context, id string, volunteerID string) error
+	EnsureIndexes(ctx context.Context) error
 }
 
 // MongoUserRepository implementa UserRepository para MongoDB
@@ -67,7 +87,7 @@ func (r *MongoUserRepository) Create(ctx context.Context, user *models.User) err
 func (r *MongoUserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, err
+		return nil, ErrUserNotFound
 	}
 
 	var user models.User
@@ -96,69 +116,127 @@ func (r *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*m
 	return &user, nil
 }
 
-// Update atualiza um usuário existente
-func (r *MongoUserRepository) Update(ctx context.Context, user *models.User) error {
-	// Prepara o usuário para atualização
-	if err := user.BeforeUpdate(); err != nil {
+// FindByVolunteerID busca o usuário vinculado a um voluntário
+func (r *MongoUserRepository) FindByVolunteerID(ctx context.Context, volunteerID string) (*models.User, error) {
+	var user models.User
+	err := r.collection.FindOne(ctx, bson.M{"volunteer_id": volunteerID}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetProfile altera apenas o nome e o email do usuário
+func (r *MongoUserRepository) SetProfile(ctx context.Context, id string, name, email string) error {
+	_, err := r.set(ctx, id, bson.M{"name": name, "email": email})
+	return err
+}
+
+// SetPassword altera apenas a senha do usuário, gravando o hash
+func (r *MongoUserRepository) SetPassword(ctx context.Context, id string, password string) error {
+	hashedPassword, err := models.HashPassword(password)
+	if err != nil {
 		return err
 	}
 
-	filter := bson.M{"_id": user.ID}
-	update := bson.M{
-		"$set": bson.M{
-			"name":       user.Name,
-			"email":      user.Email,
-			"password":   user.Password,
-			"role":       user.Role,
-			"is_active":  user.IsActive,
-			"updated_at": user.UpdatedAt,
-		},
+	_, err = r.set(ctx, id, bson.M{"password": hashedPassword})
+	return err
+}
+
+// SetRole altera apenas o papel do usuário. Rebaixar o último administrador
+// ativo é desfeito e retorna ErrLastActiveAdmin.
+func (r *MongoUserRepository) SetRole(ctx context.Context, id string, role string) error {
+	before, err := r.set(ctx, id, bson.M{"role": role})
+	if err != nil {
+		return err
 	}
 
-	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if before.Role == models.RoleAdmin && before.IsActive && role != models.RoleAdmin {
+		return r.keepActiveAdmin(ctx, before.ID, bson.M{"role": models.RoleAdmin})
+	}
+
+	return nil
+}
+
+// SetActive ativa ou desativa o usuário. Desativar o último administrador
+// ativo é desfeito e retorna ErrLastActiveAdmin.
+func (r *MongoUserRepository) SetActive(ctx context.Context, id string, active bool) error {
+	before, err := r.set(ctx, id, bson.M{"is_active": active})
 	if err != nil {
 		return err
 	}
 
-	if result.MatchedCount == 0 {
-		return ErrUserNotFound
+	if before.Role == models.RoleAdmin && before.IsActive && !active {
+		return r.keepActiveAdmin(ctx, before.ID, bson.M{"is_active": true})
 	}
 
 	return nil
 }
 
-// Delete remove um usuário (soft delete marcando como inativo)
-func (r *MongoUserRepository) Delete(ctx context.Context, id string) error {
+// set grava apenas os campos informados, sem sobrescrever alterações
+// simultâneas nos demais, e retorna o usuário como estava antes
+func (r *MongoUserRepository) set(ctx context.Context, id string, fields bson.M) (*models.User, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return err
+		return nil, ErrUserNotFound
 	}
 
-	filter := bson.M{"_id": objectID}
-	update := bson.M{
-		"$set": bson.M{
-			"is_active":  false,
-			"updated_at": time.Now(),
-		},
+	fields["updated_at"] = time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var before models.User
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, bson.M{"$set": fields}, opts).Decode(&before)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrUserNotFound
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrUserAlreadyExists
+		}
+		return nil, err
 	}
 
-	result, err := r.collection.UpdateOne(ctx, filter, update)
+	return &before, nil
+}
+
+// keepActiveAdmin desfaz a alteração que tirou um administrador ativo se
+// nenhum outro restar. Sem transações no MongoDB standalone, a alteração é
+// aplicada antes da contagem: em duas remoções simultâneas, ao menos uma
+// enxerga a outra e é desfeita, e o sistema nunca fica sem administrador.
+func (r *MongoUserRepository) keepActiveAdmin(ctx context.Context, id primitive.ObjectID, undo bson.M) error {
+	admins, err := r.collection.CountDocuments(ctx, bson.M{"role": models.RoleAdmin, "is_active": true})
 	if err != nil {
 		return err
 	}
 
-	if result.MatchedCount == 0 {
-		return ErrUserNotFound
+	if admins > 0 {
+		return nil
 	}
 
-	return nil
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": undo}); err != nil {
+		return err
+	}
+
+	return ErrLastActiveAdmin
 }
 
 // List retorna uma lista de usuários com filtros, paginação
 func (r *MongoUserRepository) List(ctx context.Context, filter bson.M, limit, offset int) ([]*models.User, error) {
-	var users []*models.User
+	users := []*models.User{}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+	if offset > 0 {
+		findOptions.SetSkip(int64(offset))
+	}
 
-	cursor, err := r.collection.Find(ctx, filter)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -179,3 +257,255 @@ func (r *MongoUserRepository) Count(ctx context.Context, filter bson.M) (int64,
 	}
 	return count, nil
 }
+
+// ReserveLoginAttempt conta uma tentativa de login como falha antes de a senha
+// ou o código serem verificados e retorna o novo total. O incremento só
+// acontece se o contador e o bloqueio ainda estiverem como em user; se outra
+// tentativa os alterou antes, retorna ErrLoginAttemptConflict para que o
+// bloqueio seja verificado de novo.
+func (r *MongoUserRepository) ReserveLoginAttempt(ctx context.Context, user *models.User, at time.Time) (int, error) {
+	// Datas vazias comparadas com nil também casam com campos ausentes
+	filter := bson.M{
+		"_id":                   user.ID,
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"last_failed_login_at":  user.LastFailedLoginAt,
+		"locked_until":          user.LockedUntil,
+	}
+	if user.FailedLoginAttempts == 0 {
+		// Usuários anteriores ao contador não têm o campo
+		filter["failed_login_attempts"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	update := bson.M{
+		"$inc": bson.M{"failed_login_attempts": 1},
+		"$set": bson.M{"last_failed_login_at": at},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated models.User
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			if _, err := r.FindByID(ctx, user.ID.Hex()); err != nil {
+				return 0, err
+			}
+			return 0, ErrLoginAttemptConflict
+		}
+		return 0, err
+	}
+
+	return updated.FailedLoginAttempts, nil
+}
+
+// ReleaseLoginAttempt desfaz a reserva feita em at, quando a senha estava
+// correta mas o login continua pendente. O contador é decrementado e, se
+// nenhuma outra tentativa foi reservada depois, a última falha volta a ser
+// lastFailure.
+func (r *MongoUserRepository) ReleaseLoginAttempt(ctx context.Context, id string, at time.Time, lastFailure *time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	restore := bson.M{"$inc": bson.M{"failed_login_attempts": -1}}
+	if lastFailure != nil {
+		restore["$set"] = bson.M{"last_failed_login_at": *lastFailure}
+	} else {
+		restore["$unset"] = bson.M{"last_failed_login_at": ""}
+	}
+
+	filter := bson.M{"_id": objectID, "last_failed_login_at": at, "failed_login_attempts": bson.M{"$gt": 0}}
+	result, err := r.collection.UpdateOne(ctx, filter, restore)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	// Outra tentativa foi reservada depois; a última falha continua sendo a dela
+	filter = bson.M{"_id": objectID, "failed_login_attempts": bson.M{"$gt": 0}}
+	_, err = r.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"failed_login_attempts": -1}})
+	return err
+}
+
+// MarkLoginFailure avança o horário da última falha para at, sem voltar atrás
+// se outra tentativa já registrou uma falha mais recente
+func (r *MongoUserRepository) MarkLoginFailure(ctx context.Context, id string, at time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$max": bson.M{"last_failed_login_at": at}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Lock bloqueia o login do usuário até o horário informado
+func (r *MongoUserRepository) Lock(ctx context.Context, id string, until time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"locked_until": until}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ClearLoginFailures zera o contador de falhas e remove o bloqueio do usuário
+func (r *MongoUserRepository) ClearLoginFailures(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	update := bson.M{
+		"$set":   bson.M{"failed_login_attempts": 0},
+		"$unset": bson.M{"last_failed_login_at": "", "locked_until": ""},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateMFA grava a configuração de autenticação em dois fatores do usuário
+func (r *MongoUserRepository) UpdateMFA(ctx context.Context, user *models.User) error {
+	update := bson.M{
+		"$set": bson.M{
+			"mfa_enabled":        user.MFAEnabled,
+			"mfa_secret":         user.MFASecret,
+			"mfa_pending_secret": user.MFAPendingSecret,
+			"mfa_recovery_codes": user.MFARecoveryCodes,
+			"mfa_last_used_step": user.MFALastUsedStep,
+			"updated_at":         time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": user.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConsumeMFAStep registra o uso do código TOTP do passo informado, apenas se
+// nenhum código do mesmo passo ou de um posterior já foi usado. Retorna false
+// se o código já foi consumido, inclusive por uma requisição concorrente.
+func (r *MongoUserRepository) ConsumeMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, ErrUserNotFound
+	}
+
+	filter := bson.M{
+		"_id":         objectID,
+		"mfa_enabled": true,
+		"$or": bson.A{
+			bson.M{"mfa_last_used_step": bson.M{"$lt": step}},
+			bson.M{"mfa_last_used_step": bson.M{"$exists": false}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{"mfa_last_used_step": step, "updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// ConsumeRecoveryCode descarta o código de recuperação com o hash informado.
+// Retorna false se o código não existe ou já foi usado.
+func (r *MongoUserRepository) ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, ErrUserNotFound
+	}
+
+	filter := bson.M{"_id": objectID, "mfa_enabled": true, "mfa_recovery_codes": hash}
+	update := bson.M{
+		"$pull": bson.M{"mfa_recovery_codes": hash},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// SetVolunteer vincula o usuário a um voluntário; um ID vazio desfaz o vínculo
+func (r *MongoUserRepository) SetVolunteer(ctx context.Context, id string, volunteerID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{"volunteer_id": volunteerID, "updated_at": time.Now()},
+	}
+	if volunteerID == "" {
+		update = bson.M{
+			"$unset": bson.M{"volunteer_id": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		// O índice único impede dois usuários com o mesmo voluntário
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrVolunteerAlreadyLinked
+		}
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// EnsureIndexes cria o índice único do voluntário vinculado. O índice é
+// esparso porque a maioria dos usuários não tem voluntário.
+func (r *MongoUserRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "volunteer_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetSparse(true),
+	})
+	return err
+}
@@ -12,25 +12,63 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var (
+	// ErrVolunteerNotFound é retornado quando o voluntário não é encontrado
+	ErrVolunteerNotFound = errors.New("voluntário não encontrado")
+)
+
+// Filtros de deleted_at para separar os voluntários da lixeira dos demais
+var (
+	notDeleted = bson.M{"$exists": false}
+	deleted    = bson.M{"$exists": true}
+)
+
 // VolunteerRepository define a interface para operações de voluntários
 type VolunteerRepository interface {
 	Create(ctx context.Context, volunteer *models.Volunteer) error
 	FindByID(ctx context.Context, id string) (*models.Volunteer, error)
+	FindDeletedByID(ctx context.Context, id string) (*models.Volunteer, error)
 	FindByEmail(ctx context.Context, email string) (*models.Volunteer, error)
 	FindAll(ctx context.Context, filter VolunteerFilter) ([]*models.Volunteer, error)
+	Count(ctx context.Context, filter VolunteerFilter) (int64, error)
+	FindDeletedBefore(ctx context.Context, before time.Time) ([]*models.Volunteer, error)
 	Update(ctx context.Context, id string, volunteer *models.Volunteer) error
-	Delete(ctx context.Context, id string) error
-	Inactivate(ctx context.Context, id string, exitDate time.Time) error
+	Delete(ctx context.Context, id string, deletedBy string) error
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
+	UpdateServicePeriods(ctx context.Context, id string, periods []models.ServicePeriod) error
 	AddWorkshop(ctx context.Context, volunteerID string, workshopID string) error
 	RemoveWorkshop(ctx context.Context, volunteerID string, workshopID string) error
+	RemoveWorkshopFromAll(ctx context.Context, workshopID string) (int64, error)
+	EnsureIndexes(ctx context.Context) error
 }
 
 // VolunteerFilter representa os filtros para busca de voluntários
 type VolunteerFilter struct {
 	Name     string
 	IsActive *bool
+	Deleted  bool // lista apenas a lixeira em vez dos voluntários ativos e inativos
 	Page     int
 	Limit    int
+	After    *models.Cursor // paginação por cursor: apenas registros depois dele, ignorando Page
+}
+
+// query monta o filtro BSON compartilhado pela listagem e pela contagem
+func (f VolunteerFilter) query() bson.M {
+	bsonFilter := bson.M{"deleted_at": notDeleted}
+	if f.Deleted {
+		bsonFilter["deleted_at"] = deleted
+	}
+
+	if f.Name != "" {
+		bsonFilter["name"] = bson.M{"$regex": f.Name, "$options": "i"}
+	}
+
+	if f.IsActive != nil {
+		bsonFilter["is_active"] = *f.IsActive
+	}
+
+	return bsonFilter
 }
 
 // MongoVolunteerRepository implementa VolunteerRepository usando MongoDB
@@ -56,18 +94,28 @@ func (r *MongoVolunteerRepository) Create(ctx context.Context, volunteer *models
 	return nil
 }
 
-// FindByID busca um voluntário por ID
+// FindByID busca um voluntário por ID, fora da lixeira
 func (r *MongoVolunteerRepository) FindByID(ctx context.Context, id string) (*models.Volunteer, error) {
+	return r.findByID(ctx, id, notDeleted)
+}
+
+// FindDeletedByID busca um voluntário da lixeira por ID
+func (r *MongoVolunteerRepository) FindDeletedByID(ctx context.Context, id string) (*models.Volunteer, error) {
+	return r.findByID(ctx, id, deleted)
+}
+
+// findByID busca um voluntário por ID conforme o filtro de deleted_at
+func (r *MongoVolunteerRepository) findByID(ctx context.Context, id string, deletedFilter bson.M) (*models.Volunteer, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, errors.New("ID inválido")
 	}
 
 	var volunteer models.Volunteer
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&volunteer)
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "deleted_at": deletedFilter}).Decode(&volunteer)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("voluntário não encontrado")
+			return nil, ErrVolunteerNotFound
 		}
 		return nil, err
 	}
@@ -75,7 +123,7 @@ func (r *MongoVolunteerRepository) FindByID(ctx context.Context, id string) (*mo
 	return &volunteer, nil
 }
 
-// FindByEmail busca um voluntário por email
+// FindByEmail busca um voluntário por email, inclusive na lixeira
 func (r *MongoVolunteerRepository) FindByEmail(ctx context.Context, email string) (*models.Volunteer, error) {
 	var volunteer models.Volunteer
 	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&volunteer)
@@ -91,27 +139,27 @@ func (r *MongoVolunteerRepository) FindByEmail(ctx context.Context, email string
 
 // FindAll busca todos os voluntários com filtros opcionais
 func (r *MongoVolunteerRepository) FindAll(ctx context.Context, filter VolunteerFilter) ([]*models.Volunteer, error) {
-	// Construir filtro BSON
-	bsonFilter := bson.M{}
-	
-	if filter.Name != "" {
-		bsonFilter["name"] = bson.M{"$regex": filter.Name, "$options": "i"}
-	}
-	
-	if filter.IsActive != nil {
-		bsonFilter["is_active"] = *filter.IsActive
+	bsonFilter := filter.query()
+
+	// O cursor não entra em Count: o total é o da listagem inteira
+	if filter.After != nil {
+		bsonFilter["$or"] = bson.A{
+			bson.M{"created_at": bson.M{"$lt": filter.After.CreatedAt}},
+			bson.M{"created_at": filter.After.CreatedAt, "_id": bson.M{"$lt": filter.After.ID}},
+		}
 	}
 
 	// Configurar paginação
 	findOptions := options.Find()
 	if filter.Limit > 0 {
 		findOptions.SetLimit(int64(filter.Limit))
-		if filter.Page > 0 {
+		if filter.Page > 0 && filter.After == nil {
 			skip := (filter.Page - 1) * filter.Limit
 			findOptions.SetSkip(int64(skip))
 		}
 	}
-	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}})
+	// O _id desempata registros criados no mesmo instante, mantendo a ordem estável
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
 
 	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
 	if err != nil {
@@ -127,6 +175,11 @@ func (r *MongoVolunteerRepository) FindAll(ctx context.Context, filter Volunteer
 	return volunteers, nil
 }
 
+// Count conta os voluntários que correspondem ao filtro, sem considerar a paginação
+func (r *MongoVolunteerRepository) Count(ctx context.Context, filter VolunteerFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter.query())
+}
+
 // Update atualiza um voluntário
 func (r *MongoVolunteerRepository) Update(ctx context.Context, id string, volunteer *models.Volunteer) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
@@ -146,58 +199,114 @@ func (r *MongoVolunteerRepository) Update(ctx context.Context, id string, volunt
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("voluntário não encontrado")
+		return ErrVolunteerNotFound
 	}
 
 	return nil
 }
 
-// Delete deleta um voluntário
-func (r *MongoVolunteerRepository) Delete(ctx context.Context, id string) error {
+// FindDeletedBefore busca os voluntários que estão na lixeira desde antes da data
+func (r *MongoVolunteerRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*models.Volunteer, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"deleted_at": bson.M{"$lt": before}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	volunteers := []*models.Volunteer{}
+	if err = cursor.All(ctx, &volunteers); err != nil {
+		return nil, err
+	}
+
+	return volunteers, nil
+}
+
+// Delete move um voluntário para a lixeira
+func (r *MongoVolunteerRepository) Delete(ctx context.Context, id string, deletedBy string) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"deleted_by": deletedBy,
+			"updated_at": now,
+		},
+	}
+
+	return r.updateOne(ctx, id, notDeleted, update)
+}
+
+// Restore retira um voluntário da lixeira
+func (r *MongoVolunteerRepository) Restore(ctx context.Context, id string) error {
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+
+	return r.updateOne(ctx, id, deleted, update)
+}
+
+// updateOne aplica a alteração ao voluntário conforme o filtro de deleted_at
+func (r *MongoVolunteerRepository) updateOne(ctx context.Context, id string, deletedFilter bson.M, update bson.M) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return errors.New("ID inválido")
 	}
 
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "deleted_at": deletedFilter}, update)
 	if err != nil {
 		return err
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.New("voluntário não encontrado")
+	if result.MatchedCount == 0 {
+		return ErrVolunteerNotFound
 	}
 
 	return nil
 }
 
-// Inactivate inativa um voluntário
-func (r *MongoVolunteerRepository) Inactivate(ctx context.Context, id string, exitDate time.Time) error {
+// Purge remove definitivamente um voluntário que está na lixeira
+func (r *MongoVolunteerRepository) Purge(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return errors.New("ID inválido")
 	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"is_active":  false,
-			"exit_date":  exitDate,
-			"updated_at": time.Now(),
-		},
-	}
-
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID, "deleted_at": deleted})
 	if err != nil {
 		return err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.New("voluntário não encontrado")
+	if result.DeletedCount == 0 {
+		return ErrVolunteerNotFound
 	}
 
 	return nil
 }
 
+// UpdateServicePeriods grava os períodos de atuação do voluntário. A situação
+// e a data de saída acompanham o último período: em andamento, o voluntário
+// fica ativo e sem data de saída.
+func (r *MongoVolunteerRepository) UpdateServicePeriods(ctx context.Context, id string, periods []models.ServicePeriod) error {
+	if len(periods) == 0 {
+		return errors.New("voluntário precisa ter ao menos um período de atuação")
+	}
+	current := periods[len(periods)-1]
+
+	set := bson.M{
+		"service_periods": periods,
+		"is_active":       current.ExitDate == nil,
+		"updated_at":      time.Now(),
+	}
+	update := bson.M{"$set": set}
+	if current.ExitDate != nil {
+		set["exit_date"] = *current.ExitDate
+	} else {
+		update["$unset"] = bson.M{"exit_date": ""}
+	}
+
+	return r.updateOne(ctx, id, notDeleted, update)
+}
+
 // AddWorkshop adiciona uma oficina ao voluntário
 func (r *MongoVolunteerRepository) AddWorkshop(ctx context.Context, volunteerID string, workshopID string) error {
 	objectID, err := primitive.ObjectIDFromHex(volunteerID)
@@ -216,7 +325,7 @@ func (r *MongoVolunteerRepository) AddWorkshop(ctx context.Context, volunteerID
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("voluntário não encontrado")
+		return ErrVolunteerNotFound
 	}
 
 	return nil
@@ -240,8 +349,32 @@ func (r *MongoVolunteerRepository) RemoveWorkshop(ctx context.Context, volunteer
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("voluntário não encontrado")
+		return ErrVolunteerNotFound
 	}
 
 	return nil
 }
+
+// RemoveWorkshopFromAll remove uma oficina do histórico de todos os voluntários
+func (r *MongoVolunteerRepository) RemoveWorkshopFromAll(ctx context.Context, workshopID string) (int64, error) {
+	update := bson.M{
+		"$pull": bson.M{"workshops": workshopID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{"workshops": workshopID}, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// EnsureIndexes cria o índice usado pela listagem paginada, na mesma ordem
+// de FindAll
+func (r *MongoVolunteerRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+	})
+	return err
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrVolunteerRevisionNotFound é retornado quando não há versão do voluntário na data informada
+var ErrVolunteerRevisionNotFound = errors.New("não há versão do voluntário na data informada")
+
+// ErrVolunteerRevisionConflict é retornado quando outra alteração já gravou a mesma versão do voluntário
+var ErrVolunteerRevisionConflict = errors.New("versão do voluntário já gravada por outra alteração")
+
+// VolunteerRevisionRepository define a interface do histórico de versões dos
+// voluntários. As revisões não são alteradas e só são removidas junto com o
+// voluntário, quando ele é excluído definitivamente.
+type VolunteerRevisionRepository interface {
+	Create(ctx context.Context, revision *models.VolunteerRevision) error
+	FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.VolunteerRevision, error)
+	CountByVolunteer(ctx context.Context, volunteerID string) (int64, error)
+	FindLatest(ctx context.Context, volunteerID string) (*models.VolunteerRevision, error)
+	FindAsOf(ctx context.Context, volunteerID string, at time.Time) (*models.VolunteerRevision, error)
+	DeleteByVolunteer(ctx context.Context, volunteerID string) error
+	EnsureIndexes(ctx context.Context) error
+}
+
+// MongoVolunteerRevisionRepository implementa VolunteerRevisionRepository usando MongoDB
+type MongoVolunteerRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoVolunteerRevisionRepository cria uma nova instância do repositório
+func NewMongoVolunteerRevisionRepository(db *mongo.Database) VolunteerRevisionRepository {
+	return &MongoVolunteerRevisionRepository{
+		collection: db.Collection("volunteer_revisions"),
+	}
+}
+
+// Create grava uma revisão do voluntário. O índice único de EnsureIndexes
+// impede que duas alterações simultâneas gravem o mesmo número de versão.
+func (r *MongoVolunteerRevisionRepository) Create(ctx context.Context, revision *models.VolunteerRevision) error {
+	result, err := r.collection.InsertOne(ctx, revision)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrVolunteerRevisionConflict
+		}
+		return err
+	}
+
+	revision.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByVolunteer lista uma página das revisões de um voluntário, da mais
+// recente à mais antiga
+func (r *MongoVolunteerRevisionRepository) FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.VolunteerRevision, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+		if page > 0 {
+			findOptions.SetSkip(int64((page - 1) * limit))
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"volunteer_id": volunteerID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*models.VolunteerRevision{}
+	if err = cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// CountByVolunteer conta as revisões de um voluntário
+func (r *MongoVolunteerRevisionRepository) CountByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"volunteer_id": volunteerID})
+}
+
+// FindLatest busca a revisão mais recente de um voluntário
+func (r *MongoVolunteerRevisionRepository) FindLatest(ctx context.Context, volunteerID string) (*models.VolunteerRevision, error) {
+	return r.findOne(ctx, bson.M{"volunteer_id": volunteerID})
+}
+
+// FindAsOf busca a revisão que estava valendo na data informada
+func (r *MongoVolunteerRevisionRepository) FindAsOf(ctx context.Context, volunteerID string, at time.Time) (*models.VolunteerRevision, error) {
+	return r.findOne(ctx, bson.M{
+		"volunteer_id": volunteerID,
+		"recorded_at":  bson.M{"$lte": at},
+	})
+}
+
+// findOne busca a revisão de maior versão que atende ao filtro
+func (r *MongoVolunteerRevisionRepository) findOne(ctx context.Context, filter bson.M) (*models.VolunteerRevision, error) {
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var revision models.VolunteerRevision
+	err := r.collection.FindOne(ctx, filter, findOptions).Decode(&revision)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrVolunteerRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// DeleteByVolunteer remove todas as revisões de um voluntário
+func (r *MongoVolunteerRevisionRepository) DeleteByVolunteer(ctx context.Context, volunteerID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"volunteer_id": volunteerID})
+	return err
+}
+
+// EnsureIndexes cria o índice único de versão por voluntário, usado também
+// para buscar a versão mais recente e a versão vigente em uma data
+func (r *MongoVolunteerRevisionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "volunteer_id", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
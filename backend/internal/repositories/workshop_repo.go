@@ -0,0 +1,469 @@
+package repositories
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// ErrWorkshopNotFound é retornado quando a oficina não é encontrada
+	ErrWorkshopNotFound = errors.New("oficina não encontrada")
+	// ErrWorkshopClosed é retornado quando a oficina não aceita novas inscrições
+	ErrWorkshopClosed = errors.New("oficina não está aberta para inscrições")
+	// ErrWorkshopCancelled é retornado ao atualizar uma oficina já cancelada
+	ErrWorkshopCancelled = errors.New("oficina cancelada")
+	// ErrWorkshopOverCapacity é retornado quando a oficina tem mais inscritos que a nova capacidade
+	ErrWorkshopOverCapacity = errors.New("oficina tem mais inscritos que a capacidade informada")
+)
+
+// maxPromotionAttempts limita as tentativas de promoção da fila de espera sob concorrência
+const maxPromotionAttempts = 5
+
+// WorkshopRepository define a interface para operações de oficinas
+type WorkshopRepository interface {
+	Create(ctx context.Context, workshop *models.Workshop) error
+	FindByID(ctx context.Context, id string) (*models.Workshop, error)
+	FindAll(ctx context.Context, filter WorkshopFilter) ([]*models.Workshop, error)
+	Count(ctx context.Context, filter WorkshopFilter) (int64, error)
+	Update(ctx context.Context, id string, workshop *models.Workshop) error
+	Delete(ctx context.Context, id string) error
+	Cancel(ctx context.Context, id string) error
+	Enroll(ctx context.Context, workshopID string, volunteerID string) (models.EnrollmentStatus, error)
+	Unenroll(ctx context.Context, workshopID string, volunteerID string) (bool, error)
+	RemoveVolunteerFromAll(ctx context.Context, volunteerID string) (int64, error)
+	PromoteFromWaitlist(ctx context.Context, workshopID string) (string, error)
+	AddCoordinator(ctx context.Context, workshopID string, userID string) error
+	RemoveCoordinator(ctx context.Context, workshopID string, userID string) error
+}
+
+// WorkshopFilter representa os filtros para busca de oficinas
+type WorkshopFilter struct {
+	Title       string
+	Instructor  string
+	Status      string
+	Coordinator string // ID do usuário coordenador
+	Volunteer   string // ID do voluntário inscrito ou na fila de espera
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	Page        int
+	Limit       int
+}
+
+// query monta o filtro BSON compartilhado pela listagem e pela contagem
+func (f WorkshopFilter) query() bson.M {
+	bsonFilter := bson.M{}
+
+	if f.Title != "" {
+		bsonFilter["title"] = bson.M{"$regex": f.Title, "$options": "i"}
+	}
+
+	if f.Instructor != "" {
+		bsonFilter["instructor"] = bson.M{"$regex": f.Instructor, "$options": "i"}
+	}
+
+	if f.Status != "" {
+		bsonFilter["status"] = f.Status
+	}
+
+	if f.Coordinator != "" {
+		bsonFilter["coordinators"] = f.Coordinator
+	}
+
+	if f.Volunteer != "" {
+		bsonFilter["$or"] = bson.A{
+			bson.M{"enrolled": f.Volunteer},
+			bson.M{"waitlist": f.Volunteer},
+		}
+	}
+
+	if f.DateFrom != nil || f.DateTo != nil {
+		dateFilter := bson.M{}
+		if f.DateFrom != nil {
+			dateFilter["$gte"] = *f.DateFrom
+		}
+		if f.DateTo != nil {
+			dateFilter["$lte"] = *f.DateTo
+		}
+		bsonFilter["date"] = dateFilter
+	}
+
+	return bsonFilter
+}
+
+// MongoWorkshopRepository implementa WorkshopRepository usando MongoDB
+type MongoWorkshopRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoWorkshopRepository cria uma nova instância do repositório
+func NewMongoWorkshopRepository(db *mongo.Database) WorkshopRepository {
+	return &MongoWorkshopRepository{
+		collection: db.Collection("workshops"),
+	}
+}
+
+// Create cria uma nova oficina
+func (r *MongoWorkshopRepository) Create(ctx context.Context, workshop *models.Workshop) error {
+	result, err := r.collection.InsertOne(ctx, workshop)
+	if err != nil {
+		return err
+	}
+
+	workshop.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID busca uma oficina por ID
+func (r *MongoWorkshopRepository) FindByID(ctx context.Context, id string) (*models.Workshop, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrWorkshopNotFound
+	}
+
+	var workshop models.Workshop
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&workshop)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrWorkshopNotFound
+		}
+		return nil, err
+	}
+
+	return &workshop, nil
+}
+
+// FindAll busca todas as oficinas com filtros opcionais
+func (r *MongoWorkshopRepository) FindAll(ctx context.Context, filter WorkshopFilter) ([]*models.Workshop, error) {
+	bsonFilter := filter.query()
+
+	// Configurar paginação
+	findOptions := options.Find()
+	if filter.Limit > 0 {
+		findOptions.SetLimit(int64(filter.Limit))
+		if filter.Page > 0 {
+			skip := (filter.Page - 1) * filter.Limit
+			findOptions.SetSkip(int64(skip))
+		}
+	}
+	findOptions.SetSort(bson.D{{Key: "date", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var workshops []*models.Workshop
+	if err = cursor.All(ctx, &workshops); err != nil {
+		return nil, err
+	}
+
+	return workshops, nil
+}
+
+// Count conta as oficinas que correspondem ao filtro, sem considerar a paginação
+func (r *MongoWorkshopRepository) Count(ctx context.Context, filter WorkshopFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, filter.query())
+}
+
+// Update atualiza os dados editáveis de uma oficina que não esteja cancelada e
+// cujos inscritos caibam na nova capacidade
+func (r *MongoWorkshopRepository) Update(ctx context.Context, id string, workshop *models.Workshop) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrWorkshopNotFound
+	}
+
+	workshop.UpdatedAt = time.Now()
+
+	// As condições são verificadas no próprio documento, e não na leitura feita
+	// pelo serviço: um cancelamento ou uma inscrição concorrente recusa a gravação
+	filter := bson.M{
+		"_id":    objectID,
+		"status": bson.M{"$ne": models.WorkshopStatusCancelled},
+	}
+	if !workshop.HasUnlimitedCapacity() {
+		enrolled := bson.M{"$size": bson.M{"$ifNull": bson.A{"$enrolled", bson.A{}}}}
+		filter["$expr"] = bson.M{"$lte": bson.A{enrolled, workshop.Capacity}}
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"title":       workshop.Title,
+			"description": workshop.Description,
+			"instructor":  workshop.Instructor,
+			"date":        workshop.Date,
+			"location":    workshop.Location,
+			"capacity":    workshop.Capacity,
+			"status":      workshop.Status,
+			"updated_at":  workshop.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		current, err := r.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if current.IsCancelled() {
+			return ErrWorkshopCancelled
+		}
+		return ErrWorkshopOverCapacity
+	}
+
+	return nil
+}
+
+// Delete deleta uma oficina
+func (r *MongoWorkshopRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrWorkshopNotFound
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrWorkshopNotFound
+	}
+
+	return nil
+}
+
+// Cancel marca uma oficina como cancelada
+func (r *MongoWorkshopRepository) Cancel(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrWorkshopNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     models.WorkshopStatusCancelled,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrWorkshopNotFound
+	}
+
+	return nil
+}
+
+// AddCoordinator vincula um usuário à coordenação da oficina
+func (r *MongoWorkshopRepository) AddCoordinator(ctx context.Context, workshopID string, userID string) error {
+	return r.updateCoordinators(ctx, workshopID, bson.M{"$addToSet": bson.M{"coordinators": userID}})
+}
+
+// RemoveCoordinator desvincula um usuário da coordenação da oficina
+func (r *MongoWorkshopRepository) RemoveCoordinator(ctx context.Context, workshopID string, userID string) error {
+	return r.updateCoordinators(ctx, workshopID, bson.M{"$pull": bson.M{"coordinators": userID}})
+}
+
+// updateCoordinators aplica a alteração na lista de coordenadores da oficina
+func (r *MongoWorkshopRepository) updateCoordinators(ctx context.Context, workshopID string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(workshopID)
+	if err != nil {
+		return ErrWorkshopNotFound
+	}
+
+	update["$set"] = bson.M{"updated_at": time.Now()}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrWorkshopNotFound
+	}
+
+	return nil
+}
+
+// hasOpenSeat retorna a condição que só é satisfeita se ainda houver vaga livre
+func hasOpenSeat() bson.M {
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"capacity": bson.M{"$lte": 0}},
+			bson.M{"$expr": bson.M{
+				"$lt": bson.A{bson.M{"$size": bson.M{"$ifNull": bson.A{"$enrolled", bson.A{}}}}, "$capacity"},
+			}},
+		},
+	}
+}
+
+// Enroll inscreve o voluntário na oficina, ocupando uma vaga se houver ou
+// colocando-o no fim da fila de espera. A verificação de vaga e a inscrição
+// acontecem em uma única operação atômica no documento da oficina.
+func (r *MongoWorkshopRepository) Enroll(ctx context.Context, workshopID string, volunteerID string) (models.EnrollmentStatus, error) {
+	objectID, err := primitive.ObjectIDFromHex(workshopID)
+	if err != nil {
+		return "", ErrWorkshopNotFound
+	}
+
+	notListed := bson.M{
+		"_id":      objectID,
+		"status":   bson.M{"$ne": models.WorkshopStatusCancelled},
+		"enrolled": bson.M{"$ne": volunteerID},
+		"waitlist": bson.M{"$ne": volunteerID},
+	}
+
+	// Tenta ocupar uma vaga
+	seatFilter := bson.M{"$and": bson.A{notListed, hasOpenSeat()}}
+	result, err := r.collection.UpdateOne(ctx, seatFilter, bson.M{
+		"$push": bson.M{"enrolled": volunteerID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.MatchedCount > 0 {
+		return models.EnrollmentEnrolled, nil
+	}
+
+	// Sem vagas: entra no fim da fila de espera
+	result, err = r.collection.UpdateOne(ctx, notListed, bson.M{
+		"$push": bson.M{"waitlist": volunteerID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.MatchedCount > 0 {
+		return models.EnrollmentWaitlisted, nil
+	}
+
+	// Nenhuma atualização: a oficina não existe, está cancelada ou o voluntário já está listado
+	workshop, err := r.FindByID(ctx, workshopID)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case workshop.IsEnrolled(volunteerID):
+		return models.EnrollmentEnrolled, nil
+	case workshop.IsWaitlisted(volunteerID):
+		return models.EnrollmentWaitlisted, nil
+	default:
+		return "", ErrWorkshopClosed
+	}
+}
+
+// Unenroll remove o voluntário das vagas e da fila de espera da oficina.
+// Retorna true se o voluntário ocupava uma vaga.
+func (r *MongoWorkshopRepository) Unenroll(ctx context.Context, workshopID string, volunteerID string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(workshopID)
+	if err != nil {
+		return false, ErrWorkshopNotFound
+	}
+
+	update := bson.M{
+		"$pull": bson.M{"enrolled": volunteerID, "waitlist": volunteerID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var before models.Workshop
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&before)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, ErrWorkshopNotFound
+		}
+		return false, err
+	}
+
+	return before.IsEnrolled(volunteerID), nil
+}
+
+// RemoveVolunteerFromAll remove o voluntário das vagas e da fila de espera de
+// todas as oficinas, sem promover a fila. Usado na exclusão definitiva, quando
+// as inscrições em oficinas futuras já foram liberadas.
+func (r *MongoWorkshopRepository) RemoveVolunteerFromAll(ctx context.Context, volunteerID string) (int64, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"enrolled": volunteerID},
+			bson.M{"waitlist": volunteerID},
+		},
+	}
+	update := bson.M{
+		"$pull": bson.M{"enrolled": volunteerID, "waitlist": volunteerID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// PromoteFromWaitlist move o primeiro voluntário da fila de espera para uma vaga
+// livre, se houver. Retorna o ID do voluntário promovido ou "" se ninguém foi promovido.
+func (r *MongoWorkshopRepository) PromoteFromWaitlist(ctx context.Context, workshopID string) (string, error) {
+	objectID, err := primitive.ObjectIDFromHex(workshopID)
+	if err != nil {
+		return "", ErrWorkshopNotFound
+	}
+
+	for attempt := 0; attempt < maxPromotionAttempts; attempt++ {
+		workshop, err := r.FindByID(ctx, workshopID)
+		if err != nil {
+			return "", err
+		}
+
+		if workshop.IsCancelled() || len(workshop.Waitlist) == 0 {
+			return "", nil
+		}
+		if !workshop.HasUnlimitedCapacity() && workshop.AvailableSeats() == 0 {
+			return "", nil
+		}
+
+		// Só promove se o primeiro da fila ainda for o mesmo e ainda houver vaga
+		next := workshop.Waitlist[0]
+		filter := bson.M{"$and": bson.A{
+			bson.M{
+				"_id":        objectID,
+				"status":     bson.M{"$ne": models.WorkshopStatusCancelled},
+				"waitlist.0": next,
+			},
+			hasOpenSeat(),
+		}}
+		update := bson.M{
+			"$pop":  bson.M{"waitlist": -1},
+			"$push": bson.M{"enrolled": next},
+			"$set":  bson.M{"updated_at": time.Now()},
+		}
+
+		result, err := r.collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return "", err
+		}
+		if result.MatchedCount > 0 {
+			return next, nil
+		}
+	}
+
+	return "", nil
+}
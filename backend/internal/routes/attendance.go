@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAttendanceRoutes configura as rotas de presença e horas dos voluntários
+func SetupAttendanceRoutes(router *gin.Engine, attendanceHandler *handlers.AttendanceHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas de presença
+	attendance := router.Group("/api/attendance")
+	{
+		// Rotas protegidas - requer autenticação e a permissão de cada rota
+		attendance.Use(authMiddleware.RequireAuth())
+		{
+			read := middleware.RequirePermission(models.PermAttendanceRead)
+			// Coordenadores lançam presenças nas oficinas que coordenam; o serviço verifica a oficina
+			write := middleware.RequireAnyPermission(models.PermAttendanceWrite, models.PermWorkshopsCoordinate)
+
+			// Lançamento e correção
+			attendance.POST("", write, attendanceHandler.Record)       // Lançar horas
+			attendance.GET("", read, attendanceHandler.GetAll)         // Listar
+			attendance.GET("/:id", read, attendanceHandler.GetByID)    // Buscar por ID
+			attendance.PUT("/:id", write, attendanceHandler.Update)    // Corrigir
+			attendance.DELETE("/:id", write, attendanceHandler.Delete) // Remover
+
+			// Check-in / check-out
+			attendance.POST("/check-in", write, attendanceHandler.CheckIn)       // Registrar entrada
+			attendance.POST("/:id/check-out", write, attendanceHandler.CheckOut) // Registrar saída
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAuditRoutes configura a rota de consulta da trilha de auditoria
+func SetupAuditRoutes(router *gin.Engine, auditHandler *handlers.AuditHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas de auditoria
+	audit := router.Group("/api/audit")
+	{
+		// Rotas protegidas - apenas administradores consultam a auditoria
+		audit.Use(authMiddleware.RequireAuth(), middleware.RequirePermission(models.PermAuditRead))
+		{
+			audit.GET("", auditHandler.GetAll) // Listar operações
+		}
+	}
+}
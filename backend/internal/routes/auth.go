@@ -3,25 +3,48 @@ package routes
 import (
 	"ellp-volunter-platform/backend/internal/handlers"
 	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupAuthRoutes configura todas as rotas de autenticação
-func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler) {
+func SetupAuthRoutes(router *gin.Engine, authHandler *handlers.AuthHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Limite por IP nas tentativas de login
+	loginLimiter := middleware.NewRateLimiter(10, time.Minute)
+
 	// Rotas públicas de autenticação
 	authRoutes := router.Group("/api/auth")
 	{
-		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.POST("/login", middleware.RateLimit(loginLimiter), authHandler.Login)
 		authRoutes.POST("/register", authHandler.Register)
 		authRoutes.POST("/logout", authHandler.Logout)
 		authRoutes.POST("/refresh", authHandler.RefreshTokenFromBody)
+
+		// Segunda etapa do login com autenticação em dois fatores
+		authRoutes.POST("/mfa/verify", middleware.RateLimit(loginLimiter), authHandler.VerifyMFA)
+		authRoutes.POST("/mfa/challenge/setup", middleware.RateLimit(loginLimiter), authHandler.MFAChallengeSetup)
 	}
 
 	// Rotas protegidas de autenticação (requerem token válido)
 	protectedAuthRoutes := router.Group("/api/auth")
-	protectedAuthRoutes.Use(middleware.AuthMiddlewareFunc())
+	protectedAuthRoutes.Use(authMiddleware.RequireAuth())
 	{
 		protectedAuthRoutes.GET("/me", authHandler.Me)
+		protectedAuthRoutes.PUT("/me", authHandler.UpdateMe)
+		protectedAuthRoutes.POST("/me/password", authHandler.ChangePassword)
+
+		// Autenticação em dois fatores do próprio usuário
+		protectedAuthRoutes.POST("/mfa/setup", authHandler.SetupMFA)
+		protectedAuthRoutes.POST("/mfa/enable", authHandler.EnableMFA)
+		protectedAuthRoutes.POST("/mfa/disable", authHandler.DisableMFA)
+
+		// Convites de cadastro - requer users:manage
+		protectedAuthRoutes.POST("/invites", middleware.RequirePermission(models.PermUsersManage), authHandler.CreateInvite)
+
+		// Políticas de segurança - requer settings:manage
+		protectedAuthRoutes.GET("/security-settings", middleware.RequirePermission(models.PermSettingsManage), authHandler.GetSecuritySettings)
+		protectedAuthRoutes.PUT("/security-settings", middleware.RequirePermission(models.PermSettingsManage), authHandler.UpdateSecuritySettings)
 	}
 }
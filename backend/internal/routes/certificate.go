@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCertificateRoutes configura as rotas de certificados de participação
+func SetupCertificateRoutes(router *gin.Engine, certificateHandler *handlers.CertificateHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Emissão e consulta por voluntário - requer autenticação e permissão
+	volunteers := router.Group("/api/volunteers")
+	volunteers.Use(authMiddleware.RequireAuth())
+	{
+		issue := middleware.RequirePermission(models.PermCertificatesIssue)
+		read := middleware.RequirePermission(models.PermVolunteersRead)
+
+		volunteers.GET("/:id/certificate.pdf", issue, certificateHandler.Download)   // Emitir certificado
+		volunteers.GET("/:id/certificates", read, certificateHandler.GetByVolunteer) // Certificados emitidos
+	}
+
+	certificates := router.Group("/api/certificates")
+	{
+		// Rota pública - verificação de autenticidade por terceiros
+		certificates.GET("/:code", certificateHandler.Verify)
+
+		// Rotas administrativas
+		admin := certificates.Group("")
+		admin.Use(authMiddleware.RequireAuth(), middleware.RequirePermission(models.PermCertificatesRevoke))
+		{
+			admin.POST("/:code/revoke", certificateHandler.Revoke) // Revogar certificado
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupJWKSRoutes configura a rota pública das chaves de assinatura
+func SetupJWKSRoutes(router *gin.Engine, jwksHandler *handlers.JWKSHandler) {
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+}
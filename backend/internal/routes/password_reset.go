@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPasswordResetRoutes configura as rotas públicas de recuperação de senha
+func SetupPasswordResetRoutes(router *gin.Engine, passwordResetHandler *handlers.PasswordResetHandler) {
+	// Limite por IP nos pedidos de email, para evitar o envio em massa
+	forgotLimiter := middleware.NewRateLimiter(5, 15*time.Minute)
+
+	authRoutes := router.Group("/api/auth")
+	{
+		// Enviar link por email
+		authRoutes.POST("/forgot-password", middleware.RateLimit(forgotLimiter), passwordResetHandler.ForgotPassword)
+		// Definir nova senha
+		authRoutes.POST("/reset-password", passwordResetHandler.ResetPassword)
+	}
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPortalRoutes configura as rotas do portal do voluntário. O voluntário
+// vem sempre da conta autenticada, nunca de parâmetros da requisição.
+func SetupPortalRoutes(router *gin.Engine, portalHandler *handlers.PortalHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas do portal
+	portal := router.Group("/api/portal")
+	{
+		// Rotas protegidas - requer autenticação e a permissão portal:access
+		portal.Use(authMiddleware.RequireAuth(), middleware.RequirePermission(models.PermPortalAccess))
+		{
+			portal.GET("/me", portalHandler.Profile)  // Meu cadastro
+			portal.GET("/hours", portalHandler.Hours) // Minhas horas
+
+			// Oficinas
+			portal.GET("/workshops", portalHandler.Workshops)                // Minhas oficinas
+			portal.GET("/workshops/open", portalHandler.OpenWorkshops)       // Oficinas abertas
+			portal.POST("/workshops/:workshop_id", portalHandler.Enroll)     // Inscrever-se
+			portal.DELETE("/workshops/:workshop_id", portalHandler.Unenroll) // Cancelar inscrição
+
+			// Certificados
+			portal.GET("/certificates", portalHandler.Certificates)   // Meus certificados
+			portal.GET("/certificate.pdf", portalHandler.Certificate) // Baixar certificado
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRoleRoutes configura as rotas de papéis e permissões
+func SetupRoleRoutes(router *gin.Engine, roleHandler *handlers.RoleHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas de papéis
+	roles := router.Group("/api/roles")
+	{
+		// Rotas administrativas - requer autenticação e a permissão roles:manage
+		roles.Use(authMiddleware.RequireAuth(), middleware.RequirePermission(models.PermRolesManage))
+		{
+			roles.GET("", roleHandler.List)                        // Listar
+			roles.GET("/permissions", roleHandler.ListPermissions) // Permissões disponíveis
+			roles.GET("/:name", roleHandler.GetByName)             // Buscar por nome
+			roles.POST("", roleHandler.Create)                     // Criar
+			roles.PUT("/:name", roleHandler.Update)                // Atualizar
+			roles.DELETE("/:name", roleHandler.Delete)             // Remover
+		}
+	}
+}
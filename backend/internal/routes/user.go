@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupUserRoutes configura as rotas de administração de usuários
+func SetupUserRoutes(router *gin.Engine, userHandler *handlers.UserHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas de usuários
+	users := router.Group("/api/users")
+	{
+		// Rotas administrativas - requer autenticação e a permissão users:manage
+		users.Use(authMiddleware.RequireAuth(), middleware.RequirePermission(models.PermUsersManage))
+		{
+			users.GET("", userHandler.List)                              // Listar
+			users.GET("/:id", userHandler.GetByID)                       // Buscar por ID
+			users.PUT("/:id/role", userHandler.ChangeRole)               // Alterar papel
+			users.POST("/:id/deactivate", userHandler.Deactivate)        // Desativar
+			users.POST("/:id/reactivate", userHandler.Reactivate)        // Reativar
+			users.POST("/:id/unlock", userHandler.Unlock)                // Desbloquear login
+			users.POST("/:id/mfa/reset", userHandler.ResetMFA)           // Remover MFA
+			users.POST("/:id/reset-password", userHandler.ResetPassword) // Redefinir senha
+			users.PUT("/:id/volunteer", userHandler.LinkVolunteer)       // Vincular voluntário
+			users.DELETE("/:id/volunteer", userHandler.UnlinkVolunteer)  // Desvincular voluntário
+		}
+	}
+}
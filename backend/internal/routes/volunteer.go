@@ -3,6 +3,7 @@ package routes
 import (
 	"ellp-volunter-platform/backend/internal/handlers"
 	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,23 +14,37 @@ func SetupVolunteerRoutes(router *gin.Engine, volunteerHandler *handlers.Volunte
 	volunteers := router.Group("/api/volunteers")
 	{
 		// Rotas públicas (se houver)
-		
-		// Rotas protegidas - requer autenticação
+
+		// Rotas protegidas - requer autenticação e a permissão de cada rota
 		volunteers.Use(authMiddleware.RequireAuth())
 		{
+			read := middleware.RequirePermission(models.PermVolunteersRead)
+			write := middleware.RequirePermission(models.PermVolunteersWrite)
+			remove := middleware.RequirePermission(models.PermVolunteersDelete)
+			purge := middleware.RequirePermission(models.PermVolunteersPurge)
+			// Coordenadores inscrevem voluntários nas oficinas que coordenam; o serviço verifica a oficina
+			enroll := middleware.RequireAnyPermission(models.PermVolunteersWrite, models.PermWorkshopsCoordinate)
+
 			// CRUD básico
-			volunteers.POST("", volunteerHandler.Create)           // Criar voluntário
-			volunteers.GET("", volunteerHandler.GetAll)            // Listar todos
-			volunteers.GET("/:id", volunteerHandler.GetByID)       // Buscar por ID
-			volunteers.PUT("/:id", volunteerHandler.Update)        // Atualizar
-			volunteers.DELETE("/:id", volunteerHandler.Delete)     // Deletar
+			volunteers.POST("", write, volunteerHandler.Create)        // Criar voluntário
+			volunteers.GET("", read, volunteerHandler.GetAll)          // Listar todos
+			volunteers.GET("/:id", read, volunteerHandler.GetByID)     // Buscar por ID
+			volunteers.PUT("/:id", write, volunteerHandler.Update)     // Atualizar
+			volunteers.DELETE("/:id", remove, volunteerHandler.Delete) // Deletar
 
 			// Operações específicas
-			volunteers.POST("/:id/inactivate", volunteerHandler.Inactivate) // Inativar
+			volunteers.POST("/:id/inactivate", write, volunteerHandler.Inactivate) // Inativar
+			volunteers.POST("/:id/reactivate", write, volunteerHandler.Reactivate) // Reativar
+			volunteers.GET("/:id/history", read, volunteerHandler.History)         // Histórico de versões
+
+			// Lixeira
+			volunteers.GET("/trash", remove, volunteerHandler.GetTrash)       // Listar lixeira
+			volunteers.POST("/:id/restore", remove, volunteerHandler.Restore) // Restaurar
+			volunteers.DELETE("/:id/purge", purge, volunteerHandler.Purge)    // Excluir definitivamente
 
 			// Gerenciamento de oficinas
-			volunteers.POST("/:id/workshops/:workshop_id", volunteerHandler.AddWorkshop)       // Adicionar oficina
-			volunteers.DELETE("/:id/workshops/:workshop_id", volunteerHandler.RemoveWorkshop)  // Remover oficina
+			volunteers.POST("/:id/workshops/:workshop_id", enroll, volunteerHandler.AddWorkshop)      // Adicionar oficina
+			volunteers.DELETE("/:id/workshops/:workshop_id", enroll, volunteerHandler.RemoveWorkshop) // Remover oficina
 		}
 	}
 }
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"ellp-volunter-platform/backend/internal/handlers"
+	"ellp-volunter-platform/backend/internal/middleware"
+	"ellp-volunter-platform/backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWorkshopRoutes configura as rotas de oficinas
+func SetupWorkshopRoutes(router *gin.Engine, workshopHandler *handlers.WorkshopHandler, authMiddleware *middleware.AuthMiddleware) {
+	// Grupo de rotas de oficinas
+	workshops := router.Group("/api/workshops")
+	{
+		// Rotas protegidas - requer autenticação e a permissão de cada rota
+		workshops.Use(authMiddleware.RequireAuth())
+		{
+			read := middleware.RequirePermission(models.PermWorkshopsRead)
+			manage := middleware.RequirePermission(models.PermWorkshopsManage)
+
+			// CRUD básico
+			workshops.POST("", manage, workshopHandler.Create)       // Criar oficina
+			workshops.GET("", read, workshopHandler.GetAll)          // Listar todas
+			workshops.GET("/:id", read, workshopHandler.GetByID)     // Buscar por ID
+			workshops.PUT("/:id", manage, workshopHandler.Update)    // Atualizar
+			workshops.DELETE("/:id", manage, workshopHandler.Delete) // Deletar
+
+			// Operações específicas
+			workshops.POST("/:id/cancel", manage, workshopHandler.Cancel) // Cancelar
+
+			// Coordenadores
+			workshops.POST("/:id/coordinators/:user_id", manage, workshopHandler.AddCoordinator)      // Vincular coordenador
+			workshops.DELETE("/:id/coordinators/:user_id", manage, workshopHandler.RemoveCoordinator) // Desvincular coordenador
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrVolunteerNotEnrolled é retornado quando o voluntário não está inscrito na oficina
+	ErrVolunteerNotEnrolled = errors.New("voluntário não está inscrito nesta oficina")
+	// ErrAlreadyCheckedIn é retornado quando já existe um check-in em aberto
+	ErrAlreadyCheckedIn = errors.New("voluntário já possui check-in em aberto nesta oficina")
+	// ErrNotCheckedIn é retornado ao fazer check-out de um registro sem check-in em aberto
+	ErrNotCheckedIn = errors.New("registro não possui check-in em aberto")
+	// ErrHoursRequired é retornado quando o lançamento manual não informa horas nem horários
+	ErrHoursRequired = errors.New("informe as horas ou os horários de check-in e check-out")
+)
+
+// AttendanceService define a interface para o serviço de presença
+type AttendanceService interface {
+	Record(ctx context.Context, req models.RecordAttendanceRequest, recordedBy string) (*models.AttendanceResponse, error)
+	CheckIn(ctx context.Context, req models.CheckInRequest, recordedBy string) (*models.AttendanceResponse, error)
+	CheckOut(ctx context.Context, id string, req models.CheckOutRequest) (*models.AttendanceResponse, error)
+	GetByID(ctx context.Context, id string) (*models.AttendanceResponse, error)
+	GetAll(ctx context.Context, filter repositories.AttendanceFilter) (*models.Page[*models.AttendanceResponse], error)
+	Update(ctx context.Context, id string, req models.UpdateAttendanceRequest) (*models.AttendanceResponse, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// attendanceService implementa AttendanceService
+type attendanceService struct {
+	repo          repositories.AttendanceRepository
+	volunteerRepo repositories.VolunteerRepository
+	workshopRepo  repositories.WorkshopRepository
+	auditRepo     repositories.AuditRepository
+}
+
+// NewAttendanceService cria uma nova instância do serviço
+func NewAttendanceService(repo repositories.AttendanceRepository, volunteerRepo repositories.VolunteerRepository, workshopRepo repositories.WorkshopRepository, auditRepo repositories.AuditRepository) AttendanceService {
+	return &attendanceService{
+		repo:          repo,
+		volunteerRepo: volunteerRepo,
+		workshopRepo:  workshopRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// ensureEnrolled verifica se o voluntário e a oficina existem, se o usuário da
+// requisição pode lançar presenças na oficina e se o voluntário participa dela
+func (s *attendanceService) ensureEnrolled(ctx context.Context, volunteerID string, workshopID string) error {
+	volunteer, err := s.volunteerRepo.FindByID(ctx, volunteerID)
+	if err != nil {
+		return err
+	}
+
+	workshop, err := s.workshopRepo.FindByID(ctx, workshopID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeWorkshop(ctx, workshop, models.PermAttendanceWrite); err != nil {
+		return err
+	}
+
+	for _, id := range volunteer.Workshops {
+		if id == workshop.ID.Hex() {
+			return nil
+		}
+	}
+
+	return ErrVolunteerNotEnrolled
+}
+
+// findAuthorized busca um registro de presença e verifica se o usuário da
+// requisição pode alterá-lo. Registros de oficinas removidas só podem ser
+// alterados com a permissão geral.
+func (s *attendanceService) findAuthorized(ctx context.Context, id string) (*models.Attendance, error) {
+	attendance, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workshop, err := s.workshopRepo.FindByID(ctx, attendance.WorkshopID)
+	if err != nil && err != repositories.ErrWorkshopNotFound {
+		return nil, err
+	}
+	if err := authorizeWorkshop(ctx, workshop, models.PermAttendanceWrite); err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+// Record lança um registro de presença completo (horas manuais ou entrada e saída)
+func (s *attendanceService) Record(ctx context.Context, req models.RecordAttendanceRequest, recordedBy string) (*models.AttendanceResponse, error) {
+	if req.Hours == nil && (req.CheckIn == nil || req.CheckOut == nil) {
+		return nil, ErrHoursRequired
+	}
+
+	if err := s.ensureEnrolled(ctx, req.VolunteerID, req.WorkshopID); err != nil {
+		return nil, err
+	}
+
+	attendance := models.NewAttendanceFromRequest(req, recordedBy)
+
+	// Validar
+	if err := attendance.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Salvar no banco
+	if err := s.repo.Create(ctx, attendance); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityAttendance, attendance.ID.Hex(), nil, attendance)
+
+	response := attendance.ToResponse()
+	return &response, nil
+}
+
+// CheckIn registra a entrada do voluntário em uma sessão da oficina
+func (s *attendanceService) CheckIn(ctx context.Context, req models.CheckInRequest, recordedBy string) (*models.AttendanceResponse, error) {
+	if err := s.ensureEnrolled(ctx, req.VolunteerID, req.WorkshopID); err != nil {
+		return nil, err
+	}
+
+	// Não permite dois check-ins em aberto na mesma oficina
+	open, err := s.repo.FindOpen(ctx, req.VolunteerID, req.WorkshopID)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, ErrAlreadyCheckedIn
+	}
+
+	checkIn := time.Now()
+	if req.Time != nil {
+		checkIn = *req.Time
+	}
+
+	attendance := models.NewAttendanceFromRequest(models.RecordAttendanceRequest{
+		VolunteerID: req.VolunteerID,
+		WorkshopID:  req.WorkshopID,
+		CheckIn:     &checkIn,
+	}, recordedBy)
+
+	if err := attendance.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, attendance); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityAttendance, attendance.ID.Hex(), nil, attendance)
+
+	response := attendance.ToResponse()
+	return &response, nil
+}
+
+// CheckOut registra a saída do voluntário e calcula as horas trabalhadas
+func (s *attendanceService) CheckOut(ctx context.Context, id string, req models.CheckOutRequest) (*models.AttendanceResponse, error) {
+	attendance, err := s.findAuthorized(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !attendance.IsOpen() {
+		return nil, ErrNotCheckedIn
+	}
+
+	before := models.AuditSnapshot(attendance)
+
+	checkOut := time.Now()
+	if req.Time != nil {
+		checkOut = *req.Time
+	}
+	attendance.CheckOut = &checkOut
+	attendance.ComputeHours()
+
+	if err := attendance.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, id, attendance); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityAttendance, id, before, attendance)
+
+	response := attendance.ToResponse()
+	return &response, nil
+}
+
+// GetByID busca um registro de presença por ID
+func (s *attendanceService) GetByID(ctx context.Context, id string) (*models.AttendanceResponse, error) {
+	attendance, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := attendance.ToResponse()
+	return &response, nil
+}
+
+// GetAll busca uma página de registros de presença com filtros
+func (s *attendanceService) GetAll(ctx context.Context, filter repositories.AttendanceFilter) (*models.Page[*models.AttendanceResponse], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+
+	records, err := s.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.AttendanceResponse, len(records))
+	for i, attendance := range records {
+		response := attendance.ToResponse()
+		responses[i] = &response
+	}
+
+	return models.NewPage(responses, total, filter.Page, filter.Limit), nil
+}
+
+// Update corrige um registro de presença
+func (s *attendanceService) Update(ctx context.Context, id string, req models.UpdateAttendanceRequest) (*models.AttendanceResponse, error) {
+	attendance, err := s.findAuthorized(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := models.AuditSnapshot(attendance)
+
+	// Atualizar campos se fornecidos
+	if !req.Date.IsZero() {
+		attendance.Date = req.Date
+	}
+	if req.CheckIn != nil {
+		attendance.CheckIn = req.CheckIn
+	}
+	if req.CheckOut != nil {
+		attendance.CheckOut = req.CheckOut
+	}
+	if req.Notes != nil {
+		attendance.Notes = *req.Notes
+	}
+
+	// Horas informadas explicitamente prevalecem sobre o cálculo por horários
+	if req.Hours != nil {
+		attendance.Hours = models.RoundHours(*req.Hours)
+	} else if req.CheckIn != nil || req.CheckOut != nil {
+		attendance.ComputeHours()
+	}
+
+	attendance.UpdatedAt = time.Now()
+
+	// Validar
+	if err := attendance.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, id, attendance); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityAttendance, id, before, attendance)
+
+	response := attendance.ToResponse()
+	return &response, nil
+}
+
+// Delete remove um registro de presença
+func (s *attendanceService) Delete(ctx context.Context, id string) error {
+	attendance, err := s.findAuthorized(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionDelete, models.AuditEntityAttendance, id, attendance, nil)
+	return nil
+}
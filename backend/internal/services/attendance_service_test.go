@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockAttendanceRepository é um mock do repositório de presença para testes
+type MockAttendanceRepository struct {
+	records map[string]*models.Attendance
+}
+
+func NewMockAttendanceRepository() *MockAttendanceRepository {
+	return &MockAttendanceRepository{
+		records: make(map[string]*models.Attendance),
+	}
+}
+
+func (m *MockAttendanceRepository) Create(ctx context.Context, attendance *models.Attendance) error {
+	if attendance.ID.IsZero() {
+		attendance.ID = primitive.NewObjectID()
+	}
+	m.records[attendance.ID.Hex()] = attendance
+	return nil
+}
+
+func (m *MockAttendanceRepository) FindByID(ctx context.Context, id string) (*models.Attendance, error) {
+	attendance, exists := m.records[id]
+	if !exists {
+		return nil, repositories.ErrAttendanceNotFound
+	}
+	return attendance, nil
+}
+
+func (m *MockAttendanceRepository) FindAll(ctx context.Context, filter repositories.AttendanceFilter) ([]*models.Attendance, error) {
+	records := []*models.Attendance{}
+	for _, attendance := range m.records {
+		if filter.VolunteerID != "" && attendance.VolunteerID != filter.VolunteerID {
+			continue
+		}
+		if filter.WorkshopID != "" && attendance.WorkshopID != filter.WorkshopID {
+			continue
+		}
+		if filter.DateTo != nil && attendance.Date.After(*filter.DateTo) {
+			continue
+		}
+		records = append(records, attendance)
+	}
+	return records, nil
+}
+
+func (m *MockAttendanceRepository) Count(ctx context.Context, filter repositories.AttendanceFilter) (int64, error) {
+	records, _ := m.FindAll(ctx, filter)
+	return int64(len(records)), nil
+}
+
+func (m *MockAttendanceRepository) FindOpen(ctx context.Context, volunteerID string, workshopID string) (*models.Attendance, error) {
+	for _, attendance := range m.records {
+		if attendance.VolunteerID == volunteerID && attendance.WorkshopID == workshopID && attendance.IsOpen() {
+			return attendance, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockAttendanceRepository) Update(ctx context.Context, id string, attendance *models.Attendance) error {
+	if _, exists := m.records[id]; !exists {
+		return repositories.ErrAttendanceNotFound
+	}
+	m.records[id] = attendance
+	return nil
+}
+
+func (m *MockAttendanceRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.records[id]; !exists {
+		return repositories.ErrAttendanceNotFound
+	}
+	delete(m.records, id)
+	return nil
+}
+
+func (m *MockAttendanceRepository) DeleteByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	var deleted int64
+	for id, attendance := range m.records {
+		if attendance.VolunteerID == volunteerID {
+			delete(m.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MockAttendanceRepository) SumHoursByVolunteer(ctx context.Context, volunteerIDs []string) (map[string]float64, error) {
+	totals := make(map[string]float64)
+	for _, id := range volunteerIDs {
+		for _, attendance := range m.records {
+			if attendance.VolunteerID == id {
+				totals[id] += attendance.Hours
+			}
+		}
+	}
+	return totals, nil
+}
+
+func TestAttendanceService_CheckInCheckOut(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	service := NewAttendanceService(attendanceRepo, volunteerRepo, workshopRepo, NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	workshop := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	other := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	volunteer.Workshops = []string{workshop.ID.Hex()}
+
+	checkIn := time.Date(2025, 3, 15, 14, 0, 0, 0, time.UTC)
+	req := models.CheckInRequest{VolunteerID: volunteer.ID.Hex(), WorkshopID: workshop.ID.Hex(), Time: &checkIn}
+
+	record, err := service.CheckIn(ctx, req, "coordinator")
+	if err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+	if !record.IsOpen {
+		t.Error("CheckIn() should leave the record open")
+	}
+
+	if _, err := service.CheckIn(ctx, req, "coordinator"); err != ErrAlreadyCheckedIn {
+		t.Errorf("second CheckIn() error = %v, want %v", err, ErrAlreadyCheckedIn)
+	}
+
+	notEnrolled := models.CheckInRequest{VolunteerID: volunteer.ID.Hex(), WorkshopID: other.ID.Hex()}
+	if _, err := service.CheckIn(ctx, notEnrolled, "coordinator"); err != ErrVolunteerNotEnrolled {
+		t.Errorf("CheckIn() in other workshop error = %v, want %v", err, ErrVolunteerNotEnrolled)
+	}
+
+	checkOut := checkIn.Add(2*time.Hour + 30*time.Minute)
+	record, err = service.CheckOut(ctx, record.ID.Hex(), models.CheckOutRequest{Time: &checkOut})
+	if err != nil {
+		t.Fatalf("CheckOut() error = %v", err)
+	}
+	if record.Hours != 2.5 {
+		t.Errorf("CheckOut() hours = %v, want 2.5", record.Hours)
+	}
+
+	if _, err := service.CheckOut(ctx, record.ID.Hex(), models.CheckOutRequest{}); err != ErrNotCheckedIn {
+		t.Errorf("second CheckOut() error = %v, want %v", err, ErrNotCheckedIn)
+	}
+}
+
+func TestAttendanceService_RecordAndCorrect(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	service := NewAttendanceService(attendanceRepo, volunteerRepo, workshopRepo, NewMockAuditRepository())
+	volunteerService := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), workshopRepo, attendanceRepo, NewMockUserRepository(), NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	workshop := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	volunteer.Workshops = []string{workshop.ID.Hex()}
+
+	base := models.RecordAttendanceRequest{
+		VolunteerID: volunteer.ID.Hex(),
+		WorkshopID:  workshop.ID.Hex(),
+		Date:        time.Now(),
+	}
+
+	if _, err := service.Record(ctx, base, "admin"); err != ErrHoursRequired {
+		t.Errorf("Record() without hours error = %v, want %v", err, ErrHoursRequired)
+	}
+
+	tooMany := base
+	hours := 30.0
+	tooMany.Hours = &hours
+	if _, err := service.Record(ctx, tooMany, "admin"); err == nil {
+		t.Error("Record() with more than 24 hours should fail")
+	}
+
+	manual := base
+	hours = 3
+	manual.Hours = &hours
+	first, err := service.Record(ctx, manual, "admin")
+	if err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	hours = 1.5
+	manual.Hours = &hours
+	if _, err := service.Record(ctx, manual, "admin"); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	corrected := 4.0
+	if _, err := service.Update(ctx, first.ID.Hex(), models.UpdateAttendanceRequest{Hours: &corrected}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	response, err := volunteerService.GetByID(ctx, volunteer.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if response.TotalHours != 5.5 {
+		t.Errorf("TotalHours = %v, want 5.5", response.TotalHours)
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"log"
+)
+
+// AuditService define a interface para a consulta da trilha de auditoria
+type AuditService interface {
+	GetAll(ctx context.Context, filter repositories.AuditFilter) (*models.Page[*models.AuditEntry], error)
+}
+
+// auditService implementa AuditService
+type auditService struct {
+	repo repositories.AuditRepository
+}
+
+// NewAuditService cria uma nova instância do serviço
+func NewAuditService(repo repositories.AuditRepository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+// GetAll busca uma página de registros de auditoria com filtros
+func (s *auditService) GetAll(ctx context.Context, filter repositories.AuditFilter) (*models.Page[*models.AuditEntry], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+
+	entries, err := s.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(entries, total, filter.Page, filter.Limit), nil
+}
+
+// recordAudit grava na trilha de auditoria uma operação já concluída, com o
+// usuário autenticado da requisição. A operação não é desfeita se a gravação
+// falhar; o erro fica registrado no log do servidor.
+func recordAudit(ctx context.Context, repo repositories.AuditRepository, action, entity, entityID string, before, after interface{}) {
+	actor, _ := models.ActorFromContext(ctx)
+	entry := models.NewAuditEntry(actor, action, entity, entityID, before, after)
+
+	if err := repo.Create(ctx, entry); err != nil {
+		log.Printf("erro ao gravar auditoria (%s %s %s): %v", action, entity, entityID, err)
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockAuditRepository é um mock do repositório de auditoria para testes
+type MockAuditRepository struct {
+	entries []*models.AuditEntry
+}
+
+func NewMockAuditRepository() *MockAuditRepository {
+	return &MockAuditRepository{}
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	entry.ID = primitive.NewObjectID()
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockAuditRepository) FindAll(ctx context.Context, filter repositories.AuditFilter) ([]*models.AuditEntry, error) {
+	entries := []*models.AuditEntry{}
+	for _, entry := range m.entries {
+		if filter.ActorID != "" && entry.ActorID != filter.ActorID {
+			continue
+		}
+		if filter.Entity != "" && entry.Entity != filter.Entity {
+			continue
+		}
+		if filter.EntityID != "" && entry.EntityID != filter.EntityID {
+			continue
+		}
+		if filter.Action != "" && entry.Action != filter.Action {
+			continue
+		}
+		if filter.DateFrom != nil && entry.CreatedAt.Before(*filter.DateFrom) {
+			continue
+		}
+		if filter.DateTo != nil && entry.CreatedAt.After(*filter.DateTo) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (m *MockAuditRepository) Count(ctx context.Context, filter repositories.AuditFilter) (int64, error) {
+	entries, _ := m.FindAll(ctx, filter)
+	return int64(len(entries)), nil
+}
+
+func TestVolunteerService_Audit(t *testing.T) {
+	volunteerRepo := NewMockVolunteerRepository()
+	auditRepo := NewMockAuditRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), NewMockWorkshopRepository(), NewMockAttendanceRepository(), NewMockUserRepository(), auditRepo)
+	auditService := NewAuditService(auditRepo)
+
+	actor := &models.Actor{UserID: primitive.NewObjectID().Hex(), Email: "admin@example.com", Role: models.RoleAdmin}
+	ctx := models.WithActor(context.Background(), actor)
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	id := volunteer.ID.Hex()
+	oldName := volunteer.Name
+
+	if _, err := service.Update(ctx, id, models.UpdateVolunteerRequest{Name: "Maria Souza"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := service.Delete(ctx, id, actor.UserID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	page, _ := auditService.GetAll(ctx, repositories.AuditFilter{Entity: models.AuditEntityVolunteer, EntityID: id})
+	if page.Total != 2 || len(page.Items) != 2 {
+		t.Fatalf("GetAll() = %d entries, want 2", len(page.Items))
+	}
+	entries := page.Items
+
+	update := entries[0]
+	if update.Action != models.AuditActionUpdate || update.ActorID != actor.UserID || update.ActorEmail != actor.Email {
+		t.Errorf("update entry = %+v, want update by %s", update, actor.Email)
+	}
+	var nameChange *models.AuditChange
+	for i := range update.Changes {
+		if update.Changes[i].Field == "name" {
+			nameChange = &update.Changes[i]
+		}
+	}
+	if nameChange == nil || nameChange.Before != oldName || nameChange.After != "Maria Souza" {
+		t.Errorf("update changes = %+v, want name %q -> %q", update.Changes, oldName, "Maria Souza")
+	}
+
+	remove := entries[1]
+	if remove.Action != models.AuditActionDelete || remove.Before["deleted_at"] != nil || remove.After["deleted_by"] != actor.UserID {
+		t.Errorf("delete entry = %+v, want move to trash by %s", remove, actor.UserID)
+	}
+
+	// O filtro por usuário não retorna operações de outros
+	others, _ := auditService.GetAll(ctx, repositories.AuditFilter{ActorID: primitive.NewObjectID().Hex()})
+	if others.Total != 0 {
+		t.Errorf("GetAll() by other actor = %d entries, want 0", len(others.Items))
+	}
+}
+
+func TestAuthService_Audit(t *testing.T) {
+	userRepo := NewMockUserRepository()
+	auditRepo := NewMockAuditRepository()
+	service := NewAuthService(userRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), auditRepo, RegistrationOpen)
+
+	admin := newTestUser(userRepo, "admin@example.com", models.RoleAdmin)
+	ctx := models.WithActor(context.Background(), &models.Actor{UserID: admin.ID.Hex(), Email: admin.Email, Role: models.RoleAdmin})
+
+	registered, err := service.Register(context.Background(), &models.CreateUserRequest{Name: "Member", Email: "member@example.com", Password: "Password123"})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, err := service.CreateInvite(ctx, &models.CreateInviteRequest{Role: models.RoleMember}, admin.ID.Hex()); err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	if _, err := service.UpdateProfile(ctx, admin.ID.Hex(), &models.UpdateProfileRequest{Name: "Admin"}); err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if _, err := service.ChangePassword(ctx, admin.ID.Hex(), "TestPassword123", "NewPassword123"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	setup, _ := service.BeginMFASetup(ctx, admin.ID.Hex())
+	if _, err := service.EnableMFA(ctx, admin.ID.Hex(), mfaCode(t, setup.Secret, 0)); err != nil {
+		t.Fatalf("EnableMFA() error = %v", err)
+	}
+	if err := service.DisableMFA(ctx, admin.ID.Hex(), "NewPassword123", mfaCode(t, setup.Secret, 1)); err != nil {
+		t.Fatalf("DisableMFA() error = %v", err)
+	}
+	required := true
+	if _, err := service.UpdateSecuritySettings(ctx, &models.UpdateSecuritySettingsRequest{RequireAdminMFA: &required}, admin.ID.Hex()); err != nil {
+		t.Fatalf("UpdateSecuritySettings() error = %v", err)
+	}
+
+	want := []struct{ action, entity, entityID string }{
+		{models.AuditActionCreate, models.AuditEntityUser, registered.ID.Hex()},
+		{models.AuditActionCreate, models.AuditEntityInvite, ""},
+		{models.AuditActionUpdate, models.AuditEntityUser, admin.ID.Hex()},
+		{models.AuditActionChangePassword, models.AuditEntityUser, admin.ID.Hex()},
+		{models.AuditActionUpdate, models.AuditEntityUser, admin.ID.Hex()},
+		{models.AuditActionUpdate, models.AuditEntityUser, admin.ID.Hex()},
+		{models.AuditActionUpdate, models.AuditEntitySettings, "security"},
+	}
+	if len(auditRepo.entries) != len(want) {
+		t.Fatalf("audit entries = %d, want %d", len(auditRepo.entries), len(want))
+	}
+	for i, entry := range auditRepo.entries {
+		if entry.Action != want[i].action || entry.Entity != want[i].entity || (want[i].entityID != "" && entry.EntityID != want[i].entityID) {
+			t.Errorf("entry %d = %s %s %s, want %+v", i, entry.Action, entry.Entity, entry.EntityID, want[i])
+		}
+
+		// Senhas, tokens e segredos do MFA não entram na auditoria
+		data, _ := json.Marshal(entry)
+		for _, secret := range []string{"\"password\"", "token\"", "mfa_secret", "recovery", setup.Secret} {
+			if strings.Contains(string(data), secret) {
+				t.Errorf("entry %d contains %q: %s", i, secret, data)
+			}
+		}
+	}
+
+	// O cadastro é feito sem usuário autenticado; a ativação do MFA aparece nas alterações
+	if auditRepo.entries[0].ActorID != "" {
+		t.Errorf("register actor = %q, want empty", auditRepo.entries[0].ActorID)
+	}
+	enabled := auditRepo.entries[4]
+	if len(enabled.Changes) == 0 || enabled.After["mfa_enabled"] != true {
+		t.Errorf("enable MFA entry = %+v, want mfa_enabled change", enabled)
+	}
+}
@@ -2,11 +2,18 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"ellp-volunter-platform/backend/internal/config"
 	"ellp-volunter-platform/backend/internal/models"
 	"ellp-volunter-platform/backend/internal/repositories"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 var (
@@ -14,33 +21,130 @@ var (
 	ErrInvalidCredentials = errors.New("credenciais inválidas")
 	// ErrUserInactive é retornado quando o usuário está inativo
 	ErrUserInactive = errors.New("usuário inativo")
+	// ErrInvalidRefreshToken é retornado quando o refresh token é inválido, expirado ou revogado
+	ErrInvalidRefreshToken = errors.New("refresh token inválido ou expirado")
+	// ErrRefreshTokenReused é retornado quando um refresh token já rotacionado é usado
+	// novamente; toda a família de tokens é revogada
+	ErrRefreshTokenReused = errors.New("refresh token reutilizado; sessão revogada")
+	// ErrLoginBlocked é retornado quando o login está bloqueado temporariamente;
+	// o erro concreto é um *LoginBlockedError com o tempo de espera
+	ErrLoginBlocked = errors.New("muitas tentativas de login; tente novamente mais tarde")
+	// ErrWrongPassword é retornado quando a senha atual informada não confere
+	ErrWrongPassword = errors.New("senha atual incorreta")
+	// ErrRegistrationDisabled é retornado quando o auto-cadastro está desativado
+	ErrRegistrationDisabled = errors.New("cadastro desativado")
+	// ErrInviteRequired é retornado quando o cadastro exige um convite e nenhum foi informado
+	ErrInviteRequired = errors.New("cadastro permitido apenas por convite")
+	// ErrInvalidInvite é retornado quando o convite é inválido, expirou ou já foi usado
+	ErrInvalidInvite = errors.New("convite inválido, expirado ou já utilizado")
+	// ErrInviteEmailMismatch é retornado quando o convite é de outro email
+	ErrInviteEmailMismatch = errors.New("convite emitido para outro email")
+)
+
+// RegistrationMode define como novos usuários podem se cadastrar
+type RegistrationMode string
+
+const (
+	// RegistrationDisabled desativa o auto-cadastro
+	RegistrationDisabled RegistrationMode = "disabled"
+	// RegistrationInviteOnly exige um convite emitido por um administrador; o papel vem do convite
+	RegistrationInviteOnly RegistrationMode = "invite"
+	// RegistrationOpen permite o cadastro de qualquer pessoa, sempre como "member"
+	RegistrationOpen RegistrationMode = "open"
 )
 
+// Proteção contra força bruta no login: a partir de loginDelayThreshold falhas
+// seguidas, cada nova tentativa exige uma espera que dobra a cada falha; ao
+// atingir maxLoginFailures o login fica bloqueado por loginLockoutDuration.
+// Após o bloqueio, uma nova falha bloqueia a conta outra vez, até que um login
+// correto ou um administrador zere o contador.
+const (
+	loginDelayThreshold  = 3
+	loginBaseDelay       = time.Second
+	maxLoginFailures     = 5
+	loginLockoutDuration = 15 * time.Minute
+)
+
+// LoginBlockedError indica que o login foi recusado sem verificar a senha
+type LoginBlockedError struct {
+	RetryAfter time.Duration
+	Locked     bool // conta bloqueada, e não apenas aguardando o atraso progressivo
+}
+
+func (e *LoginBlockedError) Error() string {
+	return ErrLoginBlocked.Error()
+}
+
+// Is permite comparar com errors.Is(err, ErrLoginBlocked)
+func (e *LoginBlockedError) Is(target error) bool {
+	return target == ErrLoginBlocked
+}
+
+// Validade padrão de um convite de cadastro
+const defaultInviteExpiration = 72 * time.Hour
+
+// ParseRegistrationMode converte o valor da configuração em RegistrationMode.
+// Valor vazio equivale ao cadastro aberto.
+func ParseRegistrationMode(value string) (RegistrationMode, error) {
+	switch mode := RegistrationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return RegistrationOpen, nil
+	case RegistrationDisabled, RegistrationInviteOnly, RegistrationOpen:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("modo de cadastro inválido: %q (use disabled, invite ou open)", value)
+	}
+}
+
 // AuthService define a interface para serviços de autenticação
 type AuthService interface {
 	Login(ctx context.Context, email, password string) (*LoginResponse, error)
 	Register(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error)
 	ValidateToken(token string) (*config.Claims, error)
-	RefreshToken(token string) (string, error)
-	RefreshTokenWithRefreshToken(refreshToken string) (*LoginResponse, error)
+	RefreshTokenWithRefreshToken(ctx context.Context, refreshToken string) (*LoginResponse, error)
+	Logout(ctx context.Context, refreshToken string) error
+	CreateInvite(ctx context.Context, req *models.CreateInviteRequest, createdBy string) (*models.InviteResponse, error)
+	GetCurrentUser(ctx context.Context, userID string) (*models.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID string, req *models.UpdateProfileRequest) (*models.UserResponse, error)
+	ChangePassword(ctx context.Context, userID string, currentPassword, newPassword string) (*LoginResponse, error)
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResponse, error)
+	BeginMFAChallengeSetup(ctx context.Context, mfaToken string) (*models.MFASetupResponse, error)
+	BeginMFASetup(ctx context.Context, userID string) (*models.MFASetupResponse, error)
+	EnableMFA(ctx context.Context, userID, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID, password, code string) error
+	GetSecuritySettings(ctx context.Context) (*models.SecuritySettings, error)
+	UpdateSecuritySettings(ctx context.Context, req *models.UpdateSecuritySettingsRequest, actorID string) (*models.SecuritySettings, error)
 }
 
 // LoginResponse representa a resposta de login
 type LoginResponse struct {
-	User         models.UserResponse `json:"user"`
-	AccessToken  string              `json:"access_token"`
-	RefreshToken string              `json:"refresh_token"`
+	User          models.UserResponse `json:"user"`
+	AccessToken   string              `json:"access_token"`
+	RefreshToken  string              `json:"refresh_token"`
+	RecoveryCodes []string            `json:"recovery_codes,omitempty"` // apenas ao concluir o cadastro do MFA no login
 }
 
 // authService implementa AuthService
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo         repositories.UserRepository
+	sessionRepo      repositories.SessionRepository
+	inviteRepo       repositories.InviteRepository
+	settingsRepo     repositories.SettingsRepository
+	roleRepo         repositories.RoleRepository
+	auditRepo        repositories.AuditRepository
+	registrationMode RegistrationMode
 }
 
 // NewAuthService cria uma nova instância do serviço de autenticação
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, inviteRepo repositories.InviteRepository, settingsRepo repositories.SettingsRepository, roleRepo repositories.RoleRepository, auditRepo repositories.AuditRepository, registrationMode RegistrationMode) AuthService {
 	return &authService{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		inviteRepo:       inviteRepo,
+		settingsRepo:     settingsRepo,
+		roleRepo:         roleRepo,
+		auditRepo:        auditRepo,
+		registrationMode: registrationMode,
 	}
 }
 
@@ -58,17 +162,44 @@ func (s *authService) Login(ctx context.Context, email, password string) (*Login
 		return nil, ErrUserInactive
 	}
 
+	// A tentativa é contada antes da verificação da senha
+	attempt, err := s.reserveLoginAttempt(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := models.CheckPassword(password, user.Password); err != nil {
+		if err := s.recordLoginFailure(ctx, user, attempt); err != nil {
+			return nil, err
+		}
 		return nil, ErrInvalidCredentials
 	}
 
+	// Com MFA, o login só é concluído em VerifyMFA; a tentativa com a senha
+	// correta é devolvida e as falhas anteriores continuam contando até lá
+	// para limitar tentativas de código
+	if err := s.mfaChallenge(ctx, user); err != nil {
+		if releaseErr := s.userRepo.ReleaseLoginAttempt(ctx, user.ID.Hex(), attempt.at, attempt.lastFailure); releaseErr != nil {
+			return nil, releaseErr
+		}
+		return nil, err
+	}
+
+	if err := s.userRepo.ClearLoginFailures(ctx, user.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// issueTokens emite o access token e inicia uma nova família de refresh tokens
+func (s *authService) issueTokens(ctx context.Context, user *models.User) (*LoginResponse, error) {
 	accessToken, err := config.GenerateToken(user.ID.Hex(), user.Email, user.Role)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate refresh token with longer expiration (7 days)
-	refreshToken, err := config.GenerateRefreshToken(user.ID.Hex(), user.Email)
+	refreshToken, err := s.startSession(ctx, user)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +211,14 @@ func (s *authService) Login(ctx context.Context, email, password string) (*Login
 	}, nil
 }
 
-// Register registra um novo usuário no sistema
+// Register registra um novo usuário no sistema conforme o modo de cadastro.
+// O papel nunca vem do cliente: no cadastro aberto é sempre "member" e no
+// cadastro por convite é o papel definido pelo administrador no convite.
 func (s *authService) Register(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, error) {
+	if s.registrationMode == RegistrationDisabled {
+		return nil, ErrRegistrationDisabled
+	}
+
 	if err := models.ValidateEmail(req.Email); err != nil {
 		return nil, err
 	}
@@ -94,51 +231,185 @@ func (s *authService) Register(ctx context.Context, req *models.CreateUserReques
 		Name:      req.Name,
 		Email:     req.Email,
 		Password:  req.Password,
-		Role:      req.Role,
+		Role:      models.RoleMember,
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
+	if s.registrationMode == RegistrationOpen {
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+
+		return s.registered(ctx, user), nil
+	}
+
+	invite, err := s.findInvite(ctx, req.InviteToken, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = invite.Role
+	user.ID = primitive.NewObjectID()
+
+	// O convite é consumido antes da criação do usuário para que dois cadastros
+	// simultâneos não usem o mesmo convite
+	if err := s.inviteRepo.MarkUsed(ctx, invite.TokenID, user.ID.Hex()); err != nil {
+		if err == repositories.ErrInviteAlreadyUsed {
+			return nil, ErrInvalidInvite
+		}
+		return nil, err
+	}
+
 	if err := s.userRepo.Create(ctx, user); err != nil {
+		// Libera o convite para uma nova tentativa (ex.: email já cadastrado)
+		if releaseErr := s.inviteRepo.Release(ctx, invite.TokenID); releaseErr != nil {
+			return nil, releaseErr
+		}
 		return nil, err
 	}
 
+	return s.registered(ctx, user), nil
+}
+
+// registered registra o cadastro na auditoria e retorna o usuário criado
+func (s *authService) registered(ctx context.Context, user *models.User) *models.UserResponse {
 	response := user.ToResponse()
-	return &response, nil
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityUser, user.ID.Hex(), nil, response)
+	return &response
 }
 
-// ValidateToken valida um token JWT
-func (s *authService) ValidateToken(token string) (*config.Claims, error) {
-	return config.ValidateToken(token)
+// CreateInvite emite um convite de cadastro de uso único
+func (s *authService) CreateInvite(ctx context.Context, req *models.CreateInviteRequest, createdBy string) (*models.InviteResponse, error) {
+	expiration := defaultInviteExpiration
+	if req.ExpiresInHours > 0 {
+		expiration = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email != "" {
+		if err := models.ValidateEmail(email); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ensureRole(ctx, s.roleRepo, req.Role); err != nil {
+		return nil, err
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &models.Invite{
+		TokenID:   tokenID,
+		Email:     email,
+		Role:      req.Role,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(expiration),
+		CreatedAt: time.Now(),
+	}
+
+	token, err := config.GenerateInviteToken(tokenID, email, invite.Role, invite.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.Create(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	// O token do convite não entra na auditoria
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityInvite, invite.ID.Hex(), nil, invite)
+
+	return &models.InviteResponse{
+		Invite: *invite,
+		Token:  token,
+	}, nil
 }
 
-// RefreshToken renova um token JWT
-func (s *authService) RefreshToken(token string) (string, error) {
-	return config.RefreshToken(token)
+// ValidateToken valida um access token JWT
+func (s *authService) ValidateToken(token string) (*config.Claims, error) {
+	return config.ValidateAccessToken(token)
 }
 
-// RefreshTokenWithRefreshToken gera novo access token usando refresh token
-func (s *authService) RefreshTokenWithRefreshToken(refreshToken string) (*LoginResponse, error) {
-	claims, err := config.ValidateToken(refreshToken)
+// RefreshTokenWithRefreshToken gera novo access token usando refresh token.
+// O refresh token usado é rotacionado: ele deixa de valer e um novo é emitido
+// na mesma família. Reutilizar um token já rotacionado revoga a família inteira.
+func (s *authService) RefreshTokenWithRefreshToken(ctx context.Context, refreshToken string) (*LoginResponse, error) {
+	session, err := s.findSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.IsRotated() {
+		if err := s.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if session.IsRevoked() || session.IsExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// O novo access token usa o papel e a situação atuais do usuário,
+	// não os dados do token anterior
+	user, err := s.userRepo.FindByID(ctx, session.UserID)
 	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil, ErrInvalidRefreshToken
+		}
 		return nil, err
 	}
 
+	if !user.IsActive {
+		if err := s.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrUserInactive
+	}
+
+	// Se a política passou a exigir MFA, a sessão termina e o próximo login
+	// conduz o cadastro do autenticador
+	if !user.MFAEnabled {
+		required, err := s.mfaRequired(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			if err := s.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrMFASetupRequired
+		}
+	}
+
 	// Generate new access token
-	accessToken, err := config.GenerateToken(claims.UserID, claims.Email, claims.Role)
+	accessToken, err := config.GenerateToken(user.ID.Hex(), user.Email, user.Role)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate new refresh token
-	newRefreshToken, err := config.GenerateRefreshToken(claims.UserID, claims.Email)
+	tokenID, err := newTokenID()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get user to include in response
-	user, err := s.userRepo.FindByEmail(context.Background(), claims.Email)
+	// Marca o token atual como usado antes de emitir o próximo; se outra
+	// requisição já o usou, trata como reutilização
+	if err := s.sessionRepo.Rotate(ctx, session.TokenID, tokenID); err != nil {
+		if err == repositories.ErrSessionAlreadyRotated {
+			if err := s.sessionRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, err
+	}
+
+	newRefreshToken, err := s.createSession(ctx, user, tokenID, session.FamilyID)
 	if err != nil {
 		return nil, err
 	}
@@ -149,3 +420,272 @@ func (s *authService) RefreshTokenWithRefreshToken(refreshToken string) (*LoginR
 		RefreshToken: newRefreshToken,
 	}, nil
 }
+
+// Logout revoga a sessão (família de refresh tokens) do token informado
+func (s *authService) Logout(ctx context.Context, refreshToken string) error {
+	session, err := s.findSession(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeFamily(ctx, session.FamilyID)
+}
+
+// findSession valida a assinatura do refresh token e busca a sessão correspondente
+func (s *authService) findSession(ctx context.Context, refreshToken string) (*models.Session, error) {
+	claims, err := config.ValidateRefreshToken(refreshToken)
+	if err != nil || claims.ID == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.FindByTokenID(ctx, claims.ID)
+	if err != nil {
+		if err == repositories.ErrSessionNotFound {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(session.TokenHash), []byte(models.HashToken(refreshToken))) != 1 {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return session, nil
+}
+
+// GetCurrentUser busca os dados atuais do usuário autenticado
+func (s *authService) GetCurrentUser(ctx context.Context, userID string) (*models.UserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// UpdateProfile altera o nome e o email do próprio usuário
+func (s *authService) UpdateProfile(ctx context.Context, userID string, req *models.UpdateProfileRequest) (*models.UserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.ToResponse()
+	name, email := user.Name, user.Email
+	if value := strings.TrimSpace(req.Name); value != "" {
+		name = value
+	}
+
+	if value := strings.TrimSpace(req.Email); value != "" && value != user.Email {
+		if err := models.ValidateEmail(value); err != nil {
+			return nil, err
+		}
+		existing, err := s.userRepo.FindByEmail(ctx, value)
+		if err != nil && err != repositories.ErrUserNotFound {
+			return nil, err
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, repositories.ErrUserAlreadyExists
+		}
+		email = value
+	}
+
+	// Apenas nome e email são gravados, sem sobrescrever papel ou status
+	if err := s.userRepo.SetProfile(ctx, userID, name, email); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := updated.ToResponse()
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityUser, userID, before, response)
+	return &response, nil
+}
+
+// ChangePassword troca a senha do próprio usuário após conferir a senha atual.
+// Todas as sessões são encerradas e uma nova é iniciada para quem fez a troca.
+func (s *authService) ChangePassword(ctx context.Context, userID string, currentPassword, newPassword string) (*LoginResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := models.CheckPassword(currentPassword, user.Password); err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	if err := models.ValidatePassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	// A senha é convertida em hash pelo repositório
+	before := user.ToResponse()
+	if err := s.userRepo.SetPassword(ctx, userID, newPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.RevokeByUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// A senha não entra na auditoria, apenas o registro da troca
+	recordAudit(ctx, s.auditRepo, models.AuditActionChangePassword, models.AuditEntityUser, userID, before, user.ToResponse())
+
+	return s.issueTokens(ctx, user)
+}
+
+// maxLoginReserveAttempts limita as releituras quando tentativas simultâneas
+// disputam o mesmo contador de falhas
+const maxLoginReserveAttempts = 3
+
+// loginAttempt é uma tentativa de login já contada como falha
+type loginAttempt struct {
+	failures    int        // falhas contadas, incluindo esta tentativa
+	at          time.Time  // horário gravado como última falha
+	lastFailure *time.Time // última falha antes desta tentativa
+}
+
+// reserveLoginAttempt verifica o bloqueio e conta a tentativa como falha antes
+// de a senha ou o código serem verificados. A contagem é condicional ao estado
+// lido, então tentativas simultâneas não passam todas pela mesma leitura: se
+// outra tentativa alterou o contador antes, o usuário é relido e o bloqueio
+// verificado de novo.
+func (s *authService) reserveLoginAttempt(ctx context.Context, user *models.User) (*loginAttempt, error) {
+	for attempt := 1; ; attempt++ {
+		now := time.Now()
+		if err := checkLoginAllowed(user, now); err != nil {
+			return nil, err
+		}
+
+		lastFailure := user.LastFailedLoginAt
+		failures, err := s.userRepo.ReserveLoginAttempt(ctx, user, now)
+		if err == nil {
+			return &loginAttempt{failures: failures, at: now, lastFailure: lastFailure}, nil
+		}
+		if err != repositories.ErrLoginAttemptConflict {
+			return nil, err
+		}
+		if attempt == maxLoginReserveAttempts {
+			return nil, &LoginBlockedError{RetryAfter: loginBaseDelay}
+		}
+
+		current, err := s.userRepo.FindByID(ctx, user.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		*user = *current
+	}
+}
+
+// recordLoginFailure conclui uma tentativa reservada com senha ou código
+// incorreto. A falha já foi contada na reserva; a conta é bloqueada ao
+// atingir o limite de falhas e, antes disso, a espera passa a contar do fim
+// da verificação, e não do início da tentativa.
+func (s *authService) recordLoginFailure(ctx context.Context, user *models.User, attempt *loginAttempt) error {
+	now := time.Now()
+	if attempt.failures >= maxLoginFailures {
+		return s.userRepo.Lock(ctx, user.ID.Hex(), now.Add(loginLockoutDuration))
+	}
+
+	return s.userRepo.MarkLoginFailure(ctx, user.ID.Hex(), now)
+}
+
+// checkLoginAllowed verifica o bloqueio e o atraso progressivo do usuário
+func checkLoginAllowed(user *models.User, now time.Time) error {
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return &LoginBlockedError{RetryAfter: user.LockedUntil.Sub(now), Locked: true}
+	}
+
+	if user.FailedLoginAttempts < loginDelayThreshold || user.LastFailedLoginAt == nil {
+		return nil
+	}
+
+	next := user.LastFailedLoginAt.Add(loginDelay(user.FailedLoginAttempts))
+	if now.Before(next) {
+		return &LoginBlockedError{RetryAfter: next.Sub(now)}
+	}
+
+	return nil
+}
+
+// loginDelay calcula a espera exigida após o número de falhas informado
+func loginDelay(failures int) time.Duration {
+	if failures < loginDelayThreshold {
+		return 0
+	}
+
+	delay := loginBaseDelay << (failures - loginDelayThreshold)
+	if delay <= 0 || delay > loginLockoutDuration {
+		return loginLockoutDuration
+	}
+	return delay
+}
+
+// findInvite valida o token do convite e busca o convite gravado. O papel e o
+// email considerados são os do convite gravado, não os do token.
+func (s *authService) findInvite(ctx context.Context, inviteToken string, email string) (*models.Invite, error) {
+	if inviteToken == "" {
+		return nil, ErrInviteRequired
+	}
+
+	claims, err := config.ValidateInviteToken(inviteToken)
+	if err != nil || claims.ID == "" {
+		return nil, ErrInvalidInvite
+	}
+
+	invite, err := s.inviteRepo.FindByTokenID(ctx, claims.ID)
+	if err != nil {
+		if err == repositories.ErrInviteNotFound {
+			return nil, ErrInvalidInvite
+		}
+		return nil, err
+	}
+
+	if invite.IsUsed() || invite.IsExpired() {
+		return nil, ErrInvalidInvite
+	}
+
+	if invite.Email != "" && !strings.EqualFold(invite.Email, strings.TrimSpace(email)) {
+		return nil, ErrInviteEmailMismatch
+	}
+
+	return invite, nil
+}
+
+// startSession emite o primeiro refresh token de uma nova família
+func (s *authService) startSession(ctx context.Context, user *models.User) (string, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	return s.createSession(ctx, user, tokenID, tokenID)
+}
+
+// createSession assina o refresh token e grava a sessão correspondente
+func (s *authService) createSession(ctx context.Context, user *models.User, tokenID, familyID string) (string, error) {
+	refreshToken, err := config.GenerateRefreshToken(user.ID.Hex(), user.Email, tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	session := models.NewSession(tokenID, familyID, user.ID.Hex(), refreshToken, time.Now().Add(config.RefreshTokenExpiration))
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return "", err
+	}
+
+	return refreshToken, nil
+}
+
+// newTokenID gera um identificador aleatório para o JTI de refresh tokens e convites
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
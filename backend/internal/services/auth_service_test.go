@@ -19,7 +19,6 @@ type MockUserRepository struct {
 	createError error
 	findError   error
 	updateError error
-	deleteError error
 }
 
 func NewMockUserRepository() *MockUserRepository {
@@ -79,53 +78,361 @@ func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*mo
 	return m.users[id], nil
 }
 
-func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
-	if m.updateError != nil {
-		return m.updateError
+func (m *MockUserRepository) SetProfile(ctx context.Context, id string, name, email string) error {
+	user, err := m.set(id)
+	if err != nil {
+		return err
 	}
+	delete(m.emailToID, user.Email)
+	user.Name, user.Email = name, email
+	m.emailToID[email] = id
+	return nil
+}
 
-	if _, exists := m.users[user.ID.Hex()]; !exists {
-		return repositories.ErrUserNotFound
+func (m *MockUserRepository) SetPassword(ctx context.Context, id string, password string) error {
+	user, err := m.set(id)
+	if err != nil {
+		return err
 	}
+	user.Password, err = models.HashPassword(password)
+	return err
+}
 
-	if err := user.BeforeUpdate(); err != nil {
+func (m *MockUserRepository) SetRole(ctx context.Context, id string, role string) error {
+	user, err := m.set(id)
+	if err != nil {
 		return err
 	}
-
-	m.users[user.ID.Hex()] = user
+	wasAdmin := user.Role == models.RoleAdmin && user.IsActive
+	user.Role = role
+	if wasAdmin && role != models.RoleAdmin {
+		return m.keepActiveAdmin(func() { user.Role = models.RoleAdmin })
+	}
 	return nil
 }
 
-func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
-	if m.deleteError != nil {
-		return m.deleteError
+func (m *MockUserRepository) SetActive(ctx context.Context, id string, active bool) error {
+	user, err := m.set(id)
+	if err != nil {
+		return err
+	}
+	wasAdmin := user.Role == models.RoleAdmin && user.IsActive
+	user.IsActive = active
+	if wasAdmin && !active {
+		return m.keepActiveAdmin(func() { user.IsActive = true })
 	}
+	return nil
+}
 
+// set busca o usuário a alterar, aplicando o erro de atualização simulado
+func (m *MockUserRepository) set(id string) (*models.User, error) {
+	if m.updateError != nil {
+		return nil, m.updateError
+	}
 	user, exists := m.users[id]
 	if !exists {
-		return repositories.ErrUserNotFound
+		return nil, repositories.ErrUserNotFound
 	}
-
-	user.IsActive = false
 	user.UpdatedAt = time.Now()
-	return nil
+	return user, nil
+}
+
+// keepActiveAdmin desfaz a alteração se nenhum administrador ativo restar, como o repositório
+func (m *MockUserRepository) keepActiveAdmin(undo func()) error {
+	if admins, _ := m.Count(context.Background(), bson.M{"role": models.RoleAdmin, "is_active": true}); admins > 0 {
+		return nil
+	}
+	undo()
+	return repositories.ErrLastActiveAdmin
 }
 
 func (m *MockUserRepository) List(ctx context.Context, filter bson.M, limit, offset int) ([]*models.User, error) {
 	users := []*models.User{}
 	for _, user := range m.users {
-		users = append(users, user)
+		if matchesUserFilter(user, filter) {
+			users = append(users, user)
+		}
 	}
 	return users, nil
 }
 
 func (m *MockUserRepository) Count(ctx context.Context, filter bson.M) (int64, error) {
-	return int64(len(m.users)), nil
+	var count int64
+	for _, user := range m.users {
+		if matchesUserFilter(user, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockUserRepository) ReserveLoginAttempt(ctx context.Context, user *models.User, at time.Time) (int, error) {
+	stored, exists := m.users[user.ID.Hex()]
+	if !exists {
+		return 0, repositories.ErrUserNotFound
+	}
+	if stored.FailedLoginAttempts != user.FailedLoginAttempts || !sameTime(stored.LastFailedLoginAt, user.LastFailedLoginAt) || !sameTime(stored.LockedUntil, user.LockedUntil) {
+		return 0, repositories.ErrLoginAttemptConflict
+	}
+	stored.FailedLoginAttempts++
+	stored.LastFailedLoginAt = &at
+	return stored.FailedLoginAttempts, nil
+}
+
+func (m *MockUserRepository) ReleaseLoginAttempt(ctx context.Context, id string, at time.Time, lastFailure *time.Time) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	if user.FailedLoginAttempts > 0 {
+		user.FailedLoginAttempts--
+	}
+	if sameTime(user.LastFailedLoginAt, &at) {
+		user.LastFailedLoginAt = lastFailure
+	}
+	return nil
+}
+
+// sameTime compara datas opcionais como o filtro do MongoDB
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (m *MockUserRepository) MarkLoginFailure(ctx context.Context, id string, at time.Time) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	if user.LastFailedLoginAt == nil || user.LastFailedLoginAt.Before(at) {
+		user.LastFailedLoginAt = &at
+	}
+	return nil
+}
+
+func (m *MockUserRepository) Lock(ctx context.Context, id string, until time.Time) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	user.LockedUntil = &until
+	return nil
+}
+
+func (m *MockUserRepository) ClearLoginFailures(ctx context.Context, id string) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	user.FailedLoginAttempts = 0
+	user.LastFailedLoginAt = nil
+	user.LockedUntil = nil
+	return nil
+}
+
+func (m *MockUserRepository) FindByVolunteerID(ctx context.Context, volunteerID string) (*models.User, error) {
+	for _, user := range m.users {
+		if user.VolunteerID == volunteerID {
+			return user, nil
+		}
+	}
+	return nil, repositories.ErrUserNotFound
+}
+
+func (m *MockUserRepository) SetVolunteer(ctx context.Context, id string, volunteerID string) error {
+	user, exists := m.users[id]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	// Simula o índice único do voluntário vinculado
+	for _, other := range m.users {
+		if volunteerID != "" && other.VolunteerID == volunteerID && other.ID != user.ID {
+			return repositories.ErrVolunteerAlreadyLinked
+		}
+	}
+	user.VolunteerID = volunteerID
+	return nil
+}
+
+func (m *MockUserRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// matchesUserFilter aplica os filtros simples de igualdade (role, is_active) usados nos testes
+func matchesUserFilter(user *models.User, filter bson.M) bool {
+	if role, ok := filter["role"]; ok && user.Role != role {
+		return false
+	}
+	if isActive, ok := filter["is_active"]; ok && user.IsActive != isActive {
+		return false
+	}
+	return true
+}
+
+// MockSessionRepository é um mock do repositório de sessões para testes
+type MockSessionRepository struct {
+	sessions map[string]*models.Session
+}
+
+func NewMockSessionRepository() *MockSessionRepository {
+	return &MockSessionRepository{
+		sessions: make(map[string]*models.Session),
+	}
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	session.ID = primitive.NewObjectID()
+	m.sessions[session.TokenID] = session
+	return nil
+}
+
+func (m *MockSessionRepository) FindByTokenID(ctx context.Context, tokenID string) (*models.Session, error) {
+	session, exists := m.sessions[tokenID]
+	if !exists {
+		return nil, repositories.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (m *MockSessionRepository) Rotate(ctx context.Context, tokenID string, replacedBy string) error {
+	session, exists := m.sessions[tokenID]
+	if !exists || session.IsRotated() || session.IsRevoked() {
+		return repositories.ErrSessionAlreadyRotated
+	}
+	now := time.Now()
+	session.RotatedAt = &now
+	session.ReplacedBy = replacedBy
+	return nil
+}
+
+func (m *MockSessionRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.FamilyID == familyID && !session.IsRevoked() {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockSessionRepository) RevokeByUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.UserID == userID && !session.IsRevoked() {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockSessionRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// MockInviteRepository é um mock do repositório de convites para testes
+type MockInviteRepository struct {
+	invites map[string]*models.Invite
+}
+
+func NewMockInviteRepository() *MockInviteRepository {
+	return &MockInviteRepository{
+		invites: make(map[string]*models.Invite),
+	}
+}
+
+func (m *MockInviteRepository) Create(ctx context.Context, invite *models.Invite) error {
+	invite.ID = primitive.NewObjectID()
+	m.invites[invite.TokenID] = invite
+	return nil
+}
+
+func (m *MockInviteRepository) FindByTokenID(ctx context.Context, tokenID string) (*models.Invite, error) {
+	invite, exists := m.invites[tokenID]
+	if !exists {
+		return nil, repositories.ErrInviteNotFound
+	}
+	return invite, nil
+}
+
+func (m *MockInviteRepository) MarkUsed(ctx context.Context, tokenID string, userID string) error {
+	invite, exists := m.invites[tokenID]
+	if !exists || invite.IsUsed() {
+		return repositories.ErrInviteAlreadyUsed
+	}
+	now := time.Now()
+	invite.UsedAt = &now
+	invite.UsedBy = userID
+	return nil
+}
+
+func (m *MockInviteRepository) Release(ctx context.Context, tokenID string) error {
+	if invite, exists := m.invites[tokenID]; exists {
+		invite.UsedAt = nil
+		invite.UsedBy = ""
+	}
+	return nil
+}
+
+// MockSettingsRepository é um mock do repositório de configurações para testes
+type MockSettingsRepository struct {
+	security models.SecuritySettings
+}
+
+func NewMockSettingsRepository() *MockSettingsRepository {
+	return &MockSettingsRepository{}
+}
+
+func (m *MockSettingsRepository) GetSecurity(ctx context.Context) (*models.SecuritySettings, error) {
+	settings := m.security
+	return &settings, nil
+}
+
+func (m *MockSettingsRepository) SaveSecurity(ctx context.Context, settings *models.SecuritySettings) error {
+	m.security = *settings
+	return nil
+}
+
+func (m *MockUserRepository) UpdateMFA(ctx context.Context, user *models.User) error {
+	stored, exists := m.users[user.ID.Hex()]
+	if !exists {
+		return repositories.ErrUserNotFound
+	}
+	stored.MFAEnabled = user.MFAEnabled
+	stored.MFASecret = user.MFASecret
+	stored.MFAPendingSecret = user.MFAPendingSecret
+	stored.MFARecoveryCodes = user.MFARecoveryCodes
+	stored.MFALastUsedStep = user.MFALastUsedStep
+	return nil
+}
+
+func (m *MockUserRepository) ConsumeMFAStep(ctx context.Context, id string, step int64) (bool, error) {
+	stored, exists := m.users[id]
+	if !exists || !stored.MFAEnabled || stored.MFALastUsedStep >= step {
+		return false, nil
+	}
+	stored.MFALastUsedStep = step
+	return true, nil
+}
+
+func (m *MockUserRepository) ConsumeRecoveryCode(ctx context.Context, id string, hash string) (bool, error) {
+	stored, exists := m.users[id]
+	if !exists || !stored.MFAEnabled {
+		return false, nil
+	}
+	for i, code := range stored.MFARecoveryCodes {
+		if code == hash {
+			stored.MFARecoveryCodes = append(stored.MFARecoveryCodes[:i:i], stored.MFARecoveryCodes[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func TestAuthService_Login(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
 	ctx := context.Background()
 
 	// Cria um usuário de teste
@@ -228,9 +535,10 @@ func TestAuthService_Register(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		name    string
-		req     *models.CreateUserRequest
-		wantErr bool
+		name     string
+		req      *models.CreateUserRequest
+		wantRole string
+		wantErr  bool
 	}{
 		{
 			name: "Successful registration",
@@ -240,7 +548,8 @@ func TestAuthService_Register(t *testing.T) {
 				Password: "NewPassword123",
 				Role:     "member",
 			},
-			wantErr: false,
+			wantRole: "member",
+			wantErr:  false,
 		},
 		{
 			name: "Duplicate email",
@@ -273,21 +582,22 @@ func TestAuthService_Register(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Valid admin registration",
+			name: "Requested admin role is ignored",
 			req: &models.CreateUserRequest{
 				Name:     "Admin User",
 				Email:    "admin@example.com",
 				Password: "AdminPass123",
 				Role:     "admin",
 			},
-			wantErr: false,
+			wantRole: "member",
+			wantErr:  false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := NewMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
 
 			// Para o teste de email duplicado, cria o usuário primeiro
 			if tt.name == "Duplicate email" {
@@ -327,8 +637,8 @@ func TestAuthService_Register(t *testing.T) {
 				t.Errorf("Register() name = %v, want %v", user.Name, tt.req.Name)
 			}
 
-			if user.Role != tt.req.Role {
-				t.Errorf("Register() role = %v, want %v", user.Role, tt.req.Role)
+			if user.Role != tt.wantRole {
+				t.Errorf("Register() role = %v, want %v", user.Role, tt.wantRole)
 			}
 		})
 	}
@@ -336,7 +646,7 @@ func TestAuthService_Register(t *testing.T) {
 
 func TestAuthService_ValidateToken(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
 	ctx := context.Background()
 
 	// Cria um usuário e faz login para obter um token válido
@@ -402,12 +712,66 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	}
 }
 
-func TestAuthService_RefreshToken(t *testing.T) {
+func TestAuthService_RefreshTokenRotation(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	sessionRepo := NewMockSessionRepository()
+	service := NewAuthService(mockRepo, sessionRepo, NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+	ctx := context.Background()
+
+	testUser := &models.User{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "TestPassword123",
+		Role:     "member",
+		IsActive: true,
+	}
+	mockRepo.Create(ctx, testUser)
+
+	loginResponse, err := service.Login(ctx, "test@example.com", "TestPassword123")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	// O refresh token é gravado apenas como hash
+	for _, session := range sessionRepo.sessions {
+		if session.TokenHash == loginResponse.RefreshToken {
+			t.Error("session should store a hash of the refresh token")
+		}
+	}
+
+	refreshed, err := service.RefreshTokenWithRefreshToken(ctx, loginResponse.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokenWithRefreshToken() error = %v", err)
+	}
+	if refreshed.RefreshToken == loginResponse.RefreshToken {
+		t.Error("RefreshTokenWithRefreshToken() should rotate the refresh token")
+	}
+
+	// Reutilizar o token antigo revoga toda a família, inclusive o token novo
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, loginResponse.RefreshToken); err != ErrRefreshTokenReused {
+		t.Errorf("reusing a rotated token error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, refreshed.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh after reuse error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+
+	// Uma nova sessão não é afetada pela revogação da anterior
+	other, _ := service.Login(ctx, "test@example.com", "TestPassword123")
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, other.RefreshToken); err != nil {
+		t.Errorf("refresh of another session error = %v", err)
+	}
+
+	// Access tokens não possuem sessão e não servem como refresh token
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, loginResponse.AccessToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh with access token error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestAuthService_Logout(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
 	ctx := context.Background()
 
-	// Cria um usuário e faz login
 	testUser := &models.User{
 		Name:     "Test User",
 		Email:    "test@example.com",
@@ -418,31 +782,358 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	mockRepo.Create(ctx, testUser)
 
 	loginResponse, _ := service.Login(ctx, "test@example.com", "TestPassword123")
-	validToken := loginResponse.AccessToken
+	refreshed, err := service.RefreshTokenWithRefreshToken(ctx, loginResponse.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshTokenWithRefreshToken() error = %v", err)
+	}
+
+	if err := service.Logout(ctx, refreshed.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, refreshed.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh after logout error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
 
-	// Testa refresh com token válido
-	newToken, err := service.RefreshToken(validToken)
+	if err := service.Logout(ctx, "invalid.token.string"); err != ErrInvalidRefreshToken {
+		t.Errorf("Logout() with invalid token error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestAuthService_RefreshUsesCurrentUser(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+	ctx := context.Background()
+
+	testUser := &models.User{
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "TestPassword123",
+		Role:     "member",
+		IsActive: true,
+	}
+	mockRepo.Create(ctx, testUser)
+
+	loginResponse, _ := service.Login(ctx, "test@example.com", "TestPassword123")
+
+	// O papel alterado depois do login vale a partir da próxima renovação
+	testUser.Role = "admin"
+	refreshed, err := service.RefreshTokenWithRefreshToken(ctx, loginResponse.RefreshToken)
 	if err != nil {
-		t.Errorf("RefreshToken() unexpected error = %v", err)
+		t.Fatalf("RefreshTokenWithRefreshToken() error = %v", err)
+	}
+
+	claims, err := service.ValidateToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.Role != "admin" {
+		t.Errorf("refreshed access token role = %v, want admin", claims.Role)
+	}
+
+	// Refresh tokens não são aceitos como access tokens
+	if _, err := service.ValidateToken(refreshed.RefreshToken); err == nil {
+		t.Error("ValidateToken() should reject a refresh token")
+	}
+
+	// Usuário desativado não consegue renovar
+	testUser.IsActive = false
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, refreshed.RefreshToken); err != ErrUserInactive {
+		t.Errorf("refresh of inactive user error = %v, want %v", err, ErrUserInactive)
+	}
+}
+
+func TestAuthService_RegistrationModes(t *testing.T) {
+	ctx := context.Background()
+	req := &models.CreateUserRequest{
+		Name:     "New User",
+		Email:    "newuser@example.com",
+		Password: "NewPassword123",
+	}
+
+	disabled := NewAuthService(NewMockUserRepository(), NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationDisabled)
+	if _, err := disabled.Register(ctx, req); err != ErrRegistrationDisabled {
+		t.Errorf("Register() with disabled mode error = %v, want %v", err, ErrRegistrationDisabled)
 	}
 
-	if newToken == "" {
-		t.Error("RefreshToken() returned empty token")
+	inviteOnly := NewAuthService(NewMockUserRepository(), NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationInviteOnly)
+	if _, err := inviteOnly.Register(ctx, req); err != ErrInviteRequired {
+		t.Errorf("Register() without invite error = %v, want %v", err, ErrInviteRequired)
 	}
 
-	// Valida que o novo token é válido
-	claims, err := service.ValidateToken(newToken)
+	tests := []struct {
+		value   string
+		want    RegistrationMode
+		wantErr bool
+	}{
+		{"", RegistrationOpen, false},
+		{"invite", RegistrationInviteOnly, false},
+		{" Disabled ", RegistrationDisabled, false},
+		{"public", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRegistrationMode(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseRegistrationMode(%q) = %v, %v; want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAuthService_RegisterWithInvite(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	inviteRepo := NewMockInviteRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), inviteRepo, NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationInviteOnly)
+
+	invite, err := service.CreateInvite(ctx, &models.CreateInviteRequest{Email: "Coord@Example.com", Role: "admin"}, "admin-id")
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+
+	req := &models.CreateUserRequest{
+		Name:        "Coordinator",
+		Email:       "other@example.com",
+		Password:    "Password123",
+		Role:        "member",
+		InviteToken: invite.Token,
+	}
+
+	// O convite só vale para o email informado na emissão
+	if _, err := service.Register(ctx, req); err != ErrInviteEmailMismatch {
+		t.Errorf("Register() with other email error = %v, want %v", err, ErrInviteEmailMismatch)
+	}
+
+	// O papel vem do convite, não da requisição
+	req.Email = "coord@example.com"
+	user, err := service.Register(ctx, req)
 	if err != nil {
-		t.Errorf("New token is invalid: %v", err)
+		t.Fatalf("Register() error = %v", err)
+	}
+	if user.Role != "admin" {
+		t.Errorf("Register() role = %v, want admin", user.Role)
+	}
+	if stored := inviteRepo.invites[invite.TokenID]; stored.UsedBy != user.ID.Hex() {
+		t.Errorf("invite used_by = %v, want %v", stored.UsedBy, user.ID.Hex())
 	}
 
-	if claims.Email != "test@example.com" {
-		t.Errorf("New token email = %v, want test@example.com", claims.Email)
+	// O convite é de uso único
+	req.Email = "coord2@example.com"
+	if _, err := service.Register(ctx, req); err != ErrInvalidInvite {
+		t.Errorf("Register() with used invite error = %v, want %v", err, ErrInvalidInvite)
 	}
 
-	// Testa refresh com token inválido
-	_, err = service.RefreshToken("invalid.token")
-	if err == nil {
-		t.Error("RefreshToken() should return error for invalid token")
+	// Um cadastro que falha libera o convite
+	retry, err := service.CreateInvite(ctx, &models.CreateInviteRequest{Role: "member"}, "admin-id")
+	if err != nil {
+		t.Fatalf("CreateInvite() error = %v", err)
+	}
+	req.InviteToken = retry.Token
+	req.Email = "coord@example.com"
+	if _, err := service.Register(ctx, req); err != repositories.ErrUserAlreadyExists {
+		t.Errorf("Register() with duplicate email error = %v, want %v", err, repositories.ErrUserAlreadyExists)
+	}
+	if inviteRepo.invites[retry.TokenID].IsUsed() {
+		t.Error("failed registration should release the invite")
+	}
+
+	// Convites expirados e tokens de outro tipo são rejeitados
+	inviteRepo.invites[retry.TokenID].ExpiresAt = time.Now().Add(-time.Minute)
+	req.Email = "late@example.com"
+	if _, err := service.Register(ctx, req); err != ErrInvalidInvite {
+		t.Errorf("Register() with expired invite error = %v, want %v", err, ErrInvalidInvite)
+	}
+	req.InviteToken = "not-a-token"
+	if _, err := service.Register(ctx, req); err != ErrInvalidInvite {
+		t.Errorf("Register() with invalid token error = %v, want %v", err, ErrInvalidInvite)
+	}
+}
+
+func TestAuthService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+	newTestUser(mockRepo, "taken@example.com", "member")
+
+	current, err := service.GetCurrentUser(ctx, user.ID.Hex())
+	if err != nil || current.Email != user.Email || current.Role != "member" {
+		t.Fatalf("GetCurrentUser() = %+v, %v", current, err)
+	}
+
+	if _, err := service.UpdateProfile(ctx, user.ID.Hex(), &models.UpdateProfileRequest{Email: "taken@example.com"}); err != repositories.ErrUserAlreadyExists {
+		t.Errorf("UpdateProfile() with taken email error = %v, want %v", err, repositories.ErrUserAlreadyExists)
+	}
+
+	updated, err := service.UpdateProfile(ctx, user.ID.Hex(), &models.UpdateProfileRequest{Name: "  Novo Nome ", Email: "new@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if updated.Name != "Novo Nome" || updated.Email != "new@example.com" || updated.Role != "member" {
+		t.Errorf("UpdateProfile() = %+v", updated)
+	}
+
+	if _, err := service.GetCurrentUser(ctx, primitive.NewObjectID().Hex()); err != repositories.ErrUserNotFound {
+		t.Errorf("GetCurrentUser() unknown user error = %v, want %v", err, repositories.ErrUserNotFound)
+	}
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+	login, err := service.Login(ctx, user.Email, "TestPassword123")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if _, err := service.ChangePassword(ctx, user.ID.Hex(), "WrongPassword123", "NewPassword123"); err != ErrWrongPassword {
+		t.Errorf("ChangePassword() with wrong password error = %v, want %v", err, ErrWrongPassword)
+	}
+	if _, err := service.ChangePassword(ctx, user.ID.Hex(), "TestPassword123", "weak"); err == nil {
+		t.Error("ChangePassword() with weak password should fail")
+	}
+
+	changed, err := service.ChangePassword(ctx, user.ID.Hex(), "TestPassword123", "NewPassword123")
+	if err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+
+	// A senha nova é gravada com hash
+	if user.Password == "NewPassword123" {
+		t.Error("ChangePassword() stored the password in plain text")
+	}
+	if _, err := service.Login(ctx, user.Email, "NewPassword123"); err != nil {
+		t.Errorf("Login() with new password error = %v", err)
+	}
+
+	// Sessões anteriores são encerradas; a nova continua válida
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, login.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh of old session error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, changed.RefreshToken); err != nil {
+		t.Errorf("refresh of new session error = %v", err)
+	}
+}
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+
+	// elapse simula a passagem do tempo desde a última falha
+	elapse := func() {
+		past := time.Now().Add(-time.Hour)
+		user.LastFailedLoginAt = &past
+	}
+
+	for i := 0; i < loginDelayThreshold; i++ {
+		if _, err := service.Login(ctx, user.Email, "WrongPassword123"); err != ErrInvalidCredentials {
+			t.Fatalf("Login() failure %d error = %v, want %v", i+1, err, ErrInvalidCredentials)
+		}
+	}
+
+	// Atraso progressivo: a próxima tentativa precisa aguardar, mesmo com a senha certa
+	_, err := service.Login(ctx, user.Email, "TestPassword123")
+	var blocked *LoginBlockedError
+	if !errors.As(err, &blocked) || blocked.Locked || blocked.RetryAfter <= 0 || blocked.RetryAfter > loginBaseDelay {
+		t.Fatalf("Login() during delay error = %v, want a short LoginBlockedError", err)
+	}
+	if !errors.Is(err, ErrLoginBlocked) {
+		t.Error("LoginBlockedError should match ErrLoginBlocked")
+	}
+
+	for user.FailedLoginAttempts < maxLoginFailures {
+		elapse()
+		if _, err := service.Login(ctx, user.Email, "WrongPassword123"); err != ErrInvalidCredentials {
+			t.Fatalf("Login() error = %v, want %v", err, ErrInvalidCredentials)
+		}
+	}
+
+	// Conta bloqueada: nem a senha correta é aceita
+	elapse()
+	_, err = service.Login(ctx, user.Email, "TestPassword123")
+	if !errors.As(err, &blocked) || !blocked.Locked {
+		t.Fatalf("Login() while locked error = %v, want locked LoginBlockedError", err)
+	}
+	if response := user.ToResponse(); response.LockedUntil == nil {
+		t.Error("ToResponse() should expose the active lock")
+	}
+
+	// Após o bloqueio, o login correto zera o contador
+	past := time.Now().Add(-time.Second)
+	user.LockedUntil = &past
+	if _, err := service.Login(ctx, user.Email, "TestPassword123"); err != nil {
+		t.Fatalf("Login() after lock expired error = %v", err)
+	}
+	if user.FailedLoginAttempts != 0 || user.LockedUntil != nil {
+		t.Errorf("successful login should clear failures, got %d / %v", user.FailedLoginAttempts, user.LockedUntil)
+	}
+}
+
+// staleUserRepository devolve a mesma leitura do usuário em toda busca por
+// email, como várias requisições de login feitas ao mesmo tempo
+type staleUserRepository struct {
+	*MockUserRepository
+	snapshot models.User
+}
+
+func (r *staleUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	read := r.snapshot
+	return &read, nil
+}
+
+func TestAuthService_ConcurrentLoginAttempts(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	user := newTestUser(mockRepo, "member@example.com", "member")
+	hourAgo := time.Now().Add(-time.Hour)
+	user.FailedLoginAttempts, user.LastFailedLoginAt = loginDelayThreshold-1, &hourAgo
+
+	repo := &staleUserRepository{MockUserRepository: mockRepo, snapshot: *user}
+	service := NewAuthService(repo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	// Todas as tentativas leem o contador antes do atraso; só a primeira passa
+	var checked, blocked int
+	for i := 0; i < maxLoginFailures*2; i++ {
+		_, err := service.Login(ctx, user.Email, "WrongPassword123")
+		switch {
+		case err == ErrInvalidCredentials:
+			checked++
+		case errors.Is(err, ErrLoginBlocked):
+			blocked++
+		default:
+			t.Fatalf("Login() error = %v", err)
+		}
+	}
+
+	if checked != 1 || blocked != maxLoginFailures*2-1 {
+		t.Errorf("checked = %d, blocked = %d; want 1 password check", checked, blocked)
+	}
+	if user.FailedLoginAttempts != loginDelayThreshold {
+		t.Errorf("FailedLoginAttempts = %d, want %d", user.FailedLoginAttempts, loginDelayThreshold)
+	}
+}
+
+func TestLoginDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{loginDelayThreshold - 1, 0},
+		{loginDelayThreshold, loginBaseDelay},
+		{loginDelayThreshold + 2, 4 * loginBaseDelay},
+		{100, loginLockoutDuration},
+	}
+
+	for _, tt := range tests {
+		if got := loginDelay(tt.failures); got != tt.want {
+			t.Errorf("loginDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
 	}
 }
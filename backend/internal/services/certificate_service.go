@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/pdf"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"encoding/base32"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CertificateService define a interface para o serviço de certificados
+type CertificateService interface {
+	Generate(ctx context.Context, volunteerID string, issuedBy string) (*models.Certificate, []byte, error)
+	Current(ctx context.Context, volunteerID string, issuedBy string) (*models.Certificate, []byte, error)
+	Verify(ctx context.Context, code string) (*models.CertificateVerification, error)
+	GetByVolunteer(ctx context.Context, volunteerID string, page, limit int) (*models.Page[*models.Certificate], error)
+	Revoke(ctx context.Context, code string, revokedBy string, reason string) (*models.Certificate, error)
+}
+
+// certificateService implementa CertificateService
+type certificateService struct {
+	repo           repositories.CertificateRepository
+	volunteerRepo  repositories.VolunteerRepository
+	workshopRepo   repositories.WorkshopRepository
+	attendanceRepo repositories.AttendanceRepository
+	auditRepo      repositories.AuditRepository
+	verifyBaseURL  string // endereço público usado para verificar os certificados
+}
+
+// NewCertificateService cria uma nova instância do serviço
+func NewCertificateService(repo repositories.CertificateRepository, volunteerRepo repositories.VolunteerRepository, workshopRepo repositories.WorkshopRepository, attendanceRepo repositories.AttendanceRepository, auditRepo repositories.AuditRepository, verifyBaseURL string) CertificateService {
+	return &certificateService{
+		repo:           repo,
+		volunteerRepo:  volunteerRepo,
+		workshopRepo:   workshopRepo,
+		attendanceRepo: attendanceRepo,
+		auditRepo:      auditRepo,
+		verifyBaseURL:  strings.TrimSuffix(verifyBaseURL, "/"),
+	}
+}
+
+// Generate emite o certificado de participação do voluntário, grava seus dados
+// para verificação posterior e retorna o PDF
+func (s *certificateService) Generate(ctx context.Context, volunteerID string, issuedBy string) (*models.Certificate, []byte, error) {
+	certificate, err := s.draft(ctx, volunteerID, issuedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.issue(ctx, certificate)
+}
+
+// Current retorna o último certificado válido do voluntário, sem emitir outro,
+// enquanto os dados impressos continuarem os mesmos. Se algo mudou, ou se não
+// houver certificado válido, emite um novo como Generate.
+func (s *certificateService) Current(ctx context.Context, volunteerID string, issuedBy string) (*models.Certificate, []byte, error) {
+	certificate, err := s.draft(ctx, volunteerID, issuedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := s.repo.FindLatestValid(ctx, volunteerID)
+	switch err {
+	case nil:
+		if latest.SameContent(certificate) {
+			data, err := renderCertificate(latest, s.verifyURL(latest.Code))
+			if err != nil {
+				return nil, nil, err
+			}
+			return latest, data, nil
+		}
+	case repositories.ErrCertificateNotFound:
+	default:
+		return nil, nil, err
+	}
+
+	return s.issue(ctx, certificate)
+}
+
+// draft monta o certificado com os dados atuais do voluntário, ainda sem código
+func (s *certificateService) draft(ctx context.Context, volunteerID string, issuedBy string) (*models.Certificate, error) {
+	volunteer, err := s.volunteerRepo.FindByID(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := s.attendanceRepo.SumHoursByVolunteer(ctx, []string{volunteerID})
+	if err != nil {
+		return nil, err
+	}
+
+	workshops, err := s.workshopTitles(ctx, volunteer)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewCertificate("", volunteer, hours[volunteerID], workshops, issuedBy), nil
+}
+
+// issue gera o código do certificado, grava seus dados para verificação
+// posterior e retorna o PDF
+func (s *certificateService) issue(ctx context.Context, certificate *models.Certificate) (*models.Certificate, []byte, error) {
+	code, err := newCertificateCode()
+	if err != nil {
+		return nil, nil, err
+	}
+	certificate.Code = code
+
+	data, err := renderCertificate(certificate, s.verifyURL(code))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.Create(ctx, certificate); err != nil {
+		return nil, nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityCertificate, certificate.Code, nil, certificate)
+
+	return certificate, data, nil
+}
+
+// Verify busca os dados públicos de um certificado emitido pelo código
+func (s *certificateService) Verify(ctx context.Context, code string) (*models.CertificateVerification, error) {
+	certificate, err := s.repo.FindByCode(ctx, normalizeCertificateCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	verification := certificate.ToVerification()
+	return &verification, nil
+}
+
+// GetByVolunteer busca uma página dos certificados emitidos para um voluntário
+func (s *certificateService) GetByVolunteer(ctx context.Context, volunteerID string, page, limit int) (*models.Page[*models.Certificate], error) {
+	if _, err := s.volunteerRepo.FindByID(ctx, volunteerID); err != nil {
+		return nil, err
+	}
+
+	page, limit = models.NormalizePagination(page, limit)
+
+	certificates, err := s.repo.FindByVolunteer(ctx, volunteerID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountByVolunteer(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(certificates, total, page, limit), nil
+}
+
+// Revoke revoga um certificado emitido; ele continua verificável, mas com status revogado
+func (s *certificateService) Revoke(ctx context.Context, code string, revokedBy string, reason string) (*models.Certificate, error) {
+	code = normalizeCertificateCode(code)
+
+	current, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	before := models.AuditSnapshot(current)
+
+	if err := s.repo.Revoke(ctx, code, revokedBy, reason); err != nil {
+		return nil, err
+	}
+
+	certificate, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityCertificate, code, before, certificate)
+	return certificate, nil
+}
+
+// verifyURL monta o endereço de verificação pública do certificado
+func (s *certificateService) verifyURL(code string) string {
+	return s.verifyBaseURL + "/api/certificates/" + code
+}
+
+// workshopTitles retorna os títulos das oficinas em que o voluntário está
+// inscrito ou nas quais tem presença registrada
+func (s *certificateService) workshopTitles(ctx context.Context, volunteer *models.Volunteer) ([]string, error) {
+	ids := append([]string{}, volunteer.Workshops...)
+
+	records, err := s.attendanceRepo.FindAll(ctx, repositories.AttendanceFilter{VolunteerID: volunteer.ID.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	for _, attendance := range records {
+		ids = append(ids, attendance.WorkshopID)
+	}
+
+	seen := make(map[string]bool, len(ids))
+	titles := []string{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		workshop, err := s.workshopRepo.FindByID(ctx, id)
+		if err != nil {
+			// Oficinas removidas não aparecem no certificado
+			if err == repositories.ErrWorkshopNotFound {
+				continue
+			}
+			return nil, err
+		}
+		titles = append(titles, workshop.Title)
+	}
+
+	return titles, nil
+}
+
+// normalizeCertificateCode aceita o código digitado com espaços ou em minúsculas
+func normalizeCertificateCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// newCertificateCode gera um código de verificação no formato ELLP-XXXX-XXXX-XXXX
+func newCertificateCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	raw := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)
+	return fmt.Sprintf("ELLP-%s-%s-%s", raw[0:4], raw[4:8], raw[8:12]), nil
+}
+
+var (
+	certificateBlue = pdf.Color{R: 25, G: 118, B: 210}
+	certificateGray = pdf.Color{R: 102, G: 102, B: 102}
+)
+
+// Limites de linhas usadas pelo período e pela lista de oficinas no certificado
+const (
+	maxPeriodLines   = 2
+	maxWorkshopLines = 3
+)
+
+// renderCertificate desenha o certificado em uma página A4 em paisagem
+func renderCertificate(certificate *models.Certificate, verifyURL string) ([]byte, error) {
+	doc := pdf.NewDocument(pdf.A4Height, pdf.A4Width)
+	doc.SetTitle("Certificado de Participação - " + certificate.VolunteerName)
+
+	width, height := doc.Width(), doc.Height()
+	center := width / 2
+
+	// Moldura
+	doc.Rect(20, 20, width-40, height-40, 3, certificateBlue)
+	doc.Rect(28, 28, width-56, height-56, 0.75, certificateBlue)
+
+	doc.Text(center, 110, pdf.HelveticaBold, 30, certificateBlue, pdf.AlignCenter, "CERTIFICADO DE PARTICIPAÇÃO")
+	doc.Line(center-180, 128, center+180, 128, 1.5, certificateBlue)
+
+	doc.Text(center, 175, pdf.Helvetica, 16, pdf.Black, pdf.AlignCenter, "Certificamos que")
+	doc.Text(center, 212, pdf.HelveticaBold, 24, certificateBlue, pdf.AlignCenter, certificate.VolunteerName)
+
+	y := 240.0
+	if certificate.IsAcademic {
+		doc.Text(center, y, pdf.Helvetica, 12, certificateGray, pdf.AlignCenter,
+			fmt.Sprintf("Curso: %s | RA: %s", certificate.Course, certificate.RA))
+		y += 22
+	}
+
+	doc.Text(center, y+10, pdf.Helvetica, 16, pdf.Black, pdf.AlignCenter, "participou como voluntário(a) do projeto")
+	doc.Text(center, y+40, pdf.HelveticaBold, 18, certificateBlue, pdf.AlignCenter, "ELLP - Ensino Lúdico de Lógica e Programação")
+
+	// Voluntários com vários períodos podem precisar de uma linha a mais
+	period := fmt.Sprintf("%s, totalizando %s de atividades.", certificatePeriod(certificate), formatHours(certificate.TotalHours))
+	for _, line := range wrapText(pdf.Helvetica, 13, width-160, period, maxPeriodLines) {
+		doc.Text(center, y+72, pdf.Helvetica, 13, pdf.Black, pdf.AlignCenter, line)
+		y += 17
+	}
+
+	if len(certificate.Workshops) > 0 {
+		lines := wrapText(pdf.Helvetica, 11, width-160, "Oficinas: "+strings.Join(certificate.Workshops, ", "), maxWorkshopLines)
+		for i, line := range lines {
+			doc.Text(center, y+87+float64(i)*15, pdf.Helvetica, 11, certificateGray, pdf.AlignCenter, line)
+		}
+	}
+
+	// Rodapé
+	doc.Text(center, height-104, pdf.Helvetica, 11, certificateGray, pdf.AlignCenter,
+		"Documento emitido em "+formatLongDate(certificate.IssuedAt))
+	doc.Text(center, height-88, pdf.Helvetica, 11, certificateGray, pdf.AlignCenter,
+		"Projeto ELLP - Universidade Tecnológica Federal do Paraná")
+	doc.Text(center, height-68, pdf.HelveticaBold, 11, pdf.Black, pdf.AlignCenter,
+		"Código de verificação: "+certificate.Code)
+	doc.Text(center, height-52, pdf.Helvetica, 9, certificateGray, pdf.AlignCenter,
+		"Confira a autenticidade em "+verifyURL)
+
+	return doc.Bytes()
+}
+
+// certificatePeriod descreve os períodos de participação; sem data de saída,
+// a participação segue até a data de emissão
+func certificatePeriod(certificate *models.Certificate) string {
+	periods := certificate.ServicePeriods()
+	if len(periods) == 1 {
+		if periods[0].ExitDate == nil {
+			return "desde " + formatLongDate(periods[0].EntryDate) + " até a presente data"
+		}
+		return fmt.Sprintf("no período de %s a %s", formatLongDate(periods[0].EntryDate), formatLongDate(*periods[0].ExitDate))
+	}
+
+	parts := make([]string, len(periods))
+	for i, period := range periods {
+		if period.ExitDate == nil {
+			parts[i] = "de " + formatLongDate(period.EntryDate) + " até a presente data"
+			continue
+		}
+		parts[i] = fmt.Sprintf("de %s a %s", formatLongDate(period.EntryDate), formatLongDate(*period.ExitDate))
+	}
+	return "nos períodos " + strings.Join(parts[:len(parts)-1], ", ") + " e " + parts[len(parts)-1]
+}
+
+var monthNames = [...]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
+
+// formatLongDate formata a data por extenso (ex.: 15 de março de 2025)
+func formatLongDate(date time.Time) string {
+	return fmt.Sprintf("%d de %s de %d", date.Day(), monthNames[date.Month()-1], date.Year())
+}
+
+// formatHours formata as horas com vírgula decimal (ex.: 12,5 horas)
+func formatHours(hours float64) string {
+	formatted := strings.Replace(strconv.FormatFloat(hours, 'f', -1, 64), ".", ",", 1)
+	if hours == 1 {
+		return formatted + " hora"
+	}
+	return formatted + " horas"
+}
+
+// wrapText quebra o texto em linhas que cabem na largura informada,
+// truncando com reticências quando excede o número máximo de linhas
+func wrapText(font pdf.Font, size, maxWidth float64, text string, maxLines int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if current == "" || pdf.TextWidth(font, size, candidate) <= maxWidth {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += " …"
+	}
+
+	return lines
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/pdf"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockCertificateRepository é um mock do repositório de certificados para testes
+type MockCertificateRepository struct {
+	certificates map[string]*models.Certificate
+}
+
+func NewMockCertificateRepository() *MockCertificateRepository {
+	return &MockCertificateRepository{
+		certificates: make(map[string]*models.Certificate),
+	}
+}
+
+func (m *MockCertificateRepository) Create(ctx context.Context, certificate *models.Certificate) error {
+	certificate.ID = primitive.NewObjectID()
+	m.certificates[certificate.Code] = certificate
+	return nil
+}
+
+func (m *MockCertificateRepository) FindByCode(ctx context.Context, code string) (*models.Certificate, error) {
+	certificate, exists := m.certificates[code]
+	if !exists {
+		return nil, repositories.ErrCertificateNotFound
+	}
+	return certificate, nil
+}
+
+func (m *MockCertificateRepository) FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.Certificate, error) {
+	certificates := []*models.Certificate{}
+	for _, certificate := range m.certificates {
+		if certificate.VolunteerID == volunteerID {
+			certificates = append(certificates, certificate)
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].IssuedAt.After(certificates[j].IssuedAt) })
+	return pageOf(certificates, page, limit), nil
+}
+
+func (m *MockCertificateRepository) CountByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	certificates, _ := m.FindByVolunteer(ctx, volunteerID, 0, 0)
+	return int64(len(certificates)), nil
+}
+
+func (m *MockCertificateRepository) FindLatestValid(ctx context.Context, volunteerID string) (*models.Certificate, error) {
+	certificates, _ := m.FindByVolunteer(ctx, volunteerID, 0, 0)
+	for _, certificate := range certificates {
+		if !certificate.IsRevoked() {
+			return certificate, nil
+		}
+	}
+	return nil, repositories.ErrCertificateNotFound
+}
+
+func (m *MockCertificateRepository) Revoke(ctx context.Context, code string, revokedBy string, reason string) error {
+	certificate, exists := m.certificates[code]
+	if !exists {
+		return repositories.ErrCertificateNotFound
+	}
+	if certificate.IsRevoked() {
+		return repositories.ErrCertificateAlreadyRevoked
+	}
+	now := time.Now()
+	certificate.RevokedAt = &now
+	certificate.RevokedBy = revokedBy
+	certificate.RevocationReason = reason
+	return nil
+}
+
+func (m *MockCertificateRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func newTestCertificateService(volunteerRepo *MockVolunteerRepository, workshopRepo *MockWorkshopRepository, attendanceRepo *MockAttendanceRepository) (CertificateService, *MockCertificateRepository) {
+	certificateRepo := NewMockCertificateRepository()
+	service := NewCertificateService(certificateRepo, volunteerRepo, workshopRepo, attendanceRepo, NewMockAuditRepository(), "https://ellp.example.com/")
+	return service, certificateRepo
+}
+
+func TestCertificateService_Generate(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	service, certificateRepo := newTestCertificateService(volunteerRepo, workshopRepo, attendanceRepo)
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	enrolled := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	attended := newTestWorkshop(workshopRepo, models.WorkshopStatusCompleted)
+	attended.Title = "Robótica"
+	volunteer.Workshops = []string{enrolled.ID.Hex()}
+
+	// Presença em uma oficina da qual o voluntário já saiu também conta
+	for _, hours := range []float64{2, 1.5} {
+		attendanceRepo.Create(ctx, &models.Attendance{
+			VolunteerID: volunteer.ID.Hex(),
+			WorkshopID:  attended.ID.Hex(),
+			Date:        time.Now(),
+			Hours:       hours,
+		})
+	}
+
+	certificate, data, err := service.Generate(ctx, volunteer.ID.Hex(), "admin")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !regexp.MustCompile(`^ELLP-[A-Z2-7]{4}-[A-Z2-7]{4}-[A-Z2-7]{4}$`).MatchString(certificate.Code) {
+		t.Errorf("Generate() code = %q, want ELLP-XXXX-XXXX-XXXX", certificate.Code)
+	}
+	if certificate.TotalHours != 3.5 {
+		t.Errorf("Generate() total hours = %v, want 3.5", certificate.TotalHours)
+	}
+	if len(certificate.Workshops) != 2 || certificate.Workshops[1] != "Robótica" {
+		t.Errorf("Generate() workshops = %v, want enrolled and attended workshops", certificate.Workshops)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Error("Generate() should return a PDF document")
+	}
+
+	if _, err := certificateRepo.FindByCode(ctx, certificate.Code); err != nil {
+		t.Errorf("Generate() should persist the certificate, FindByCode() error = %v", err)
+	}
+
+	other, _, err := service.Generate(ctx, volunteer.ID.Hex(), "admin")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if other.Code == certificate.Code {
+		t.Error("Generate() should issue a new code for each certificate")
+	}
+
+	if _, _, err := service.Generate(ctx, "inexistente", "admin"); err != repositories.ErrVolunteerNotFound {
+		t.Errorf("Generate() for unknown volunteer error = %v, want %v", err, repositories.ErrVolunteerNotFound)
+	}
+}
+
+func TestCertificateService_VerifyAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	service, _ := newTestCertificateService(volunteerRepo, NewMockWorkshopRepository(), NewMockAttendanceRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	certificate, _, err := service.Generate(ctx, volunteer.ID.Hex(), "admin")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Dados alterados depois da emissão não mudam o certificado
+	volunteer.Name = "Outro Nome"
+
+	// O código pode ser digitado em minúsculas
+	verification, err := service.Verify(ctx, " "+strings.ToLower(certificate.Code)+" ")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verification.Status != models.CertificateStatusValid || verification.VolunteerName != "João Silva" {
+		t.Errorf("Verify() = %+v, want valid certificate issued to João Silva", verification)
+	}
+
+	if _, err := service.Verify(ctx, "ELLP-AAAA-BBBB-CCCC"); err != repositories.ErrCertificateNotFound {
+		t.Errorf("Verify() unknown code error = %v, want %v", err, repositories.ErrCertificateNotFound)
+	}
+
+	revoked, err := service.Revoke(ctx, certificate.Code, "admin", "emitido por engano")
+	if err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if !revoked.IsRevoked() || revoked.RevocationReason != "emitido por engano" {
+		t.Errorf("Revoke() = %+v, want revoked certificate with reason", revoked)
+	}
+
+	if _, err := service.Revoke(ctx, certificate.Code, "admin", "de novo"); err != repositories.ErrCertificateAlreadyRevoked {
+		t.Errorf("second Revoke() error = %v, want %v", err, repositories.ErrCertificateAlreadyRevoked)
+	}
+
+	verification, err = service.Verify(ctx, certificate.Code)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verification.Status != models.CertificateStatusRevoked || verification.RevokedAt == nil {
+		t.Errorf("Verify() status = %q, want %q", verification.Status, models.CertificateStatusRevoked)
+	}
+}
+
+func TestCertificateService_Current(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	service, certificateRepo := newTestCertificateService(volunteerRepo, NewMockWorkshopRepository(), NewMockAttendanceRepository())
+	volunteer := newTestVolunteer(volunteerRepo)
+
+	first, _, err := service.Current(ctx, volunteer.ID.Hex(), "user")
+	if err != nil {
+		t.Fatalf("Current() error = %v", err)
+	}
+	if same, _, _ := service.Current(ctx, volunteer.ID.Hex(), "user"); same.Code != first.Code {
+		t.Errorf("Current() = %s, want the unchanged certificate %s", same.Code, first.Code)
+	}
+
+	// Certificados revogados não são reaproveitados
+	if _, err := service.Revoke(ctx, first.Code, "admin", "dados incorretos"); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if reissued, _, _ := service.Current(ctx, volunteer.ID.Hex(), "user"); reissued.Code == first.Code {
+		t.Error("Current() reused a revoked certificate")
+	}
+
+	// Dados alterados geram um novo certificado
+	volunteer.Name = "João da Silva"
+	changed, _, _ := service.Current(ctx, volunteer.ID.Hex(), "user")
+	if changed.VolunteerName != volunteer.Name || len(certificateRepo.certificates) != 3 {
+		t.Errorf("Current() = %s with %d certificates, want a new certificate for %s", changed.VolunteerName, len(certificateRepo.certificates), volunteer.Name)
+	}
+}
+
+func TestFormatCertificateText(t *testing.T) {
+	date := time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)
+	if got := formatLongDate(date); got != "5 de março de 2025" {
+		t.Errorf("formatLongDate() = %q", got)
+	}
+
+	if got := formatHours(12.5); got != "12,5 horas" {
+		t.Errorf("formatHours(12.5) = %q", got)
+	}
+	if got := formatHours(1); got != "1 hora" {
+		t.Errorf("formatHours(1) = %q", got)
+	}
+
+	exit := date.AddDate(0, 3, 0)
+	certificate := &models.Certificate{Periods: []models.ServicePeriod{
+		{EntryDate: date, ExitDate: &exit},
+		{EntryDate: date.AddDate(1, 0, 0)},
+	}}
+	want := "nos períodos de 5 de março de 2025 a 5 de junho de 2025 e de 5 de março de 2026 até a presente data"
+	if got := certificatePeriod(certificate); got != want {
+		t.Errorf("certificatePeriod() = %q, want %q", got, want)
+	}
+
+	lines := wrapText(pdf.Helvetica, 11, 100, "Oficinas: Scratch, Robótica, Lógica, Python, Arduino, Jogos", 2)
+	if len(lines) != 2 {
+		t.Fatalf("wrapText() lines = %v, want 2 lines", lines)
+	}
+	if last := lines[1]; last[len(last)-len("…"):] != "…" {
+		t.Errorf("wrapText() should end truncated text with an ellipsis, got %q", last)
+	}
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"ellp-volunter-platform/backend/internal/config"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/totp"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMFARequired é retornado pelo login quando falta o segundo fator; o erro
+	// concreto é um *MFAChallengeError com o token de desafio
+	ErrMFARequired = errors.New("autenticação em dois fatores necessária")
+	// ErrMFASetupRequired é retornado quando a política exige MFA e o usuário ainda não o cadastrou
+	ErrMFASetupRequired = errors.New("cadastro da autenticação em dois fatores obrigatório")
+	// ErrInvalidMFAToken é retornado quando o token de desafio é inválido ou expirou
+	ErrInvalidMFAToken = errors.New("desafio de autenticação inválido ou expirado")
+	// ErrInvalidMFACode é retornado quando o código TOTP ou de recuperação é inválido
+	ErrInvalidMFACode = errors.New("código de autenticação inválido")
+	// ErrMFAAlreadyEnabled é retornado ao iniciar o cadastro com MFA já ativo
+	ErrMFAAlreadyEnabled = errors.New("autenticação em dois fatores já está ativa")
+	// ErrMFANotEnabled é retornado ao desativar MFA de um usuário sem MFA
+	ErrMFANotEnabled = errors.New("autenticação em dois fatores não está ativa")
+	// ErrMFASetupNotStarted é retornado ao confirmar um cadastro não iniciado
+	ErrMFASetupNotStarted = errors.New("cadastro da autenticação em dois fatores não foi iniciado")
+	// ErrMFARequiredByPolicy é retornado quando a política impede desativar o MFA
+	ErrMFARequiredByPolicy = errors.New("a autenticação em dois fatores é obrigatória para administradores")
+)
+
+// Configuração do TOTP e dos códigos de recuperação
+const (
+	mfaIssuer         = "ELLP"
+	mfaSkew           = 1 // intervalos de 30s tolerados antes e depois
+	recoveryCodeCount = 10
+)
+
+// MFAChallengeError indica que o login precisa da segunda etapa
+type MFAChallengeError struct {
+	Token         string
+	SetupRequired bool // o usuário precisa cadastrar o autenticador antes de concluir o login
+	ExpiresIn     time.Duration
+}
+
+func (e *MFAChallengeError) Error() string {
+	return ErrMFARequired.Error()
+}
+
+// Is permite comparar com errors.Is(err, ErrMFARequired)
+func (e *MFAChallengeError) Is(target error) bool {
+	return target == ErrMFARequired
+}
+
+// Response converte o desafio na resposta da API
+func (e *MFAChallengeError) Response() models.MFAChallengeResponse {
+	return models.MFAChallengeResponse{
+		MFARequired:      true,
+		MFASetupRequired: e.SetupRequired,
+		MFAToken:         e.Token,
+		ExpiresIn:        int(e.ExpiresIn.Seconds()),
+	}
+}
+
+// VerifyMFA conclui o login com o código TOTP ou de recuperação. Se o usuário
+// estiver cadastrando o autenticador por exigência da política, o código
+// confirma o cadastro e a resposta traz os códigos de recuperação.
+func (s *authService) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResponse, error) {
+	user, err := s.challengeUser(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// O código é limitado pelo mesmo contador de falhas da senha
+	attempt, err := s.reserveLoginAttempt(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	if user.MFAEnabled {
+		// O consumo já grava o código como usado; o restante do MFA não é regravado
+		consumed, err := s.consumeMFACode(ctx, user, code, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if !consumed {
+			if err := s.recordLoginFailure(ctx, user, attempt); err != nil {
+				return nil, err
+			}
+			return nil, ErrInvalidMFACode
+		}
+	} else {
+		recoveryCodes, err = s.confirmMFASetup(user, code)
+		if err != nil {
+			if err == ErrInvalidMFACode {
+				if err := s.recordLoginFailure(ctx, user, attempt); err != nil {
+					return nil, err
+				}
+			}
+			return nil, err
+		}
+
+		if err := s.userRepo.UpdateMFA(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.ClearLoginFailures(ctx, user.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	response, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+
+	return response, nil
+}
+
+// BeginMFAChallengeSetup inicia o cadastro do autenticador durante o login,
+// quando a política exige MFA de um usuário que ainda não o cadastrou
+func (s *authService) BeginMFAChallengeSetup(ctx context.Context, mfaToken string) (*models.MFASetupResponse, error) {
+	user, err := s.challengeUser(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.beginMFASetup(ctx, user)
+}
+
+// BeginMFASetup inicia o cadastro do autenticador do usuário autenticado
+func (s *authService) BeginMFASetup(ctx context.Context, userID string) (*models.MFASetupResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.beginMFASetup(ctx, user)
+}
+
+// EnableMFA confirma o cadastro do autenticador com um código gerado por ele
+// e retorna os códigos de recuperação, que não são exibidos novamente
+func (s *authService) EnableMFA(ctx context.Context, userID, code string) ([]string, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	before := user.ToResponse()
+	recoveryCodes, err := s.confirmMFASetup(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateMFA(ctx, user); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityUser, userID, before, user.ToResponse())
+
+	return recoveryCodes, nil
+}
+
+// DisableMFA desativa o segundo fator após conferir a senha e um código válido
+func (s *authService) DisableMFA(ctx context.Context, userID, password, code string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.MFAEnabled {
+		return ErrMFANotEnabled
+	}
+
+	required, err := s.mfaRequired(ctx, user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequiredByPolicy
+	}
+
+	if err := models.CheckPassword(password, user.Password); err != nil {
+		return ErrWrongPassword
+	}
+
+	consumed, err := s.consumeMFACode(ctx, user, code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidMFACode
+	}
+
+	before := user.ToResponse()
+	clearMFA(user)
+	if err := s.userRepo.UpdateMFA(ctx, user); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityUser, userID, before, user.ToResponse())
+	return nil
+}
+
+// GetSecuritySettings retorna as políticas de segurança em vigor
+func (s *authService) GetSecuritySettings(ctx context.Context) (*models.SecuritySettings, error) {
+	return s.settingsRepo.GetSecurity(ctx)
+}
+
+// UpdateSecuritySettings altera as políticas de segurança. Administradores sem
+// MFA passam a cadastrá-lo no próximo login.
+func (s *authService) UpdateSecuritySettings(ctx context.Context, req *models.UpdateSecuritySettingsRequest, actorID string) (*models.SecuritySettings, error) {
+	settings, err := s.settingsRepo.GetSecurity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *settings
+	settings.RequireAdminMFA = *req.RequireAdminMFA
+	settings.UpdatedAt = time.Now()
+	settings.UpdatedBy = actorID
+
+	if err := s.settingsRepo.SaveSecurity(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntitySettings, "security", &before, settings)
+
+	return settings, nil
+}
+
+// mfaChallenge decide se o login precisa da segunda etapa e, se precisar,
+// retorna o desafio correspondente
+func (s *authService) mfaChallenge(ctx context.Context, user *models.User) error {
+	setupRequired := false
+	if !user.MFAEnabled {
+		required, err := s.mfaRequired(ctx, user)
+		if err != nil || !required {
+			return err
+		}
+		setupRequired = true
+	}
+
+	token, err := config.GenerateMFAToken(user.ID.Hex(), user.Email)
+	if err != nil {
+		return err
+	}
+
+	return &MFAChallengeError{
+		Token:         token,
+		SetupRequired: setupRequired,
+		ExpiresIn:     config.MFATokenExpiration,
+	}
+}
+
+// mfaRequired indica se a política exige MFA do usuário
+func (s *authService) mfaRequired(ctx context.Context, user *models.User) (bool, error) {
+	if user.Role != models.RoleAdmin {
+		return false, nil
+	}
+
+	settings, err := s.settingsRepo.GetSecurity(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return settings.RequireAdminMFA, nil
+}
+
+// challengeUser valida o token de desafio e busca o usuário correspondente
+func (s *authService) challengeUser(ctx context.Context, mfaToken string) (*models.User, error) {
+	claims, err := config.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	return user, nil
+}
+
+// beginMFASetup gera um novo segredo, que só passa a valer após a confirmação
+func (s *authService) beginMFASetup(ctx context.Context, user *models.User) (*models.MFASetupResponse, error) {
+	if user.MFAEnabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.MFAPendingSecret = secret
+	if err := s.userRepo.UpdateMFA(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &models.MFASetupResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(mfaIssuer, user.Email, secret),
+	}, nil
+}
+
+// confirmMFASetup ativa o segredo pendente se o código conferir e gera os
+// códigos de recuperação. As alterações são feitas apenas no usuário em memória.
+func (s *authService) confirmMFASetup(user *models.User, code string) ([]string, error) {
+	if user.MFAPendingSecret == "" {
+		return nil, ErrMFASetupNotStarted
+	}
+
+	step, ok := totp.Verify(user.MFAPendingSecret, code, time.Now(), mfaSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	user.MFAEnabled = true
+	user.MFASecret = user.MFAPendingSecret
+	user.MFAPendingSecret = ""
+	user.MFALastUsedStep = step
+	user.MFARecoveryCodes = hashes
+
+	return recoveryCodes, nil
+}
+
+// consumeMFACode confere um código TOTP ou de recuperação. O consumo é uma
+// gravação condicional no repositório, para que duas requisições concorrentes
+// com o mesmo código não sejam aceitas: um código TOTP já usado não vale de
+// novo e um código de recuperação é descartado no uso.
+func (s *authService) consumeMFACode(ctx context.Context, user *models.User, code string, now time.Time) (bool, error) {
+	if step, ok := totp.Verify(user.MFASecret, code, now, mfaSkew); ok {
+		consumed, err := s.userRepo.ConsumeMFAStep(ctx, user.ID.Hex(), step)
+		if err != nil || !consumed {
+			return false, err
+		}
+		user.MFALastUsedStep = step
+		return true, nil
+	}
+
+	hash := models.HashToken(normalizeRecoveryCode(code))
+	consumed, err := s.userRepo.ConsumeRecoveryCode(ctx, user.ID.Hex(), hash)
+	if err != nil || !consumed {
+		return false, err
+	}
+	for i, stored := range user.MFARecoveryCodes {
+		if stored == hash {
+			user.MFARecoveryCodes = append(user.MFARecoveryCodes[:i:i], user.MFARecoveryCodes[i+1:]...)
+			break
+		}
+	}
+	return true, nil
+}
+
+// clearMFA remove toda a configuração de MFA do usuário
+func clearMFA(user *models.User) {
+	user.MFAEnabled = false
+	user.MFASecret = ""
+	user.MFAPendingSecret = ""
+	user.MFARecoveryCodes = nil
+	user.MFALastUsedStep = 0
+}
+
+// newRecoveryCodes gera os códigos de recuperação (XXXX-XXXX) e seus hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := base32.StdEncoding.EncodeToString(buf)
+		codes[i] = raw[:4] + "-" + raw[4:]
+		hashes[i] = models.HashToken(normalizeRecoveryCode(codes[i]))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode aceita o código digitado sem hífen ou em minúsculas
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/totp"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mfaCode gera o código TOTP do segredo deslocado em intervalos de 30s
+func mfaCode(t *testing.T, secret string, steps int) string {
+	t.Helper()
+	code, err := totp.Code(secret, time.Now().Add(time.Duration(steps)*totp.Period*time.Second))
+	if err != nil {
+		t.Fatalf("totp.Code() error = %v", err)
+	}
+	return code
+}
+
+// loginChallenge faz o login esperando o desafio de MFA
+func loginChallenge(t *testing.T, service AuthService, email, password string) *MFAChallengeError {
+	t.Helper()
+	_, err := service.Login(context.Background(), email, password)
+	var challenge *MFAChallengeError
+	if !errors.As(err, &challenge) {
+		t.Fatalf("Login() error = %v, want MFAChallengeError", err)
+	}
+	return challenge
+}
+
+func TestAuthService_MFAEnrollmentAndLogin(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+
+	setup, err := service.BeginMFASetup(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("BeginMFASetup() error = %v", err)
+	}
+	if !strings.HasPrefix(setup.ProvisioningURI, "otpauth://totp/ELLP:member@example.com?") {
+		t.Errorf("ProvisioningURI = %v", setup.ProvisioningURI)
+	}
+
+	// O segredo só vale depois de confirmado
+	if _, err := service.Login(ctx, user.Email, "TestPassword123"); err != nil {
+		t.Fatalf("Login() before confirmation error = %v", err)
+	}
+	if _, err := service.EnableMFA(ctx, user.ID.Hex(), "000000"); err != ErrInvalidMFACode {
+		t.Errorf("EnableMFA() with wrong code error = %v, want %v", err, ErrInvalidMFACode)
+	}
+
+	code := mfaCode(t, setup.Secret, 0)
+	recoveryCodes, err := service.EnableMFA(ctx, user.ID.Hex(), code)
+	if err != nil {
+		t.Fatalf("EnableMFA() error = %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount || !user.MFAEnabled {
+		t.Fatalf("EnableMFA() codes = %d, enabled = %v", len(recoveryCodes), user.MFAEnabled)
+	}
+	if user.MFARecoveryCodes[0] == recoveryCodes[0] {
+		t.Error("recovery codes should be stored hashed")
+	}
+
+	// Com MFA, a senha sozinha não conclui o login; a senha correta não conta
+	// como falha nem adia a próxima tentativa
+	hourAgo := time.Now().Add(-time.Hour)
+	user.FailedLoginAttempts, user.LastFailedLoginAt = loginDelayThreshold, &hourAgo
+	challenge := loginChallenge(t, service, user.Email, "TestPassword123")
+	if challenge.SetupRequired {
+		t.Error("challenge should not require setup")
+	}
+	if user.FailedLoginAttempts != loginDelayThreshold || !user.LastFailedLoginAt.Equal(hourAgo) {
+		t.Errorf("failures after challenge = %d at %v, want %d at %v", user.FailedLoginAttempts, user.LastFailedLoginAt, loginDelayThreshold, hourAgo)
+	}
+	user.FailedLoginAttempts, user.LastFailedLoginAt = 0, nil
+
+	// O código usado na ativação não pode ser reutilizado
+	if _, err := service.VerifyMFA(ctx, challenge.Token, code); err != ErrInvalidMFACode {
+		t.Errorf("VerifyMFA() with reused code error = %v, want %v", err, ErrInvalidMFACode)
+	}
+
+	response, err := service.VerifyMFA(ctx, challenge.Token, mfaCode(t, setup.Secret, 1))
+	if err != nil {
+		t.Fatalf("VerifyMFA() error = %v", err)
+	}
+	if response.AccessToken == "" || response.RefreshToken == "" || !response.User.MFAEnabled {
+		t.Errorf("VerifyMFA() = %+v", response)
+	}
+	if user.FailedLoginAttempts != 0 {
+		t.Errorf("FailedLoginAttempts = %d, want 0 after a successful login", user.FailedLoginAttempts)
+	}
+
+	// Códigos de recuperação valem uma única vez, com ou sem hífen
+	challenge = loginChallenge(t, service, user.Email, "TestPassword123")
+	recovery := strings.ToLower(strings.ReplaceAll(recoveryCodes[0], "-", ""))
+	if _, err := service.VerifyMFA(ctx, challenge.Token, recovery); err != nil {
+		t.Fatalf("VerifyMFA() with recovery code error = %v", err)
+	}
+	if _, err := service.VerifyMFA(ctx, challenge.Token, recoveryCodes[0]); err != ErrInvalidMFACode {
+		t.Errorf("VerifyMFA() with used recovery code error = %v, want %v", err, ErrInvalidMFACode)
+	}
+
+	// Tokens que não são de desafio são rejeitados
+	if _, err := service.VerifyMFA(ctx, response.AccessToken, recoveryCodes[1]); err != ErrInvalidMFAToken {
+		t.Errorf("VerifyMFA() with access token error = %v, want %v", err, ErrInvalidMFAToken)
+	}
+
+	if err := service.DisableMFA(ctx, user.ID.Hex(), "WrongPassword123", recoveryCodes[1]); err != ErrWrongPassword {
+		t.Errorf("DisableMFA() with wrong password error = %v, want %v", err, ErrWrongPassword)
+	}
+	if err := service.DisableMFA(ctx, user.ID.Hex(), "TestPassword123", recoveryCodes[1]); err != nil {
+		t.Fatalf("DisableMFA() error = %v", err)
+	}
+	if user.MFAEnabled || user.MFASecret != "" || len(user.MFARecoveryCodes) != 0 {
+		t.Errorf("DisableMFA() left MFA data: %+v", user)
+	}
+	if _, err := service.Login(ctx, user.Email, "TestPassword123"); err != nil {
+		t.Errorf("Login() after disabling MFA error = %v", err)
+	}
+}
+
+func TestAuthService_MFACodeAttemptsAreLimited(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+	setup, _ := service.BeginMFASetup(ctx, user.ID.Hex())
+	if _, err := service.EnableMFA(ctx, user.ID.Hex(), mfaCode(t, setup.Secret, 0)); err != nil {
+		t.Fatalf("EnableMFA() error = %v", err)
+	}
+
+	challenge := loginChallenge(t, service, user.Email, "TestPassword123")
+	for i := 0; i < loginDelayThreshold; i++ {
+		if _, err := service.VerifyMFA(ctx, challenge.Token, "000000"); err != ErrInvalidMFACode {
+			t.Fatalf("VerifyMFA() attempt %d error = %v, want %v", i+1, err, ErrInvalidMFACode)
+		}
+	}
+
+	// As tentativas de código contam para o bloqueio de login
+	if _, err := service.VerifyMFA(ctx, challenge.Token, mfaCode(t, setup.Secret, 1)); !errors.Is(err, ErrLoginBlocked) {
+		t.Errorf("VerifyMFA() after failures error = %v, want %v", err, ErrLoginBlocked)
+	}
+}
+
+func TestAuthService_MFAPolicy(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	settingsRepo := NewMockSettingsRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), settingsRepo, NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	admin := newTestUser(mockRepo, "admin@example.com", "admin")
+	other := newTestUser(mockRepo, "other-admin@example.com", "admin")
+	member := newTestUser(mockRepo, "member@example.com", "member")
+
+	session, err := service.Login(ctx, other.Email, "TestPassword123")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	required := true
+	settings, err := service.UpdateSecuritySettings(ctx, &models.UpdateSecuritySettingsRequest{RequireAdminMFA: &required}, admin.ID.Hex())
+	if err != nil || !settings.RequireAdminMFA || settings.UpdatedBy != admin.ID.Hex() {
+		t.Fatalf("UpdateSecuritySettings() = %+v, %v", settings, err)
+	}
+
+	// Membros não são afetados pela política
+	if _, err := service.Login(ctx, member.Email, "TestPassword123"); err != nil {
+		t.Errorf("Login() for member error = %v", err)
+	}
+
+	// Sessões de administradores sem MFA deixam de ser renovadas
+	if _, err := service.RefreshTokenWithRefreshToken(ctx, session.RefreshToken); err != ErrMFASetupRequired {
+		t.Errorf("refresh for admin without MFA error = %v, want %v", err, ErrMFASetupRequired)
+	}
+
+	// O administrador cadastra o autenticador durante o login
+	challenge := loginChallenge(t, service, admin.Email, "TestPassword123")
+	if !challenge.SetupRequired {
+		t.Fatal("challenge should require setup")
+	}
+	if _, err := service.VerifyMFA(ctx, challenge.Token, "000000"); err != ErrMFASetupNotStarted {
+		t.Errorf("VerifyMFA() before setup error = %v, want %v", err, ErrMFASetupNotStarted)
+	}
+
+	setup, err := service.BeginMFAChallengeSetup(ctx, challenge.Token)
+	if err != nil {
+		t.Fatalf("BeginMFAChallengeSetup() error = %v", err)
+	}
+
+	response, err := service.VerifyMFA(ctx, challenge.Token, mfaCode(t, setup.Secret, 0))
+	if err != nil {
+		t.Fatalf("VerifyMFA() error = %v", err)
+	}
+	if len(response.RecoveryCodes) != recoveryCodeCount || !admin.MFAEnabled {
+		t.Errorf("VerifyMFA() recovery codes = %d, enabled = %v", len(response.RecoveryCodes), admin.MFAEnabled)
+	}
+
+	// Com a política ativa, administradores não podem desativar o MFA
+	if err := service.DisableMFA(ctx, admin.ID.Hex(), "TestPassword123", response.RecoveryCodes[0]); err != ErrMFARequiredByPolicy {
+		t.Errorf("DisableMFA() error = %v, want %v", err, ErrMFARequiredByPolicy)
+	}
+}
+
+func TestAuthService_MFACodeConsumedOnce(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := NewMockUserRepository()
+	service := NewAuthService(mockRepo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen).(*authService)
+
+	user := newTestUser(mockRepo, "member@example.com", "member")
+	setup, _ := service.BeginMFASetup(ctx, user.ID.Hex())
+	recoveryCodes, err := service.EnableMFA(ctx, user.ID.Hex(), mfaCode(t, setup.Secret, 0))
+	if err != nil {
+		t.Fatalf("EnableMFA() error = %v", err)
+	}
+
+	// Duas requisições concorrentes leem o mesmo estado antes de consumir o código
+	concurrentReads := func() (*models.User, *models.User) {
+		first, second := *user, *user
+		first.MFARecoveryCodes = append([]string{}, user.MFARecoveryCodes...)
+		second.MFARecoveryCodes = append([]string{}, user.MFARecoveryCodes...)
+		return &first, &second
+	}
+
+	for name, code := range map[string]string{"totp": mfaCode(t, setup.Secret, 1), "recovery": recoveryCodes[0]} {
+		first, second := concurrentReads()
+		if ok, err := service.consumeMFACode(ctx, first, code, time.Now()); !ok || err != nil {
+			t.Fatalf("consumeMFACode(%s) first = %v, %v; want true", name, ok, err)
+		}
+		if ok, err := service.consumeMFACode(ctx, second, code, time.Now()); ok || err != nil {
+			t.Errorf("consumeMFACode(%s) second = %v, %v; want false", name, ok, err)
+		}
+	}
+
+	if len(user.MFARecoveryCodes) != recoveryCodeCount-1 {
+		t.Errorf("recovery codes = %d, want %d", len(user.MFARecoveryCodes), recoveryCodeCount-1)
+	}
+}
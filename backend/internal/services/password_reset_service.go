@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"ellp-volunter-platform/backend/internal/mailer"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidResetToken é retornado quando o token de redefinição é inválido, expirou ou já foi usado
+var ErrInvalidResetToken = errors.New("token de redefinição inválido ou expirado")
+
+// PasswordResetExpiration é a validade do token de redefinição de senha
+const PasswordResetExpiration = 30 * time.Minute
+
+// passwordResetSendTimeout limita a emissão e o envio de um email de redefinição
+const passwordResetSendTimeout = time.Minute
+
+// PasswordResetService define a interface para a recuperação de senha por email
+type PasswordResetService interface {
+	RequestReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+}
+
+// passwordResetService implementa PasswordResetService
+type passwordResetService struct {
+	repo        repositories.PasswordResetRepository
+	userRepo    repositories.UserRepository
+	sessionRepo repositories.SessionRepository
+	auditRepo   repositories.AuditRepository
+	mailer      mailer.Mailer
+	resetURL    string         // página do frontend que recebe o token
+	pending     sync.WaitGroup // envios em andamento
+}
+
+// NewPasswordResetService cria uma nova instância do serviço
+func NewPasswordResetService(repo repositories.PasswordResetRepository, userRepo repositories.UserRepository, sessionRepo repositories.SessionRepository, auditRepo repositories.AuditRepository, m mailer.Mailer, resetURL string) PasswordResetService {
+	return &passwordResetService{
+		repo:        repo,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		auditRepo:   auditRepo,
+		mailer:      m,
+		resetURL:    resetURL,
+	}
+}
+
+// RequestReset envia um token de redefinição para o email informado. Emails
+// desconhecidos ou de usuários inativos são ignorados sem erro, para não
+// revelar quais contas existem. Pelo mesmo motivo, o token é emitido e enviado
+// em segundo plano: a resposta leva o mesmo tempo com ou sem conta.
+func (s *passwordResetService) RequestReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	// O envio continua depois que a requisição termina
+	sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), passwordResetSendTimeout)
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer cancel()
+
+		// Falhas não são repassadas ao cliente pelo mesmo motivo
+		if err := s.sendReset(sendCtx, user); err != nil {
+			log.Printf("erro ao enviar email de redefinição de senha para %s: %v", user.Email, err)
+		}
+	}()
+
+	return nil
+}
+
+// sendReset invalida os tokens anteriores do usuário, grava um novo e o envia por email
+func (s *passwordResetService) sendReset(ctx context.Context, user *models.User) error {
+	if err := s.repo.InvalidateByUser(ctx, user.ID.Hex()); err != nil {
+		return err
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+
+	reset := models.NewPasswordReset(user.ID.Hex(), token, time.Now().Add(PasswordResetExpiration))
+	if err := s.repo.Create(ctx, reset); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, s.resetMessage(user, token))
+}
+
+// ResetPassword define a nova senha a partir do token recebido por email,
+// remove o bloqueio de login e encerra todas as sessões do usuário
+func (s *passwordResetService) ResetPassword(ctx context.Context, token string, password string) error {
+	if err := models.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	reset, err := s.repo.FindByTokenHash(ctx, models.HashToken(token))
+	if err != nil {
+		if err == repositories.ErrPasswordResetNotFound {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if reset.IsUsed() || reset.IsExpired() {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, reset.UserID)
+	if err != nil {
+		if err == repositories.ErrUserNotFound {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.repo.MarkUsed(ctx, reset.ID); err != nil {
+		if err == repositories.ErrPasswordResetAlreadyUsed {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// A senha é convertida em hash pelo repositório
+	if err := s.userRepo.SetPassword(ctx, reset.UserID, password); err != nil {
+		return err
+	}
+
+	// Quem comprovou acesso ao email não precisa esperar o fim do bloqueio
+	if err := s.userRepo.ClearLoginFailures(ctx, reset.UserID); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeByUser(ctx, reset.UserID); err != nil {
+		return err
+	}
+
+	// Não há usuário autenticado; o registro indica que a troca veio do token
+	// enviado por email. A senha não entra na auditoria.
+	after := models.AuditSnapshot(user.ToResponse())
+	after["via"] = "password_reset"
+	recordAudit(ctx, s.auditRepo, models.AuditActionChangePassword, models.AuditEntityUser, reset.UserID, user.ToResponse(), after)
+
+	return nil
+}
+
+// resetMessage monta o email com o link de redefinição
+func (s *passwordResetService) resetMessage(user *models.User, token string) mailer.Message {
+	link := s.resetURL + "?token=" + url.QueryEscape(token)
+
+	body := fmt.Sprintf(`Olá, %s.
+
+Recebemos um pedido para redefinir a senha da sua conta no ELLP.
+Para criar uma nova senha, acesse o link abaixo em até %d minutos:
+
+%s
+
+Se você não fez este pedido, ignore este email; sua senha continua a mesma.
+`, user.Name, int(PasswordResetExpiration.Minutes()), link)
+
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "Redefinição de senha - ELLP",
+		Body:    body,
+	}
+}
+
+// newResetToken gera um token aleatório de redefinição de senha
+func newResetToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
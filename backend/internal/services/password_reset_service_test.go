@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/mailer"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MockPasswordResetRepository é um mock do repositório de tokens de redefinição para testes
+type MockPasswordResetRepository struct {
+	resets map[primitive.ObjectID]*models.PasswordReset
+}
+
+func NewMockPasswordResetRepository() *MockPasswordResetRepository {
+	return &MockPasswordResetRepository{
+		resets: make(map[primitive.ObjectID]*models.PasswordReset),
+	}
+}
+
+func (m *MockPasswordResetRepository) Create(ctx context.Context, reset *models.PasswordReset) error {
+	reset.ID = primitive.NewObjectID()
+	m.resets[reset.ID] = reset
+	return nil
+}
+
+func (m *MockPasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error) {
+	for _, reset := range m.resets {
+		if reset.TokenHash == tokenHash {
+			return reset, nil
+		}
+	}
+	return nil, repositories.ErrPasswordResetNotFound
+}
+
+func (m *MockPasswordResetRepository) MarkUsed(ctx context.Context, id primitive.ObjectID) error {
+	reset, exists := m.resets[id]
+	if !exists || reset.IsUsed() {
+		return repositories.ErrPasswordResetAlreadyUsed
+	}
+	now := time.Now()
+	reset.UsedAt = &now
+	return nil
+}
+
+func (m *MockPasswordResetRepository) InvalidateByUser(ctx context.Context, userID string) error {
+	now := time.Now()
+	for _, reset := range m.resets {
+		if reset.UserID == userID && !reset.IsUsed() {
+			reset.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *MockPasswordResetRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// recordingMailer guarda as mensagens enviadas para inspeção nos testes
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+var resetLinkPattern = regexp.MustCompile(`\?token=(\S+)`)
+
+// resetTokenFrom extrai o token do link enviado no email
+func resetTokenFrom(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+	match := resetLinkPattern.FindStringSubmatch(msg.Body)
+	if match == nil {
+		t.Fatalf("email body %q has no reset link", msg.Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("QueryUnescape() error = %v", err)
+	}
+	return token
+}
+
+// requestReset pede a redefinição e aguarda o envio feito em segundo plano
+func requestReset(ctx context.Context, service PasswordResetService, email string) error {
+	err := service.RequestReset(ctx, email)
+	service.(*passwordResetService).pending.Wait()
+	return err
+}
+
+func TestPasswordResetService_RequestReset(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	resetRepo := NewMockPasswordResetRepository()
+	mail := &recordingMailer{}
+	service := NewPasswordResetService(resetRepo, userRepo, NewMockSessionRepository(), NewMockAuditRepository(), mail, "http://localhost:3000/reset-password")
+
+	user := newTestUser(userRepo, "member@example.com", "member")
+
+	// Emails desconhecidos não geram erro nem email
+	if err := requestReset(ctx, service, "unknown@example.com"); err != nil {
+		t.Errorf("RequestReset() unknown email error = %v", err)
+	}
+	if len(mail.sent) != 0 {
+		t.Fatalf("RequestReset() sent %d emails for unknown email", len(mail.sent))
+	}
+
+	if err := requestReset(ctx, service, user.Email); err != nil {
+		t.Fatalf("RequestReset() error = %v", err)
+	}
+	if len(mail.sent) != 1 || mail.sent[0].To != user.Email {
+		t.Fatalf("RequestReset() sent = %+v", mail.sent)
+	}
+
+	// O token não é gravado em texto puro
+	token := resetTokenFrom(t, mail.sent[0])
+	for _, reset := range resetRepo.resets {
+		if reset.TokenHash == token || reset.TokenHash != models.HashToken(token) {
+			t.Errorf("stored token hash = %v", reset.TokenHash)
+		}
+	}
+
+	// Um novo pedido invalida o token anterior
+	if err := requestReset(ctx, service, user.Email); err != nil {
+		t.Fatalf("RequestReset() error = %v", err)
+	}
+	if err := service.ResetPassword(ctx, token, "NewPassword123"); err != ErrInvalidResetToken {
+		t.Errorf("ResetPassword() with superseded token error = %v, want %v", err, ErrInvalidResetToken)
+	}
+
+	// Usuários inativos não recebem email
+	user.IsActive = false
+	if err := requestReset(ctx, service, user.Email); err != nil || len(mail.sent) != 2 {
+		t.Errorf("RequestReset() inactive user error = %v, sent = %d", err, len(mail.sent))
+	}
+}
+
+func TestPasswordResetService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	sessionRepo := NewMockSessionRepository()
+	resetRepo := NewMockPasswordResetRepository()
+	mail := &recordingMailer{}
+	auditRepo := NewMockAuditRepository()
+	service := NewPasswordResetService(resetRepo, userRepo, sessionRepo, auditRepo, mail, "http://localhost:3000/reset-password")
+	authService := NewAuthService(userRepo, sessionRepo, NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	user := newTestUser(userRepo, "member@example.com", "member")
+	login, err := authService.Login(ctx, user.Email, "TestPassword123")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if err := requestReset(ctx, service, user.Email); err != nil {
+		t.Fatalf("RequestReset() error = %v", err)
+	}
+	token := resetTokenFrom(t, mail.sent[0])
+
+	if err := service.ResetPassword(ctx, token, "weak"); err == nil {
+		t.Error("ResetPassword() with weak password should fail")
+	}
+	if err := service.ResetPassword(ctx, "wrong-token", "NewPassword123"); err != ErrInvalidResetToken {
+		t.Errorf("ResetPassword() with wrong token error = %v, want %v", err, ErrInvalidResetToken)
+	}
+
+	if err := service.ResetPassword(ctx, token, "NewPassword123"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	// A nova senha é gravada com hash e as sessões existentes são encerradas
+	if _, err := authService.Login(ctx, user.Email, "NewPassword123"); err != nil {
+		t.Errorf("Login() with new password error = %v", err)
+	}
+	if _, err := authService.RefreshTokenWithRefreshToken(ctx, login.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh after reset error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+
+	// A troca fica na auditoria sem usuário autenticado, marcada como feita pelo token
+	if len(auditRepo.entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(auditRepo.entries))
+	}
+	entry := auditRepo.entries[0]
+	if entry.Action != models.AuditActionChangePassword || entry.EntityID != user.ID.Hex() || entry.ActorID != "" || entry.After["via"] != "password_reset" {
+		t.Errorf("audit entry = %+v, want change_password via password_reset", entry)
+	}
+
+	// O token é de uso único
+	if err := service.ResetPassword(ctx, token, "OtherPassword123"); err != ErrInvalidResetToken {
+		t.Errorf("ResetPassword() reusing token error = %v, want %v", err, ErrInvalidResetToken)
+	}
+
+	// Tokens expirados são rejeitados
+	if err := requestReset(ctx, service, user.Email); err != nil {
+		t.Fatalf("RequestReset() error = %v", err)
+	}
+	expired := resetTokenFrom(t, mail.sent[1])
+	for _, reset := range resetRepo.resets {
+		reset.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	if err := service.ResetPassword(ctx, expired, "OtherPassword123"); err != ErrInvalidResetToken {
+		t.Errorf("ResetPassword() with expired token error = %v, want %v", err, ErrInvalidResetToken)
+	}
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNoLinkedVolunteer é retornado quando a conta não está vinculada a um voluntário
+	ErrNoLinkedVolunteer = errors.New("conta não está vinculada a um voluntário")
+	// ErrVolunteerInactive é retornado quando um voluntário inativo tenta se inscrever
+	ErrVolunteerInactive = errors.New("voluntário inativo não pode se inscrever em oficinas")
+	// ErrWorkshopNotOpen é retornado quando a oficina não aceita inscrições pelo portal
+	ErrWorkshopNotOpen = errors.New("oficina não está aberta para inscrições")
+)
+
+// PortalService define a interface do portal do voluntário. Todas as operações
+// recebem o ID do usuário autenticado e atuam apenas sobre o voluntário
+// vinculado a ele.
+type PortalService interface {
+	Profile(ctx context.Context, userID string) (*models.VolunteerResponse, error)
+	Hours(ctx context.Context, userID string) (*models.PortalHoursResponse, error)
+	Workshops(ctx context.Context, userID string, page, limit int) (*models.Page[*models.PortalWorkshopResponse], error)
+	OpenWorkshops(ctx context.Context, userID string, page, limit int) (*models.Page[*models.PortalWorkshopResponse], error)
+	Enroll(ctx context.Context, userID string, workshopID string) (models.EnrollmentStatus, error)
+	Unenroll(ctx context.Context, userID string, workshopID string) error
+	Certificates(ctx context.Context, userID string, page, limit int) (*models.Page[*models.Certificate], error)
+	Certificate(ctx context.Context, userID string) (*models.Certificate, []byte, error)
+}
+
+// portalService implementa PortalService
+type portalService struct {
+	userRepo           repositories.UserRepository
+	volunteerRepo      repositories.VolunteerRepository
+	workshopRepo       repositories.WorkshopRepository
+	attendanceRepo     repositories.AttendanceRepository
+	auditRepo          repositories.AuditRepository
+	certificateService CertificateService
+}
+
+// NewPortalService cria uma nova instância do serviço
+func NewPortalService(userRepo repositories.UserRepository, volunteerRepo repositories.VolunteerRepository, workshopRepo repositories.WorkshopRepository, attendanceRepo repositories.AttendanceRepository, auditRepo repositories.AuditRepository, certificateService CertificateService) PortalService {
+	return &portalService{
+		userRepo:           userRepo,
+		volunteerRepo:      volunteerRepo,
+		workshopRepo:       workshopRepo,
+		attendanceRepo:     attendanceRepo,
+		auditRepo:          auditRepo,
+		certificateService: certificateService,
+	}
+}
+
+// volunteer busca o voluntário vinculado à conta do usuário. O vínculo é lido
+// do banco, nunca da requisição, para que cada um veja apenas os próprios dados.
+func (s *portalService) volunteer(ctx context.Context, userID string) (*models.Volunteer, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.VolunteerID == "" {
+		return nil, ErrNoLinkedVolunteer
+	}
+
+	volunteer, err := s.volunteerRepo.FindByID(ctx, user.VolunteerID)
+	if err != nil {
+		if err == repositories.ErrVolunteerNotFound {
+			return nil, ErrNoLinkedVolunteer
+		}
+		return nil, err
+	}
+
+	return volunteer, nil
+}
+
+// Profile retorna o cadastro do voluntário com o total de horas registradas
+func (s *portalService) Profile(ctx context.Context, userID string) (*models.VolunteerResponse, error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := volunteer.ID.Hex()
+	totals, err := s.attendanceRepo.SumHoursByVolunteer(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	response := volunteer.ToResponse()
+	response.TotalHours = totals[id]
+	return &response, nil
+}
+
+// Hours retorna os registros de presença do voluntário e o total de horas
+func (s *portalService) Hours(ctx context.Context, userID string) (*models.PortalHoursResponse, error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.attendanceRepo.FindAll(ctx, repositories.AttendanceFilter{VolunteerID: volunteer.ID.Hex()})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.PortalHoursResponse{Records: make([]*models.AttendanceResponse, len(records))}
+	for i, attendance := range records {
+		record := attendance.ToResponse()
+		response.Records[i] = &record
+		response.TotalHours += attendance.Hours
+	}
+	response.TotalHours = models.RoundHours(response.TotalHours)
+
+	return response, nil
+}
+
+// Workshops busca uma página das oficinas em que o voluntário está inscrito ou
+// na fila de espera
+func (s *portalService) Workshops(ctx context.Context, userID string, page, limit int) (*models.Page[*models.PortalWorkshopResponse], error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listWorkshops(ctx, volunteer, repositories.WorkshopFilter{
+		Volunteer: volunteer.ID.Hex(),
+		Page:      page,
+		Limit:     limit,
+	})
+}
+
+// OpenWorkshops busca uma página das oficinas agendadas que ainda vão acontecer
+func (s *portalService) OpenWorkshops(ctx context.Context, userID string, page, limit int) (*models.Page[*models.PortalWorkshopResponse], error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return s.listWorkshops(ctx, volunteer, repositories.WorkshopFilter{
+		Status:   models.WorkshopStatusScheduled,
+		DateFrom: &now,
+		Page:     page,
+		Limit:    limit,
+	})
+}
+
+// listWorkshops busca uma página de oficinas e as converte para a visão do voluntário
+func (s *portalService) listWorkshops(ctx context.Context, volunteer *models.Volunteer, filter repositories.WorkshopFilter) (*models.Page[*models.PortalWorkshopResponse], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+
+	workshops, err := s.workshopRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.workshopRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.PortalWorkshopResponse, len(workshops))
+	for i, workshop := range workshops {
+		response := workshop.ToPortalResponse(volunteer.ID.Hex())
+		responses[i] = &response
+	}
+
+	return models.NewPage(responses, total, filter.Page, filter.Limit), nil
+}
+
+// Enroll inscreve o voluntário em uma oficina aberta, ou na fila de espera se
+// ela estiver lotada
+func (s *portalService) Enroll(ctx context.Context, userID string, workshopID string) (models.EnrollmentStatus, error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if !volunteer.IsActive {
+		return "", ErrVolunteerInactive
+	}
+
+	workshop, err := s.openWorkshop(ctx, workshopID)
+	if err != nil {
+		return "", err
+	}
+
+	status, err := enrollVolunteer(ctx, s.volunteerRepo, s.workshopRepo, volunteer.ID.Hex(), workshop)
+	if err != nil {
+		return "", err
+	}
+
+	recordEnrollment(ctx, s.auditRepo, volunteer.ID.Hex(), workshop.ID.Hex(), status)
+	return status, nil
+}
+
+// Unenroll cancela a inscrição do voluntário em uma oficina que ainda não aconteceu
+func (s *portalService) Unenroll(ctx context.Context, userID string, workshopID string) error {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	workshop, err := s.openWorkshop(ctx, workshopID)
+	if err != nil {
+		return err
+	}
+
+	if err := unenrollVolunteer(ctx, s.volunteerRepo, s.workshopRepo, volunteer.ID.Hex(), workshop.ID.Hex()); err != nil {
+		return err
+	}
+
+	recordEnrollment(ctx, s.auditRepo, volunteer.ID.Hex(), workshop.ID.Hex(), "")
+	return nil
+}
+
+// openWorkshop busca uma oficina que ainda aceita inscrições pelo portal
+func (s *portalService) openWorkshop(ctx context.Context, workshopID string) (*models.Workshop, error) {
+	workshop, err := s.workshopRepo.FindByID(ctx, workshopID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !workshop.IsOpenForEnrollment() {
+		return nil, ErrWorkshopNotOpen
+	}
+
+	return workshop, nil
+}
+
+// Certificates busca uma página dos certificados já emitidos para o voluntário
+func (s *portalService) Certificates(ctx context.Context, userID string, page, limit int) (*models.Page[*models.Certificate], error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.certificateService.GetByVolunteer(ctx, volunteer.ID.Hex(), page, limit)
+}
+
+// Certificate retorna o certificado de participação do próprio voluntário. Um
+// novo certificado só é emitido quando os dados mudaram desde o último.
+func (s *portalService) Certificate(ctx context.Context, userID string) (*models.Certificate, []byte, error) {
+	volunteer, err := s.volunteer(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.certificateService.Current(ctx, volunteer.ID.Hex(), userID)
+}
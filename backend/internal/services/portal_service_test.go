@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestPortalService_OwnDataOnly(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	certificateService, _ := newTestCertificateService(volunteerRepo, workshopRepo, attendanceRepo)
+	userService := NewUserService(userRepo, NewMockSessionRepository(), NewMockRoleRepository(), volunteerRepo, NewMockAuditRepository())
+	service := NewPortalService(userRepo, volunteerRepo, workshopRepo, attendanceRepo, NewMockAuditRepository(), certificateService)
+
+	user := newTestUser(userRepo, "voluntario@example.com", models.RoleVolunteer)
+	other := newTestUser(userRepo, "outro@example.com", models.RoleVolunteer)
+	volunteer := newTestVolunteer(volunteerRepo)
+	otherVolunteer := newTestVolunteer(volunteerRepo)
+
+	// Sem vínculo, o portal não expõe dados
+	if _, err := service.Profile(ctx, user.ID.Hex()); err != ErrNoLinkedVolunteer {
+		t.Errorf("Profile() without link error = %v, want %v", err, ErrNoLinkedVolunteer)
+	}
+
+	if _, err := userService.LinkVolunteer(ctx, user.ID.Hex(), volunteer.ID.Hex()); err != nil {
+		t.Fatalf("LinkVolunteer() error = %v", err)
+	}
+	if _, err := userService.LinkVolunteer(ctx, other.ID.Hex(), volunteer.ID.Hex()); err != ErrVolunteerAlreadyLinked {
+		t.Errorf("LinkVolunteer() duplicate error = %v, want %v", err, ErrVolunteerAlreadyLinked)
+	}
+	if _, err := userService.LinkVolunteer(ctx, other.ID.Hex(), otherVolunteer.ID.Hex()); err != nil {
+		t.Fatalf("LinkVolunteer() other error = %v", err)
+	}
+
+	profile, err := service.Profile(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if profile.ID != volunteer.ID {
+		t.Errorf("Profile() = %v, want %v", profile.ID, volunteer.ID)
+	}
+
+	// Horas de outros voluntários não aparecem
+	own := 3.0
+	foreign := 5.0
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: volunteer.ID.Hex(), Hours: own})
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: otherVolunteer.ID.Hex(), Hours: foreign})
+	hours, err := service.Hours(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("Hours() error = %v", err)
+	}
+	if hours.TotalHours != own || len(hours.Records) != 1 {
+		t.Errorf("Hours() = %v in %d records, want %v in 1", hours.TotalHours, len(hours.Records), own)
+	}
+
+	// Inscrição apenas em oficinas abertas
+	open := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	completed := newTestWorkshop(workshopRepo, models.WorkshopStatusCompleted)
+	past := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	past.Date = time.Now().AddDate(0, 0, -1)
+
+	openPage, err := service.OpenWorkshops(ctx, user.ID.Hex(), 0, 0)
+	if err != nil {
+		t.Fatalf("OpenWorkshops() error = %v", err)
+	}
+	openWorkshops := openPage.Items
+	if openPage.Total != 1 || len(openWorkshops) != 1 || openWorkshops[0].ID != open.ID || openWorkshops[0].Enrollment != "" {
+		t.Errorf("OpenWorkshops() = %+v, want only the open workshop", openWorkshops)
+	}
+
+	for _, workshop := range []*models.Workshop{completed, past} {
+		if _, err := service.Enroll(ctx, user.ID.Hex(), workshop.ID.Hex()); err != ErrWorkshopNotOpen {
+			t.Errorf("Enroll(%s) error = %v, want %v", workshop.Status, err, ErrWorkshopNotOpen)
+		}
+	}
+
+	status, err := service.Enroll(ctx, user.ID.Hex(), open.ID.Hex())
+	if err != nil || status != models.EnrollmentEnrolled {
+		t.Fatalf("Enroll() = %v, %v", status, err)
+	}
+	if len(volunteer.Workshops) != 1 || volunteer.Workshops[0] != open.ID.Hex() {
+		t.Errorf("volunteer.Workshops = %v, want %v", volunteer.Workshops, open.ID.Hex())
+	}
+
+	mine, err := service.Workshops(ctx, user.ID.Hex(), 0, 0)
+	if err != nil {
+		t.Fatalf("Workshops() error = %v", err)
+	}
+	if mine.Total != 1 || len(mine.Items) != 1 || mine.Items[0].Enrollment != models.EnrollmentEnrolled {
+		t.Errorf("Workshops() = %+v, want the enrolled workshop", mine)
+	}
+	if beyond, _ := service.Workshops(ctx, user.ID.Hex(), 2, 1); beyond.Total != 1 || len(beyond.Items) != 0 {
+		t.Errorf("Workshops() page 2 = %+v, want empty page of 1", beyond)
+	}
+	theirs, _ := service.Workshops(ctx, other.ID.Hex(), 0, 0)
+	if theirs.Total != 0 || len(theirs.Items) != 0 {
+		t.Errorf("Workshops() of other volunteer = %+v, want empty", theirs)
+	}
+
+	if err := service.Unenroll(ctx, user.ID.Hex(), open.ID.Hex()); err != nil {
+		t.Fatalf("Unenroll() error = %v", err)
+	}
+	if open.IsEnrolled(volunteer.ID.Hex()) || len(volunteer.Workshops) != 0 {
+		t.Error("Unenroll() didn't release the seat")
+	}
+
+	// O certificado é sempre do voluntário vinculado
+	certificate, data, err := service.Certificate(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("Certificate() error = %v", err)
+	}
+	if certificate.VolunteerID != volunteer.ID.Hex() || len(data) == 0 {
+		t.Errorf("Certificate() volunteer = %v, want %v", certificate.VolunteerID, volunteer.ID.Hex())
+	}
+	if mineCertificates, _ := service.Certificates(ctx, user.ID.Hex(), 0, 0); mineCertificates.Total != 1 || mineCertificates.Items[0].Code != certificate.Code {
+		t.Errorf("Certificates() = %+v, want the issued certificate", mineCertificates)
+	}
+
+	// Baixar de novo reaproveita o certificado enquanto os dados não mudam
+	again, data, err := service.Certificate(ctx, user.ID.Hex())
+	if err != nil || again.Code != certificate.Code || len(data) == 0 {
+		t.Errorf("Certificate() again = %v, %v; want the same certificate %s", again, err, certificate.Code)
+	}
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: volunteer.ID.Hex(), WorkshopID: open.ID.Hex(), Date: time.Now(), Hours: 2})
+	updated, _, err := service.Certificate(ctx, user.ID.Hex())
+	if err != nil || updated.Code == certificate.Code || updated.TotalHours != certificate.TotalHours+2 {
+		t.Errorf("Certificate() after new attendance = %+v, %v; want a new certificate with 2 more hours", updated, err)
+	}
+	if mineCertificates, _ := service.Certificates(ctx, user.ID.Hex(), 0, 0); mineCertificates.Total != 2 {
+		t.Errorf("Certificates() total = %d, want 2", mineCertificates.Total)
+	}
+
+	theirCertificates, _ := service.Certificates(ctx, other.ID.Hex(), 0, 0)
+	if theirCertificates.Total != 0 || len(theirCertificates.Items) != 0 {
+		t.Errorf("Certificates() of other volunteer = %d, want 0", theirCertificates.Total)
+	}
+}
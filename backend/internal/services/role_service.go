@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	// ErrSystemRole é retornado ao remover um papel padrão ou alterar as permissões do administrador
+	ErrSystemRole = errors.New("papéis padrão não podem ser removidos e as permissões do administrador não podem ser alteradas")
+	// ErrRoleInUse é retornado ao remover um papel ainda atribuído a usuários
+	ErrRoleInUse = errors.New("o papel ainda está atribuído a usuários")
+)
+
+// RoleService define a interface para o gerenciamento de papéis e permissões
+type RoleService interface {
+	List(ctx context.Context) ([]*models.Role, error)
+	GetByName(ctx context.Context, name string) (*models.Role, error)
+	Create(ctx context.Context, req *models.CreateRoleRequest) (*models.Role, error)
+	Update(ctx context.Context, name string, req *models.UpdateRoleRequest) (*models.Role, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// roleService implementa RoleService
+type roleService struct {
+	repo      repositories.RoleRepository
+	userRepo  repositories.UserRepository
+	auditRepo repositories.AuditRepository
+}
+
+// NewRoleService cria uma nova instância do serviço
+func NewRoleService(repo repositories.RoleRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) RoleService {
+	return &roleService{
+		repo:      repo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// List lista os papéis cadastrados
+func (s *roleService) List(ctx context.Context) ([]*models.Role, error) {
+	return s.repo.List(ctx)
+}
+
+// GetByName busca um papel pelo nome
+func (s *roleService) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	return s.repo.FindByName(ctx, name)
+}
+
+// Create cria um papel com as permissões informadas
+func (s *roleService) Create(ctx context.Context, req *models.CreateRoleRequest) (*models.Role, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if err := models.ValidateRoleName(name); err != nil {
+		return nil, err
+	}
+	if err := models.ValidatePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        name,
+		Description: req.Description,
+		Permissions: uniquePermissions(req.Permissions),
+	}
+
+	if err := s.repo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityRole, role.Name, nil, role)
+
+	return role, nil
+}
+
+// Update altera a descrição e as permissões de um papel. As permissões do
+// administrador são fixas para que o sistema não fique sem quem o administre.
+func (s *roleService) Update(ctx context.Context, name string, req *models.UpdateRoleRequest) (*models.Role, error) {
+	role, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	before := models.AuditSnapshot(role)
+
+	if req.Permissions != nil {
+		if role.Name == models.RoleAdmin {
+			return nil, ErrSystemRole
+		}
+		if err := models.ValidatePermissions(req.Permissions); err != nil {
+			return nil, err
+		}
+		role.Permissions = uniquePermissions(req.Permissions)
+	}
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityRole, role.Name, before, role)
+
+	return role, nil
+}
+
+// Delete remove um papel que não é padrão nem está atribuído a usuários
+func (s *roleService) Delete(ctx context.Context, name string) error {
+	role, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if role.System {
+		return ErrSystemRole
+	}
+
+	users, err := s.userRepo.Count(ctx, bson.M{"role": role.Name})
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := s.repo.Delete(ctx, role.Name); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionDelete, models.AuditEntityRole, role.Name, role, nil)
+	return nil
+}
+
+// ensureRole verifica se o papel existe antes de atribuí-lo a um usuário
+func ensureRole(ctx context.Context, repo repositories.RoleRepository, name string) error {
+	_, err := repo.FindByName(ctx, name)
+	return err
+}
+
+// uniquePermissions remove permissões repetidas mantendo a ordem
+func uniquePermissions(permissions []string) []string {
+	seen := make(map[string]bool, len(permissions))
+	unique := []string{}
+	for _, permission := range permissions {
+		if !seen[permission] {
+			seen[permission] = true
+			unique = append(unique, permission)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"testing"
+)
+
+// MockRoleRepository é um mock do repositório de papéis para testes,
+// iniciado com os papéis padrão
+type MockRoleRepository struct {
+	roles map[string]*models.Role
+}
+
+func NewMockRoleRepository() *MockRoleRepository {
+	m := &MockRoleRepository{roles: make(map[string]*models.Role)}
+	m.EnsureDefaults(context.Background())
+	return m
+}
+
+func (m *MockRoleRepository) Create(ctx context.Context, role *models.Role) error {
+	if _, exists := m.roles[role.Name]; exists {
+		return repositories.ErrRoleAlreadyExists
+	}
+	m.roles[role.Name] = role
+	return nil
+}
+
+func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	role, exists := m.roles[name]
+	if !exists {
+		return nil, repositories.ErrRoleNotFound
+	}
+	copied := *role
+	return &copied, nil
+}
+
+func (m *MockRoleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	roles := []*models.Role{}
+	for _, role := range m.roles {
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (m *MockRoleRepository) Update(ctx context.Context, role *models.Role) error {
+	if _, exists := m.roles[role.Name]; !exists {
+		return repositories.ErrRoleNotFound
+	}
+	m.roles[role.Name] = role
+	return nil
+}
+
+func (m *MockRoleRepository) Delete(ctx context.Context, name string) error {
+	if _, exists := m.roles[name]; !exists {
+		return repositories.ErrRoleNotFound
+	}
+	delete(m.roles, name)
+	return nil
+}
+
+func (m *MockRoleRepository) EnsureDefaults(ctx context.Context) error {
+	for _, role := range models.DefaultRoles() {
+		if _, exists := m.roles[role.Name]; !exists {
+			role := role
+			m.roles[role.Name] = &role
+		}
+	}
+	return nil
+}
+
+func TestRoleService_CreateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	service := NewRoleService(NewMockRoleRepository(), userRepo, NewMockAuditRepository())
+
+	// Permissões desconhecidas e nomes inválidos são rejeitados
+	if _, err := service.Create(ctx, &models.CreateRoleRequest{Name: "coordenador", Permissions: []string{"tudo"}}); !errors.Is(err, models.ErrUnknownPermission) {
+		t.Errorf("Create() unknown permission error = %v", err)
+	}
+	if _, err := service.Create(ctx, &models.CreateRoleRequest{Name: "Coordenador Geral", Permissions: []string{}}); err != models.ErrInvalidRoleName {
+		t.Errorf("Create() invalid name error = %v", err)
+	}
+
+	role, err := service.Create(ctx, &models.CreateRoleRequest{
+		Name:        "Coordenador",
+		Permissions: []string{models.PermVolunteersRead, models.PermWorkshopsManage, models.PermVolunteersRead},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if role.Name != "coordenador" || len(role.Permissions) != 2 {
+		t.Errorf("Create() = %+v, want normalized name and unique permissions", role)
+	}
+	if _, err := service.Create(ctx, &models.CreateRoleRequest{Name: "coordenador", Permissions: []string{}}); err != repositories.ErrRoleAlreadyExists {
+		t.Errorf("Create() duplicate error = %v", err)
+	}
+
+	updated, err := service.Update(ctx, "coordenador", &models.UpdateRoleRequest{Permissions: []string{models.PermVolunteersDelete}})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.HasPermission(models.PermVolunteersDelete) || updated.HasPermission(models.PermWorkshopsManage) {
+		t.Errorf("Update() permissions = %v", updated.Permissions)
+	}
+
+	// As permissões do administrador são fixas
+	if _, err := service.Update(ctx, models.RoleAdmin, &models.UpdateRoleRequest{Permissions: []string{}}); err != ErrSystemRole {
+		t.Errorf("Update(admin) error = %v, want %v", err, ErrSystemRole)
+	}
+
+	// Papéis padrão e papéis em uso não podem ser removidos
+	if err := service.Delete(ctx, models.RoleMember); err != ErrSystemRole {
+		t.Errorf("Delete(member) error = %v, want %v", err, ErrSystemRole)
+	}
+	user := newTestUser(userRepo, "coord@example.com", "coordenador")
+	if err := service.Delete(ctx, "coordenador"); err != ErrRoleInUse {
+		t.Errorf("Delete() in use error = %v, want %v", err, ErrRoleInUse)
+	}
+	user.Role = models.RoleMember
+	if err := service.Delete(ctx, "coordenador"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
+
+func TestUserService_ChangeRoleRequiresExistingRole(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	service := NewUserService(userRepo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	admin := newTestUser(userRepo, "admin@example.com", models.RoleAdmin)
+	member := newTestUser(userRepo, "member@example.com", models.RoleMember)
+
+	if _, err := service.ChangeRole(ctx, member.ID.Hex(), "inexistente", admin.ID.Hex()); err != repositories.ErrRoleNotFound {
+		t.Errorf("ChangeRole() error = %v, want %v", err, repositories.ErrRoleNotFound)
+	}
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	// ErrCannotModifySelf é retornado quando o administrador tenta rebaixar ou desativar a própria conta
+	ErrCannotModifySelf = errors.New("não é possível alterar o papel ou desativar a própria conta")
+	// ErrLastAdmin é retornado quando a operação deixaria o sistema sem administradores ativos
+	ErrLastAdmin = errors.New("o sistema precisa de pelo menos um administrador ativo")
+	// ErrVolunteerAlreadyLinked é retornado quando o voluntário já está vinculado a outra conta
+	ErrVolunteerAlreadyLinked = errors.New("voluntário já está vinculado a outra conta")
+)
+
+// UserFilter representa os filtros da listagem de usuários
+type UserFilter struct {
+	Search   string // busca por nome ou email
+	Role     string
+	IsActive *bool
+	Page     int
+	Limit    int
+}
+
+// UserService define a interface para o gerenciamento de usuários por administradores
+type UserService interface {
+	List(ctx context.Context, filter UserFilter) (*models.Page[models.UserResponse], error)
+	GetByID(ctx context.Context, id string) (*models.UserResponse, error)
+	ChangeRole(ctx context.Context, id string, role string, actorID string) (*models.UserResponse, error)
+	Deactivate(ctx context.Context, id string, actorID string) (*models.UserResponse, error)
+	Reactivate(ctx context.Context, id string) (*models.UserResponse, error)
+	Unlock(ctx context.Context, id string) (*models.UserResponse, error)
+	ResetMFA(ctx context.Context, id string) (*models.UserResponse, error)
+	ResetPassword(ctx context.Context, id string, password string) error
+	LinkVolunteer(ctx context.Context, id string, volunteerID string) (*models.UserResponse, error)
+	UnlinkVolunteer(ctx context.Context, id string) (*models.UserResponse, error)
+}
+
+// userService implementa UserService
+type userService struct {
+	repo          repositories.UserRepository
+	sessionRepo   repositories.SessionRepository
+	roleRepo      repositories.RoleRepository
+	volunteerRepo repositories.VolunteerRepository
+	auditRepo     repositories.AuditRepository
+}
+
+// NewUserService cria uma nova instância do serviço
+func NewUserService(repo repositories.UserRepository, sessionRepo repositories.SessionRepository, roleRepo repositories.RoleRepository, volunteerRepo repositories.VolunteerRepository, auditRepo repositories.AuditRepository) UserService {
+	return &userService{
+		repo:          repo,
+		sessionRepo:   sessionRepo,
+		roleRepo:      roleRepo,
+		volunteerRepo: volunteerRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// List busca usuários com filtros e paginação
+func (s *userService) List(ctx context.Context, filter UserFilter) (*models.Page[models.UserResponse], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+
+	// Construir filtro BSON
+	bsonFilter := bson.M{}
+	if filter.Search != "" {
+		// O termo é tratado como texto literal, não como expressão regular
+		pattern := bson.M{"$regex": regexp.QuoteMeta(filter.Search), "$options": "i"}
+		bsonFilter["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		}
+	}
+	if filter.Role != "" {
+		bsonFilter["role"] = filter.Role
+	}
+	if filter.IsActive != nil {
+		bsonFilter["is_active"] = *filter.IsActive
+	}
+
+	users, err := s.repo.List(ctx, bsonFilter, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.Count(ctx, bsonFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = user.ToResponse()
+	}
+
+	return models.NewPage(responses, total, filter.Page, filter.Limit), nil
+}
+
+// GetByID busca um usuário por ID
+func (s *userService) GetByID(ctx context.Context, id string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// ChangeRole altera o papel de um usuário
+func (s *userService) ChangeRole(ctx context.Context, id string, role string, actorID string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == role {
+		response := user.ToResponse()
+		return &response, nil
+	}
+
+	if err := ensureRole(ctx, s.roleRepo, role); err != nil {
+		return nil, err
+	}
+
+	if user.Role == models.RoleAdmin && id == actorID {
+		return nil, ErrCannotModifySelf
+	}
+
+	// Rebaixar um administrador exige que reste outro administrador ativo
+	before := user.ToResponse()
+	if err := s.repo.SetRole(ctx, id, role); err != nil {
+		return nil, lastAdminError(err)
+	}
+
+	return s.audited(ctx, models.AuditActionUpdate, id, before)
+}
+
+// Deactivate desativa um usuário e revoga suas sessões
+func (s *userService) Deactivate(ctx context.Context, id string, actorID string) (*models.UserResponse, error) {
+	if id == actorID {
+		return nil, ErrCannotModifySelf
+	}
+
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Desativar um administrador exige que reste outro administrador ativo
+	before := user.ToResponse()
+	if err := s.repo.SetActive(ctx, id, false); err != nil {
+		return nil, lastAdminError(err)
+	}
+
+	if err := s.sessionRepo.RevokeByUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionInactivate, id, before)
+}
+
+// Reactivate reativa um usuário desativado
+func (s *userService) Reactivate(ctx context.Context, id string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.ToResponse()
+	if err := s.repo.SetActive(ctx, id, true); err != nil {
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionReactivate, id, before)
+}
+
+// Unlock remove o bloqueio de login do usuário e zera o contador de falhas
+func (s *userService) Unlock(ctx context.Context, id string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.ToResponse()
+	if err := s.repo.ClearLoginFailures(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionUpdate, id, before)
+}
+
+// ResetMFA remove o segundo fator do usuário (ex.: perda do autenticador e dos
+// códigos de recuperação) e encerra suas sessões
+func (s *userService) ResetMFA(ctx context.Context, id string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.ToResponse()
+	clearMFA(user)
+	if err := s.repo.UpdateMFA(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.RevokeByUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionUpdate, id, before)
+}
+
+// ResetPassword define uma nova senha para o usuário, remove o bloqueio de
+// login e encerra suas sessões
+func (s *userService) ResetPassword(ctx context.Context, id string, password string) error {
+	if err := models.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// A senha é convertida em hash pelo repositório
+	before := user.ToResponse()
+	if err := s.repo.SetPassword(ctx, id, password); err != nil {
+		return err
+	}
+
+	if err := s.repo.ClearLoginFailures(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeByUser(ctx, id); err != nil {
+		return err
+	}
+
+	// A senha não aparece na auditoria, apenas o registro de que foi redefinida
+	_, err = s.audited(ctx, models.AuditActionUpdate, id, before)
+	return err
+}
+
+// LinkVolunteer vincula a conta do usuário a um voluntário, dando acesso aos
+// dados dele no portal. Cada voluntário pode ter apenas uma conta vinculada.
+func (s *userService) LinkVolunteer(ctx context.Context, id string, volunteerID string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	volunteer, err := s.volunteerRepo.FindByID(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.repo.FindByVolunteerID(ctx, volunteer.ID.Hex())
+	if err != nil && err != repositories.ErrUserNotFound {
+		return nil, err
+	}
+	if linked != nil && linked.ID != user.ID {
+		return nil, ErrVolunteerAlreadyLinked
+	}
+
+	before := user.ToResponse()
+	if err := s.repo.SetVolunteer(ctx, id, volunteer.ID.Hex()); err != nil {
+		// Outra conta pode ter sido vinculada depois da verificação acima
+		if err == repositories.ErrVolunteerAlreadyLinked {
+			return nil, ErrVolunteerAlreadyLinked
+		}
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionUpdate, id, before)
+}
+
+// UnlinkVolunteer desfaz o vínculo entre a conta do usuário e o voluntário
+func (s *userService) UnlinkVolunteer(ctx context.Context, id string) (*models.UserResponse, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := user.ToResponse()
+	if err := s.repo.SetVolunteer(ctx, id, ""); err != nil {
+		return nil, err
+	}
+
+	return s.audited(ctx, models.AuditActionUpdate, id, before)
+}
+
+// audited busca o usuário alterado, registra a operação na auditoria e retorna
+// o usuário atualizado. Antes e depois usam a resposta da API, sem segredos.
+func (s *userService) audited(ctx context.Context, action string, id string, before models.UserResponse) (*models.UserResponse, error) {
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, action, models.AuditEntityUser, id, before, response)
+	return response, nil
+}
+
+// lastAdminError traduz o erro do repositório ao remover o último administrador ativo
+func lastAdminError(err error) error {
+	if err == repositories.ErrLastActiveAdmin {
+		return ErrLastAdmin
+	}
+	return err
+}
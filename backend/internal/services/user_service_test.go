@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// newTestUser cria um usuário ativo no mock com o papel informado
+func newTestUser(repo *MockUserRepository, email, role string) *models.User {
+	user := &models.User{
+		Name:     "Test User",
+		Email:    email,
+		Password: "TestPassword123",
+		Role:     role,
+		IsActive: true,
+	}
+	repo.Create(context.Background(), user)
+	return user
+}
+
+func TestUserService_ChangeRole(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	service := NewUserService(userRepo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	admin := newTestUser(userRepo, "admin@example.com", "admin")
+	member := newTestUser(userRepo, "member@example.com", "member")
+
+	// O único administrador não pode ser rebaixado
+	if _, err := service.ChangeRole(ctx, admin.ID.Hex(), "member", member.ID.Hex()); err != ErrLastAdmin {
+		t.Errorf("demoting the last admin error = %v, want %v", err, ErrLastAdmin)
+	}
+
+	promoted, err := service.ChangeRole(ctx, member.ID.Hex(), "admin", admin.ID.Hex())
+	if err != nil {
+		t.Fatalf("ChangeRole() error = %v", err)
+	}
+	if promoted.Role != "admin" {
+		t.Errorf("ChangeRole() role = %v, want admin", promoted.Role)
+	}
+
+	// Administradores não rebaixam a si mesmos
+	if _, err := service.ChangeRole(ctx, admin.ID.Hex(), "member", admin.ID.Hex()); err != ErrCannotModifySelf {
+		t.Errorf("self demotion error = %v, want %v", err, ErrCannotModifySelf)
+	}
+
+	if _, err := service.ChangeRole(ctx, admin.ID.Hex(), "member", member.ID.Hex()); err != nil {
+		t.Errorf("ChangeRole() with another admin error = %v", err)
+	}
+
+	if _, err := service.ChangeRole(ctx, "inexistente", "admin", admin.ID.Hex()); err != repositories.ErrUserNotFound {
+		t.Errorf("ChangeRole() unknown user error = %v, want %v", err, repositories.ErrUserNotFound)
+	}
+}
+
+func TestUserService_DeactivateAndResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	sessionRepo := NewMockSessionRepository()
+	service := NewUserService(userRepo, sessionRepo, NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+	authService := NewAuthService(userRepo, sessionRepo, NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+
+	admin := newTestUser(userRepo, "admin@example.com", "admin")
+	member := newTestUser(userRepo, "member@example.com", "member")
+
+	if _, err := service.Deactivate(ctx, admin.ID.Hex(), admin.ID.Hex()); err != ErrCannotModifySelf {
+		t.Errorf("self deactivation error = %v, want %v", err, ErrCannotModifySelf)
+	}
+
+	// Desativar encerra as sessões abertas
+	session, _ := authService.Login(ctx, "member@example.com", "TestPassword123")
+	deactivated, err := service.Deactivate(ctx, member.ID.Hex(), admin.ID.Hex())
+	if err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+	if deactivated.IsActive {
+		t.Error("Deactivate() should mark the user as inactive")
+	}
+	if _, err := authService.RefreshTokenWithRefreshToken(ctx, session.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh after deactivation error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+
+	if _, err := service.Reactivate(ctx, member.ID.Hex()); err != nil {
+		t.Fatalf("Reactivate() error = %v", err)
+	}
+
+	if err := service.ResetPassword(ctx, member.ID.Hex(), "weak"); err == nil {
+		t.Error("ResetPassword() should reject a weak password")
+	}
+
+	session, _ = authService.Login(ctx, "member@example.com", "TestPassword123")
+	if err := service.ResetPassword(ctx, member.ID.Hex(), "NewPassword456"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+	if _, err := authService.RefreshTokenWithRefreshToken(ctx, session.RefreshToken); err != ErrInvalidRefreshToken {
+		t.Errorf("refresh after password reset error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if _, err := authService.Login(ctx, "member@example.com", "NewPassword456"); err != nil {
+		t.Errorf("Login() with new password error = %v", err)
+	}
+}
+
+// concurrentUserRepository simula uma operação concorrente entre a leitura e
+// a gravação: FindByID entrega uma cópia e em seguida executa a outra operação
+type concurrentUserRepository struct {
+	*MockUserRepository
+	concurrent func()
+}
+
+func (r *concurrentUserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
+	stored, err := r.MockUserRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	read := *stored
+	if r.concurrent != nil {
+		concurrent := r.concurrent
+		r.concurrent = nil
+		concurrent()
+	}
+	return &read, nil
+}
+
+// staleLinkRepository não enxerga o vínculo feito por outra requisição entre
+// a verificação e a gravação
+type staleLinkRepository struct {
+	*MockUserRepository
+}
+
+func (r *staleLinkRepository) FindByVolunteerID(ctx context.Context, volunteerID string) (*models.User, error) {
+	return nil, repositories.ErrUserNotFound
+}
+
+func TestUserService_ConcurrentUpdates(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	repo := &concurrentUserRepository{MockUserRepository: userRepo}
+	service := NewUserService(repo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+	authService := NewAuthService(repo, NewMockSessionRepository(), NewMockInviteRepository(), NewMockSettingsRepository(), NewMockRoleRepository(), NewMockAuditRepository(), RegistrationOpen)
+	other := NewUserService(userRepo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	admin := newTestUser(userRepo, "admin@example.com", models.RoleAdmin)
+	second := newTestUser(userRepo, "second@example.com", models.RoleAdmin)
+	member := newTestUser(userRepo, "member@example.com", models.RoleMember)
+
+	// Alterar o perfil durante a desativação não reativa a conta
+	repo.concurrent = func() { other.Deactivate(ctx, member.ID.Hex(), admin.ID.Hex()) }
+	if _, err := authService.UpdateProfile(ctx, member.ID.Hex(), &models.UpdateProfileRequest{Name: "Novo Nome"}); err != nil {
+		t.Fatalf("UpdateProfile() error = %v", err)
+	}
+	if member.IsActive || member.Name != "Novo Nome" {
+		t.Errorf("after concurrent deactivation: active = %v, name = %q; want inactive with new name", member.IsActive, member.Name)
+	}
+
+	// Trocar a senha durante a mudança de papel não desfaz o novo papel
+	other.Reactivate(ctx, member.ID.Hex())
+	repo.concurrent = func() { other.ChangeRole(ctx, member.ID.Hex(), models.RoleCoordinator, admin.ID.Hex()) }
+	if _, err := authService.ChangePassword(ctx, member.ID.Hex(), "TestPassword123", "NewPassword123"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	if member.Role != models.RoleCoordinator {
+		t.Errorf("role after concurrent change = %q, want %q", member.Role, models.RoleCoordinator)
+	}
+
+	// Dois administradores que se rebaixam ao mesmo tempo não deixam o sistema sem administrador
+	repo.concurrent = func() { other.Deactivate(ctx, admin.ID.Hex(), second.ID.Hex()) }
+	if _, err := service.ChangeRole(ctx, second.ID.Hex(), models.RoleMember, admin.ID.Hex()); err != ErrLastAdmin {
+		t.Errorf("concurrent demotion error = %v, want %v", err, ErrLastAdmin)
+	}
+	if admins, _ := userRepo.Count(ctx, bson.M{"role": models.RoleAdmin, "is_active": true}); admins != 1 {
+		t.Errorf("active admins = %d, want 1", admins)
+	}
+	// Dois vínculos simultâneos ao mesmo voluntário esbarram no índice único
+	volunteerRepo := NewMockVolunteerRepository()
+	volunteer := newTestVolunteer(volunteerRepo)
+	linker := NewUserService(&staleLinkRepository{userRepo}, NewMockSessionRepository(), NewMockRoleRepository(), volunteerRepo, NewMockAuditRepository())
+	userRepo.SetVolunteer(ctx, admin.ID.Hex(), volunteer.ID.Hex())
+	if _, err := linker.LinkVolunteer(ctx, member.ID.Hex(), volunteer.ID.Hex()); err != ErrVolunteerAlreadyLinked {
+		t.Errorf("concurrent link error = %v, want %v", err, ErrVolunteerAlreadyLinked)
+	}
+	if member.VolunteerID != "" {
+		t.Errorf("member.VolunteerID = %q, want empty", member.VolunteerID)
+	}
+}
+
+func TestUserService_List(t *testing.T) {
+	ctx := context.Background()
+	userRepo := NewMockUserRepository()
+	service := NewUserService(userRepo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	newTestUser(userRepo, "admin@example.com", "admin")
+	newTestUser(userRepo, "member@example.com", "member")
+	newTestUser(userRepo, "other@example.com", "member")
+
+	list, err := service.List(ctx, UserFilter{Role: "member"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if list.Total != 2 || len(list.Items) != 2 {
+		t.Errorf("List() total = %d, items = %d, want 2", list.Total, len(list.Items))
+	}
+	if list.Page != models.DefaultPage || list.Limit != models.DefaultLimit {
+		t.Errorf("List() page = %d, limit = %d, want defaults", list.Page, list.Limit)
+	}
+}
+
+func TestUserService_Unlock(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockUserRepository()
+	service := NewUserService(repo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	user := newTestUser(repo, "member@example.com", "member")
+	until := time.Now().Add(time.Hour)
+	user.FailedLoginAttempts = maxLoginFailures
+	user.LockedUntil = &until
+
+	response, err := service.Unlock(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+	if response.FailedLoginAttempts != 0 || response.LockedUntil != nil {
+		t.Errorf("Unlock() = %+v, want cleared lockout", response)
+	}
+
+	if _, err := service.Unlock(ctx, "inexistente"); err != repositories.ErrUserNotFound {
+		t.Errorf("Unlock() unknown user error = %v, want %v", err, repositories.ErrUserNotFound)
+	}
+}
+
+func TestUserService_ResetMFA(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockUserRepository()
+	service := NewUserService(repo, NewMockSessionRepository(), NewMockRoleRepository(), NewMockVolunteerRepository(), NewMockAuditRepository())
+
+	user := newTestUser(repo, "admin@example.com", "admin")
+	user.MFAEnabled = true
+	user.MFASecret = "JBSWY3DPEHPK3PXP"
+	user.MFARecoveryCodes = []string{"hash"}
+
+	response, err := service.ResetMFA(ctx, user.ID.Hex())
+	if err != nil {
+		t.Fatalf("ResetMFA() error = %v", err)
+	}
+	if response.MFAEnabled {
+		t.Error("ResetMFA() should disable MFA")
+	}
+
+	stored, _ := repo.FindByID(ctx, user.ID.Hex())
+	if stored.MFASecret != "" || len(stored.MFARecoveryCodes) != 0 {
+		t.Errorf("ResetMFA() should clear the secret and recovery codes, got %+v", stored)
+	}
+}
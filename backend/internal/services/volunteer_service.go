@@ -5,33 +5,90 @@ import (
 	"ellp-volunter-platform/backend/internal/models"
 	"ellp-volunter-platform/backend/internal/repositories"
 	"errors"
+	"log"
 	"time"
 )
 
+var (
+	// ErrVolunteerEmailTaken é retornado quando o email já pertence a outro voluntário
+	ErrVolunteerEmailTaken = errors.New("já existe um voluntário com este email")
+	// ErrVolunteerEmailInTrash é retornado quando o email pertence a um voluntário da lixeira
+	ErrVolunteerEmailInTrash = errors.New("já existe um voluntário com este email na lixeira; restaure-o em vez de criar outro")
+	// ErrVolunteerNotInTrash é retornado ao excluir definitivamente um voluntário fora da lixeira
+	ErrVolunteerNotInTrash = errors.New("voluntário precisa estar na lixeira para ser excluído definitivamente")
+)
+
 // VolunteerService define a interface para o serviço de voluntários
 type VolunteerService interface {
 	Create(ctx context.Context, req models.CreateVolunteerRequest) (*models.VolunteerResponse, error)
 	GetByID(ctx context.Context, id string) (*models.VolunteerResponse, error)
-	GetAll(ctx context.Context, filter repositories.VolunteerFilter) ([]*models.VolunteerResponse, error)
+	GetAll(ctx context.Context, filter repositories.VolunteerFilter) (*models.Page[*models.VolunteerResponse], error)
 	Update(ctx context.Context, id string, req models.UpdateVolunteerRequest) (*models.VolunteerResponse, error)
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, deletedBy string) error
+	Restore(ctx context.Context, id string) (*models.VolunteerResponse, error)
+	Purge(ctx context.Context, id string) error
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int, error)
 	Inactivate(ctx context.Context, id string, req models.InactivateVolunteerRequest) (*models.VolunteerResponse, error)
-	AddWorkshop(ctx context.Context, volunteerID string, workshopID string) error
+	Reactivate(ctx context.Context, id string, req models.ReactivateVolunteerRequest) (*models.VolunteerResponse, error)
+	History(ctx context.Context, id string, page, limit int) (*models.Page[*models.VolunteerRevision], error)
+	GetAsOf(ctx context.Context, id string, at time.Time) (*models.VolunteerResponse, error)
+	AddWorkshop(ctx context.Context, volunteerID string, workshopID string) (models.EnrollmentStatus, error)
 	RemoveWorkshop(ctx context.Context, volunteerID string, workshopID string) error
 }
 
 // volunteerService implementa VolunteerService
 type volunteerService struct {
-	repo repositories.VolunteerRepository
+	repo           repositories.VolunteerRepository
+	revisionRepo   repositories.VolunteerRevisionRepository
+	workshopRepo   repositories.WorkshopRepository
+	attendanceRepo repositories.AttendanceRepository
+	userRepo       repositories.UserRepository
+	auditRepo      repositories.AuditRepository
 }
 
 // NewVolunteerService cria uma nova instância do serviço
-func NewVolunteerService(repo repositories.VolunteerRepository) VolunteerService {
+func NewVolunteerService(repo repositories.VolunteerRepository, revisionRepo repositories.VolunteerRevisionRepository, workshopRepo repositories.WorkshopRepository, attendanceRepo repositories.AttendanceRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) VolunteerService {
 	return &volunteerService{
-		repo: repo,
+		repo:           repo,
+		revisionRepo:   revisionRepo,
+		workshopRepo:   workshopRepo,
+		attendanceRepo: attendanceRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
 	}
 }
 
+// toResponses converte voluntários em respostas preenchendo o total de horas registradas
+func (s *volunteerService) toResponses(ctx context.Context, volunteers ...*models.Volunteer) ([]*models.VolunteerResponse, error) {
+	ids := make([]string, len(volunteers))
+	for i, volunteer := range volunteers {
+		ids[i] = volunteer.ID.Hex()
+	}
+
+	totals, err := s.attendanceRepo.SumHoursByVolunteer(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.VolunteerResponse, len(volunteers))
+	for i, volunteer := range volunteers {
+		response := volunteer.ToResponse()
+		response.TotalHours = totals[ids[i]]
+		responses[i] = &response
+	}
+
+	return responses, nil
+}
+
+// toResponse converte um único voluntário em resposta com o total de horas
+func (s *volunteerService) toResponse(ctx context.Context, volunteer *models.Volunteer) (*models.VolunteerResponse, error) {
+	responses, err := s.toResponses(ctx, volunteer)
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
 // Create cria um novo voluntário
 func (s *volunteerService) Create(ctx context.Context, req models.CreateVolunteerRequest) (*models.VolunteerResponse, error) {
 	// Verificar se já existe voluntário com o mesmo email
@@ -40,7 +97,7 @@ func (s *volunteerService) Create(ctx context.Context, req models.CreateVoluntee
 		return nil, err
 	}
 	if existingVolunteer != nil {
-		return nil, errors.New("já existe um voluntário com este email")
+		return nil, emailTakenError(existingVolunteer)
 	}
 
 	// Criar novo voluntário
@@ -56,6 +113,9 @@ func (s *volunteerService) Create(ctx context.Context, req models.CreateVoluntee
 		return nil, err
 	}
 
+	s.recordRevision(ctx, volunteer)
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityVolunteer, volunteer.ID.Hex(), nil, volunteer)
+
 	response := volunteer.ToResponse()
 	return &response, nil
 }
@@ -67,24 +127,50 @@ func (s *volunteerService) GetByID(ctx context.Context, id string) (*models.Volu
 		return nil, err
 	}
 
-	response := volunteer.ToResponse()
-	return &response, nil
+	return s.toResponse(ctx, volunteer)
 }
 
-// GetAll busca todos os voluntários com filtros
-func (s *volunteerService) GetAll(ctx context.Context, filter repositories.VolunteerFilter) ([]*models.VolunteerResponse, error) {
+// GetAll busca uma página de voluntários com filtros. Com filter.After, a
+// página é a seguinte ao cursor em vez da indicada por filter.Page. Em ambos
+// os modos a resposta traz o cursor do último item quando há mais registros.
+func (s *volunteerService) GetAll(ctx context.Context, filter repositories.VolunteerFilter) (*models.Page[*models.VolunteerResponse], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+	limit := filter.Limit
+	if filter.After != nil {
+		// Um registro a mais indica se existe uma próxima página
+		filter.Page = 0
+		filter.Limit = limit + 1
+	}
+
 	volunteers, err := s.repo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	responses := make([]*models.VolunteerResponse, len(volunteers))
-	for i, volunteer := range volunteers {
-		response := volunteer.ToResponse()
-		responses[i] = &response
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	return responses, nil
+	hasMore := int64(filter.Page*limit) < total
+	if filter.After != nil {
+		hasMore = len(volunteers) > limit
+		if hasMore {
+			volunteers = volunteers[:limit]
+		}
+	}
+
+	responses, err := s.toResponses(ctx, volunteers...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := models.NewPage(responses, total, filter.Page, limit)
+	if hasMore && len(volunteers) > 0 {
+		last := volunteers[len(volunteers)-1]
+		page.NextCursor = models.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+	return page, nil
 }
 
 // Update atualiza um voluntário
@@ -102,10 +188,13 @@ func (s *volunteerService) Update(ctx context.Context, id string, req models.Upd
 			return nil, err
 		}
 		if existingVolunteer != nil && existingVolunteer.ID != volunteer.ID {
-			return nil, errors.New("já existe um voluntário com este email")
+			return nil, emailTakenError(existingVolunteer)
 		}
 	}
 
+	before := models.AuditSnapshot(volunteer)
+	s.ensureRevision(ctx, volunteer)
+
 	// Atualizar campos se fornecidos
 	if req.Name != "" {
 		volunteer.Name = req.Name
@@ -126,7 +215,7 @@ func (s *volunteerService) Update(ctx context.Context, id string, req models.Upd
 		volunteer.RA = req.RA
 	}
 	if !req.EntryDate.IsZero() {
-		volunteer.EntryDate = req.EntryDate
+		volunteer.SetEntryDate(req.EntryDate)
 	}
 
 	volunteer.UpdatedAt = time.Now()
@@ -141,35 +230,203 @@ func (s *volunteerService) Update(ctx context.Context, id string, req models.Upd
 		return nil, err
 	}
 
-	response := volunteer.ToResponse()
-	return &response, nil
+	s.recordRevision(ctx, volunteer)
+	recordAudit(ctx, s.auditRepo, models.AuditActionUpdate, models.AuditEntityVolunteer, id, before, volunteer)
+
+	return s.toResponse(ctx, volunteer)
 }
 
-// Delete deleta um voluntário
-func (s *volunteerService) Delete(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+// emailTakenError indica se o email em uso é de um voluntário da lixeira
+func emailTakenError(existing *models.Volunteer) error {
+	if existing.IsDeleted() {
+		return ErrVolunteerEmailInTrash
+	}
+	return ErrVolunteerEmailTaken
 }
 
-// Inactivate inativa um voluntário
+// Delete move um voluntário para a lixeira. Ele deixa as oficinas que ainda
+// vão acontecer, liberando as vagas, mas mantém o histórico das já realizadas.
+func (s *volunteerService) Delete(ctx context.Context, id string, deletedBy string) error {
+	volunteer, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	before := models.AuditSnapshot(volunteer)
+	s.ensureRevision(ctx, volunteer)
+
+	if err := s.releaseUpcomingWorkshops(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id, deletedBy); err != nil {
+		return err
+	}
+
+	volunteer, err = s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.recordRevision(ctx, volunteer)
+	recordAudit(ctx, s.auditRepo, models.AuditActionDelete, models.AuditEntityVolunteer, id, before, volunteer)
+	return nil
+}
+
+// releaseUpcomingWorkshops cancela as inscrições do voluntário nas oficinas
+// agendadas que ainda não aconteceram
+func (s *volunteerService) releaseUpcomingWorkshops(ctx context.Context, id string) error {
+	now := time.Now()
+	workshops, err := s.workshopRepo.FindAll(ctx, repositories.WorkshopFilter{
+		Status:    models.WorkshopStatusScheduled,
+		DateFrom:  &now,
+		Volunteer: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, workshop := range workshops {
+		if err := unenrollVolunteer(ctx, s.repo, s.workshopRepo, id, workshop.ID.Hex()); err != nil {
+			return err
+		}
+		recordEnrollment(ctx, s.auditRepo, id, workshop.ID.Hex(), "")
+	}
+
+	return nil
+}
+
+// Restore retira um voluntário da lixeira. As inscrições canceladas ao movê-lo
+// para a lixeira não são refeitas.
+func (s *volunteerService) Restore(ctx context.Context, id string) (*models.VolunteerResponse, error) {
+	volunteer, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := models.AuditSnapshot(volunteer)
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	volunteer, err = s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordRevision(ctx, volunteer)
+	recordAudit(ctx, s.auditRepo, models.AuditActionRestore, models.AuditEntityVolunteer, id, before, volunteer)
+
+	return s.toResponse(ctx, volunteer)
+}
+
+// Purge exclui definitivamente um voluntário da lixeira
+func (s *volunteerService) Purge(ctx context.Context, id string) error {
+	volunteer, err := s.repo.FindDeletedByID(ctx, id)
+	if err != nil {
+		if err == repositories.ErrVolunteerNotFound {
+			if _, findErr := s.repo.FindByID(ctx, id); findErr == nil {
+				return ErrVolunteerNotInTrash
+			}
+		}
+		return err
+	}
+
+	return s.purge(ctx, volunteer)
+}
+
+// PurgeTrash exclui definitivamente os voluntários que estão na lixeira desde
+// antes da data informada e retorna quantos foram excluídos
+func (s *volunteerService) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int, error) {
+	volunteers, err := s.repo.FindDeletedBefore(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, volunteer := range volunteers {
+		if err := s.purge(ctx, volunteer); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purge exclui definitivamente o voluntário e todas as referências a ele: o
+// histórico de versões, as vagas e filas de espera das oficinas, o vínculo com
+// a conta de usuário e os registros de presença. As presenças são excluídas,
+// e não mantidas, porque sem o voluntário não podem ser atribuídas a ninguém e
+// a exclusão definitiva deve remover os dados pessoais. Os certificados já
+// emitidos são mantidos para que a verificação pública continue funcionando.
+// As referências são removidas antes do cadastro, para que uma falha no meio
+// do caminho possa ser repetida a partir da lixeira.
+func (s *volunteerService) purge(ctx context.Context, volunteer *models.Volunteer) error {
+	id := volunteer.ID.Hex()
+
+	if _, err := s.workshopRepo.RemoveVolunteerFromAll(ctx, id); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByVolunteerID(ctx, id)
+	if err != nil && err != repositories.ErrUserNotFound {
+		return err
+	}
+	if user != nil {
+		if err := s.userRepo.SetVolunteer(ctx, user.ID.Hex(), ""); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.attendanceRepo.DeleteByVolunteer(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.revisionRepo.DeleteByVolunteer(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionPurge, models.AuditEntityVolunteer, id, volunteer, nil)
+	return nil
+}
+
+// Inactivate inativa um voluntário, encerrando o período de atuação em andamento
 func (s *volunteerService) Inactivate(ctx context.Context, id string, req models.InactivateVolunteerRequest) (*models.VolunteerResponse, error) {
+	return s.changeServicePeriods(ctx, id, models.AuditActionInactivate, func(volunteer *models.Volunteer) error {
+		return volunteer.EndServicePeriod(req.ExitDate)
+	})
+}
+
+// Reactivate reativa um voluntário inativo, abrindo um novo período de atuação
+func (s *volunteerService) Reactivate(ctx context.Context, id string, req models.ReactivateVolunteerRequest) (*models.VolunteerResponse, error) {
+	return s.changeServicePeriods(ctx, id, models.AuditActionReactivate, func(volunteer *models.Volunteer) error {
+		return volunteer.StartServicePeriod(req.EntryDate)
+	})
+}
+
+// changeServicePeriods aplica a alteração aos períodos de atuação do voluntário
+// e grava os períodos com a nova situação
+func (s *volunteerService) changeServicePeriods(ctx context.Context, id string, action string, change func(*models.Volunteer) error) (*models.VolunteerResponse, error) {
 	// Buscar voluntário
 	volunteer, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verificar se já está inativo
-	if !volunteer.IsActive {
-		return nil, errors.New("voluntário já está inativo")
-	}
+	before := models.AuditSnapshot(volunteer)
+	s.ensureRevision(ctx, volunteer)
 
-	// Validar data de saída
-	if req.ExitDate.Before(volunteer.EntryDate) {
-		return nil, errors.New("data de saída deve ser posterior à data de entrada")
+	if err := change(volunteer); err != nil {
+		return nil, err
 	}
 
-	// Inativar
-	if err := s.repo.Inactivate(ctx, id, req.ExitDate); err != nil {
+	if err := s.repo.UpdateServicePeriods(ctx, id, volunteer.Periods); err != nil {
 		return nil, err
 	}
 
@@ -179,16 +436,224 @@ func (s *volunteerService) Inactivate(ctx context.Context, id string, req models
 		return nil, err
 	}
 
+	s.recordRevision(ctx, volunteer)
+	recordAudit(ctx, s.auditRepo, action, models.AuditEntityVolunteer, id, before, volunteer)
+
+	return s.toResponse(ctx, volunteer)
+}
+
+// History busca uma página das versões do cadastro do voluntário, da mais
+// recente à mais antiga
+func (s *volunteerService) History(ctx context.Context, id string, page, limit int) (*models.Page[*models.VolunteerRevision], error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	page, limit = models.NormalizePagination(page, limit)
+
+	revisions, err := s.revisionRepo.FindByVolunteer(ctx, id, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.revisionRepo.CountByVolunteer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(revisions, total, page, limit), nil
+}
+
+// GetAsOf retorna o voluntário como estava cadastrado na data informada, com
+// as horas das sessões realizadas até essa data. As inscrições em oficinas não
+// geram versões, então workshops fica de fora da consulta por data.
+func (s *volunteerService) GetAsOf(ctx context.Context, id string, at time.Time) (*models.VolunteerResponse, error) {
+	volunteer, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionRepo.FindAsOf(ctx, id, at)
+	if err == repositories.ErrVolunteerRevisionNotFound {
+		// Voluntários cadastrados antes do histórico valem como estão desde a última alteração
+		if _, latestErr := s.revisionRepo.FindLatest(ctx, id); latestErr == repositories.ErrVolunteerRevisionNotFound && !at.Before(volunteer.UpdatedAt) {
+			revision, err = models.NewVolunteerRevision(volunteer, 0, ""), nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	volunteer = &revision.Volunteer
+
+	records, err := s.attendanceRepo.FindAll(ctx, repositories.AttendanceFilter{VolunteerID: id, DateTo: &at})
+	if err != nil {
+		return nil, err
+	}
+
 	response := volunteer.ToResponse()
+	response.Workshops = nil
+	for _, attendance := range records {
+		response.TotalHours += attendance.Hours
+	}
+	response.TotalHours = models.RoundHours(response.TotalHours)
+
 	return &response, nil
 }
 
-// AddWorkshop adiciona uma oficina ao histórico do voluntário
-func (s *volunteerService) AddWorkshop(ctx context.Context, volunteerID string, workshopID string) error {
-	return s.repo.AddWorkshop(ctx, volunteerID, workshopID)
+// ensureRevision grava o estado atual como primeira versão de um voluntário
+// cadastrado antes do histórico existir, para que a alteração seguinte não
+// apague o valor anterior
+func (s *volunteerService) ensureRevision(ctx context.Context, volunteer *models.Volunteer) {
+	_, err := s.revisionRepo.FindLatest(ctx, volunteer.ID.Hex())
+	if err != repositories.ErrVolunteerRevisionNotFound {
+		return
+	}
+
+	// Se outra alteração gravou a primeira versão antes, não há o que fazer
+	revision := models.NewVolunteerRevision(volunteer, 1, "")
+	if err := s.revisionRepo.Create(ctx, revision); err != nil && err != repositories.ErrVolunteerRevisionConflict {
+		log.Printf("erro ao gravar versão inicial do voluntário %s: %v", revision.VolunteerID, err)
+	}
+}
+
+// maxRevisionAttempts limita as tentativas de gravar uma versão quando outra
+// alteração simultânea ocupa o mesmo número
+const maxRevisionAttempts = 3
+
+// recordRevision grava o estado atual do voluntário como nova versão, com o
+// usuário autenticado da requisição. Se outra alteração gravar a mesma versão
+// antes, a última versão é relida e a gravação tentada de novo. A alteração não
+// é desfeita se a gravação falhar; o erro fica registrado no log do servidor.
+func (s *volunteerService) recordRevision(ctx context.Context, volunteer *models.Volunteer) {
+	id := volunteer.ID.Hex()
+
+	var changedBy string
+	if actor, ok := models.ActorFromContext(ctx); ok {
+		changedBy = actor.UserID
+	}
+
+	for attempt := 1; ; attempt++ {
+		version := 1
+		latest, err := s.revisionRepo.FindLatest(ctx, id)
+		switch err {
+		case nil:
+			version = latest.Version + 1
+		case repositories.ErrVolunteerRevisionNotFound:
+		default:
+			log.Printf("erro ao buscar versão do voluntário %s: %v", id, err)
+			return
+		}
+
+		err = s.revisionRepo.Create(ctx, models.NewVolunteerRevision(volunteer, version, changedBy))
+		if err == repositories.ErrVolunteerRevisionConflict && attempt < maxRevisionAttempts {
+			continue
+		}
+		if err != nil {
+			log.Printf("erro ao gravar versão do voluntário %s: %v", id, err)
+		}
+		return
+	}
+}
+
+// AddWorkshop inscreve o voluntário em uma oficina. Se a oficina estiver lotada,
+// o voluntário é colocado na fila de espera e a oficina só entra no seu histórico
+// quando ele for promovido.
+func (s *volunteerService) AddWorkshop(ctx context.Context, volunteerID string, workshopID string) (models.EnrollmentStatus, error) {
+	// O voluntário precisa existir antes de ocupar uma vaga
+	if _, err := s.repo.FindByID(ctx, volunteerID); err != nil {
+		return "", err
+	}
+
+	// A oficina precisa existir e não pode estar cancelada
+	workshop, err := s.workshopRepo.FindByID(ctx, workshopID)
+	if err != nil {
+		return "", err
+	}
+	if err := authorizeWorkshop(ctx, workshop, models.PermVolunteersWrite); err != nil {
+		return "", err
+	}
+
+	status, err := enrollVolunteer(ctx, s.repo, s.workshopRepo, volunteerID, workshop)
+	if err != nil {
+		return "", err
+	}
+
+	recordEnrollment(ctx, s.auditRepo, volunteerID, workshop.ID.Hex(), status)
+	return status, nil
 }
 
-// RemoveWorkshop remove uma oficina do histórico do voluntário
+// RemoveWorkshop remove uma oficina do histórico do voluntário, liberando a vaga
+// para o primeiro voluntário da fila de espera
 func (s *volunteerService) RemoveWorkshop(ctx context.Context, volunteerID string, workshopID string) error {
-	return s.repo.RemoveWorkshop(ctx, volunteerID, workshopID)
+	// Oficinas já removidas só saem do histórico com a permissão geral
+	workshop, err := s.workshopRepo.FindByID(ctx, workshopID)
+	if err != nil && err != repositories.ErrWorkshopNotFound {
+		return err
+	}
+	if err := authorizeWorkshop(ctx, workshop, models.PermVolunteersWrite); err != nil {
+		return err
+	}
+
+	if err := unenrollVolunteer(ctx, s.repo, s.workshopRepo, volunteerID, workshopID); err != nil {
+		return err
+	}
+
+	recordEnrollment(ctx, s.auditRepo, volunteerID, workshopID, "")
+	return nil
+}
+
+// enrollVolunteer ocupa uma vaga na oficina ou entra na fila de espera,
+// registrando a oficina no histórico do voluntário quando ele ocupa a vaga
+func enrollVolunteer(ctx context.Context, volunteerRepo repositories.VolunteerRepository, workshopRepo repositories.WorkshopRepository, volunteerID string, workshop *models.Workshop) (models.EnrollmentStatus, error) {
+	if workshop.IsCancelled() {
+		return "", ErrWorkshopCancelled
+	}
+
+	status, err := workshopRepo.Enroll(ctx, workshop.ID.Hex(), volunteerID)
+	if err != nil {
+		if err == repositories.ErrWorkshopClosed {
+			return "", ErrWorkshopCancelled
+		}
+		return "", err
+	}
+
+	if status == models.EnrollmentEnrolled {
+		if err := volunteerRepo.AddWorkshop(ctx, volunteerID, workshop.ID.Hex()); err != nil {
+			return "", err
+		}
+	}
+
+	return status, nil
+}
+
+// unenrollVolunteer libera a vaga ou o lugar na fila de espera do voluntário,
+// remove a oficina do seu histórico e promove o próximo da fila
+func unenrollVolunteer(ctx context.Context, volunteerRepo repositories.VolunteerRepository, workshopRepo repositories.WorkshopRepository, volunteerID string, workshopID string) error {
+	wasEnrolled, err := workshopRepo.Unenroll(ctx, workshopID, volunteerID)
+	if err != nil && err != repositories.ErrWorkshopNotFound {
+		return err
+	}
+
+	if err := volunteerRepo.RemoveWorkshop(ctx, volunteerID, workshopID); err != nil {
+		return err
+	}
+
+	if wasEnrolled {
+		return fillOpenSeats(ctx, workshopRepo, volunteerRepo, workshopID)
+	}
+
+	return nil
+}
+
+// recordEnrollment registra na auditoria a inscrição do voluntário na oficina,
+// ou o cancelamento dela quando o status é vazio
+func recordEnrollment(ctx context.Context, auditRepo repositories.AuditRepository, volunteerID string, workshopID string, status models.EnrollmentStatus) {
+	if status == "" {
+		enrollment := map[string]interface{}{"workshop_id": workshopID}
+		recordAudit(ctx, auditRepo, models.AuditActionUnenroll, models.AuditEntityVolunteer, volunteerID, enrollment, nil)
+		return
+	}
+
+	enrollment := map[string]interface{}{"workshop_id": workshopID, "status": string(status)}
+	recordAudit(ctx, auditRepo, models.AuditActionEnroll, models.AuditEntityVolunteer, volunteerID, nil, enrollment)
 }
@@ -0,0 +1,965 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"sort"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// pageOf recorta a página pedida de uma listagem já ordenada; limite zero retorna tudo
+func pageOf[T any](items []T, page, limit int) []T {
+	if limit <= 0 {
+		return items
+	}
+	start := 0
+	if page > 0 {
+		start = min((page-1)*limit, len(items))
+	}
+	return items[start:min(start+limit, len(items))]
+}
+
+// afterCursor reproduz o filtro por cursor do repositório: registros criados
+// antes do cursor ou no mesmo instante com _id menor
+func afterCursor(cursor *models.Cursor, volunteer *models.Volunteer) bool {
+	if !volunteer.CreatedAt.Equal(cursor.CreatedAt) {
+		return volunteer.CreatedAt.Before(cursor.CreatedAt)
+	}
+	return volunteer.ID.Hex() < cursor.ID.Hex()
+}
+
+// MockVolunteerRepository é um mock do repositório de voluntários para testes
+type MockVolunteerRepository struct {
+	volunteers map[string]*models.Volunteer
+}
+
+func NewMockVolunteerRepository() *MockVolunteerRepository {
+	return &MockVolunteerRepository{
+		volunteers: make(map[string]*models.Volunteer),
+	}
+}
+
+func (m *MockVolunteerRepository) Create(ctx context.Context, volunteer *models.Volunteer) error {
+	if volunteer.ID.IsZero() {
+		volunteer.ID = primitive.NewObjectID()
+	}
+	m.volunteers[volunteer.ID.Hex()] = volunteer
+	return nil
+}
+
+func (m *MockVolunteerRepository) FindByID(ctx context.Context, id string) (*models.Volunteer, error) {
+	volunteer, exists := m.volunteers[id]
+	if !exists || volunteer.IsDeleted() {
+		return nil, repositories.ErrVolunteerNotFound
+	}
+	return volunteer, nil
+}
+
+func (m *MockVolunteerRepository) FindDeletedByID(ctx context.Context, id string) (*models.Volunteer, error) {
+	volunteer, exists := m.volunteers[id]
+	if !exists || !volunteer.IsDeleted() {
+		return nil, repositories.ErrVolunteerNotFound
+	}
+	return volunteer, nil
+}
+
+func (m *MockVolunteerRepository) FindByEmail(ctx context.Context, email string) (*models.Volunteer, error) {
+	for _, volunteer := range m.volunteers {
+		if volunteer.Email == email {
+			return volunteer, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockVolunteerRepository) FindAll(ctx context.Context, filter repositories.VolunteerFilter) ([]*models.Volunteer, error) {
+	volunteers := m.matching(filter)
+	if filter.After != nil {
+		remaining := []*models.Volunteer{}
+		for _, volunteer := range volunteers {
+			if afterCursor(filter.After, volunteer) {
+				remaining = append(remaining, volunteer)
+			}
+		}
+		volunteers = remaining
+	}
+	if filter.Limit > 0 {
+		start := 0
+		if filter.Page > 0 && filter.After == nil {
+			start = min((filter.Page-1)*filter.Limit, len(volunteers))
+		}
+		volunteers = volunteers[start:min(start+filter.Limit, len(volunteers))]
+	}
+	return volunteers, nil
+}
+
+func (m *MockVolunteerRepository) Count(ctx context.Context, filter repositories.VolunteerFilter) (int64, error) {
+	return int64(len(m.matching(filter))), nil
+}
+
+func (m *MockVolunteerRepository) matching(filter repositories.VolunteerFilter) []*models.Volunteer {
+	volunteers := []*models.Volunteer{}
+	for _, volunteer := range m.volunteers {
+		if volunteer.IsDeleted() != filter.Deleted {
+			continue
+		}
+		if filter.IsActive != nil && volunteer.IsActive != *filter.IsActive {
+			continue
+		}
+		volunteers = append(volunteers, volunteer)
+	}
+	sort.Slice(volunteers, func(i, j int) bool {
+		if !volunteers[i].CreatedAt.Equal(volunteers[j].CreatedAt) {
+			return volunteers[i].CreatedAt.After(volunteers[j].CreatedAt)
+		}
+		return volunteers[i].ID.Hex() > volunteers[j].ID.Hex()
+	})
+	return volunteers
+}
+
+func (m *MockVolunteerRepository) FindDeletedBefore(ctx context.Context, before time.Time) ([]*models.Volunteer, error) {
+	volunteers := []*models.Volunteer{}
+	for _, volunteer := range m.volunteers {
+		if volunteer.IsDeleted() && volunteer.DeletedAt.Before(before) {
+			volunteers = append(volunteers, volunteer)
+		}
+	}
+	return volunteers, nil
+}
+
+func (m *MockVolunteerRepository) Update(ctx context.Context, id string, volunteer *models.Volunteer) error {
+	if _, exists := m.volunteers[id]; !exists {
+		return repositories.ErrVolunteerNotFound
+	}
+	m.volunteers[id] = volunteer
+	return nil
+}
+
+func (m *MockVolunteerRepository) Delete(ctx context.Context, id string, deletedBy string) error {
+	volunteer, err := m.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	volunteer.DeletedAt = &now
+	volunteer.DeletedBy = deletedBy
+	volunteer.UpdatedAt = now
+	return nil
+}
+
+func (m *MockVolunteerRepository) Restore(ctx context.Context, id string) error {
+	volunteer, err := m.FindDeletedByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	volunteer.DeletedAt = nil
+	volunteer.DeletedBy = ""
+	volunteer.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockVolunteerRepository) Purge(ctx context.Context, id string) error {
+	if _, err := m.FindDeletedByID(ctx, id); err != nil {
+		return err
+	}
+	delete(m.volunteers, id)
+	return nil
+}
+
+func (m *MockVolunteerRepository) UpdateServicePeriods(ctx context.Context, id string, periods []models.ServicePeriod) error {
+	volunteer, err := m.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	current := periods[len(periods)-1]
+	volunteer.Periods = periods
+	volunteer.IsActive = current.ExitDate == nil
+	volunteer.ExitDate = current.ExitDate
+	volunteer.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MockVolunteerRepository) AddWorkshop(ctx context.Context, volunteerID string, workshopID string) error {
+	volunteer, exists := m.volunteers[volunteerID]
+	if !exists {
+		return repositories.ErrVolunteerNotFound
+	}
+	for _, id := range volunteer.Workshops {
+		if id == workshopID {
+			return nil
+		}
+	}
+	volunteer.Workshops = append(volunteer.Workshops, workshopID)
+	return nil
+}
+
+func (m *MockVolunteerRepository) RemoveWorkshop(ctx context.Context, volunteerID string, workshopID string) error {
+	volunteer, exists := m.volunteers[volunteerID]
+	if !exists {
+		return repositories.ErrVolunteerNotFound
+	}
+	volunteer.Workshops = removeString(volunteer.Workshops, workshopID)
+	return nil
+}
+
+func (m *MockVolunteerRepository) RemoveWorkshopFromAll(ctx context.Context, workshopID string) (int64, error) {
+	var modified int64
+	for _, volunteer := range m.volunteers {
+		before := len(volunteer.Workshops)
+		volunteer.Workshops = removeString(volunteer.Workshops, workshopID)
+		if len(volunteer.Workshops) != before {
+			modified++
+		}
+	}
+	return modified, nil
+}
+
+func (m *MockVolunteerRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// MockVolunteerRevisionRepository é um mock do histórico de voluntários para testes
+type MockVolunteerRevisionRepository struct {
+	revisions []*models.VolunteerRevision
+}
+
+func NewMockVolunteerRevisionRepository() *MockVolunteerRevisionRepository {
+	return &MockVolunteerRevisionRepository{}
+}
+
+func (m *MockVolunteerRevisionRepository) Create(ctx context.Context, revision *models.VolunteerRevision) error {
+	// Simula o índice único de versão por voluntário
+	for _, existing := range m.revisions {
+		if existing.VolunteerID == revision.VolunteerID && existing.Version == revision.Version {
+			return repositories.ErrVolunteerRevisionConflict
+		}
+	}
+	revision.ID = primitive.NewObjectID()
+	m.revisions = append(m.revisions, revision)
+	return nil
+}
+
+func (m *MockVolunteerRevisionRepository) FindByVolunteer(ctx context.Context, volunteerID string, page, limit int) ([]*models.VolunteerRevision, error) {
+	revisions := []*models.VolunteerRevision{}
+	for i := len(m.revisions) - 1; i >= 0; i-- {
+		if m.revisions[i].VolunteerID == volunteerID {
+			revisions = append(revisions, m.revisions[i])
+		}
+	}
+	return pageOf(revisions, page, limit), nil
+}
+
+func (m *MockVolunteerRevisionRepository) CountByVolunteer(ctx context.Context, volunteerID string) (int64, error) {
+	revisions, _ := m.FindByVolunteer(ctx, volunteerID, 0, 0)
+	return int64(len(revisions)), nil
+}
+
+func (m *MockVolunteerRevisionRepository) FindLatest(ctx context.Context, volunteerID string) (*models.VolunteerRevision, error) {
+	return m.FindAsOf(ctx, volunteerID, time.Now().AddDate(100, 0, 0))
+}
+
+func (m *MockVolunteerRevisionRepository) FindAsOf(ctx context.Context, volunteerID string, at time.Time) (*models.VolunteerRevision, error) {
+	var found *models.VolunteerRevision
+	for _, revision := range m.revisions {
+		if revision.VolunteerID != volunteerID || revision.RecordedAt.After(at) {
+			continue
+		}
+		if found == nil || revision.Version > found.Version {
+			found = revision
+		}
+	}
+	if found == nil {
+		return nil, repositories.ErrVolunteerRevisionNotFound
+	}
+	return found, nil
+}
+
+func (m *MockVolunteerRevisionRepository) DeleteByVolunteer(ctx context.Context, volunteerID string) error {
+	revisions := []*models.VolunteerRevision{}
+	for _, revision := range m.revisions {
+		if revision.VolunteerID != volunteerID {
+			revisions = append(revisions, revision)
+		}
+	}
+	m.revisions = revisions
+	return nil
+}
+
+func (m *MockVolunteerRevisionRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// racingRevisionRepository grava uma versão concorrente com o mesmo número
+// antes da primeira gravação, como outra requisição simultânea faria
+type racingRevisionRepository struct {
+	*MockVolunteerRevisionRepository
+	raced bool
+}
+
+func (r *racingRevisionRepository) Create(ctx context.Context, revision *models.VolunteerRevision) error {
+	if !r.raced {
+		r.raced = true
+		r.MockVolunteerRevisionRepository.Create(ctx, &models.VolunteerRevision{VolunteerID: revision.VolunteerID, Version: revision.Version, RecordedAt: time.Now()})
+	}
+	return r.MockVolunteerRevisionRepository.Create(ctx, revision)
+}
+
+// MockWorkshopRepository é um mock do repositório de oficinas para testes
+type MockWorkshopRepository struct {
+	workshops map[string]*models.Workshop
+}
+
+func NewMockWorkshopRepository() *MockWorkshopRepository {
+	return &MockWorkshopRepository{
+		workshops: make(map[string]*models.Workshop),
+	}
+}
+
+func (m *MockWorkshopRepository) Create(ctx context.Context, workshop *models.Workshop) error {
+	if workshop.ID.IsZero() {
+		workshop.ID = primitive.NewObjectID()
+	}
+	m.workshops[workshop.ID.Hex()] = workshop
+	return nil
+}
+
+func (m *MockWorkshopRepository) FindByID(ctx context.Context, id string) (*models.Workshop, error) {
+	workshop, exists := m.workshops[id]
+	if !exists {
+		return nil, repositories.ErrWorkshopNotFound
+	}
+	return workshop, nil
+}
+
+func (m *MockWorkshopRepository) FindAll(ctx context.Context, filter repositories.WorkshopFilter) ([]*models.Workshop, error) {
+	workshops := []*models.Workshop{}
+	for _, workshop := range m.workshops {
+		if filter.Status != "" && workshop.Status != filter.Status {
+			continue
+		}
+		if filter.DateFrom != nil && workshop.Date.Before(*filter.DateFrom) {
+			continue
+		}
+		if filter.Volunteer != "" && !workshop.IsEnrolled(filter.Volunteer) && !workshop.IsWaitlisted(filter.Volunteer) {
+			continue
+		}
+		workshops = append(workshops, workshop)
+	}
+	sort.Slice(workshops, func(i, j int) bool { return workshops[i].Date.Before(workshops[j].Date) })
+	return pageOf(workshops, filter.Page, filter.Limit), nil
+}
+
+func (m *MockWorkshopRepository) Count(ctx context.Context, filter repositories.WorkshopFilter) (int64, error) {
+	filter.Page, filter.Limit = 0, 0
+	workshops, _ := m.FindAll(ctx, filter)
+	return int64(len(workshops)), nil
+}
+
+func (m *MockWorkshopRepository) Update(ctx context.Context, id string, workshop *models.Workshop) error {
+	if _, exists := m.workshops[id]; !exists {
+		return repositories.ErrWorkshopNotFound
+	}
+	m.workshops[id] = workshop
+	return nil
+}
+
+func (m *MockWorkshopRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := m.workshops[id]; !exists {
+		return repositories.ErrWorkshopNotFound
+	}
+	delete(m.workshops, id)
+	return nil
+}
+
+func (m *MockWorkshopRepository) Cancel(ctx context.Context, id string) error {
+	workshop, exists := m.workshops[id]
+	if !exists {
+		return repositories.ErrWorkshopNotFound
+	}
+	workshop.Status = models.WorkshopStatusCancelled
+	return nil
+}
+
+func (m *MockWorkshopRepository) Enroll(ctx context.Context, workshopID string, volunteerID string) (models.EnrollmentStatus, error) {
+	workshop, exists := m.workshops[workshopID]
+	if !exists {
+		return "", repositories.ErrWorkshopNotFound
+	}
+	switch {
+	case workshop.IsEnrolled(volunteerID):
+		return models.EnrollmentEnrolled, nil
+	case workshop.IsWaitlisted(volunteerID):
+		return models.EnrollmentWaitlisted, nil
+	case workshop.IsCancelled():
+		return "", repositories.ErrWorkshopClosed
+	case workshop.HasUnlimitedCapacity() || workshop.AvailableSeats() > 0:
+		workshop.Enrolled = append(workshop.Enrolled, volunteerID)
+		return models.EnrollmentEnrolled, nil
+	default:
+		workshop.Waitlist = append(workshop.Waitlist, volunteerID)
+		return models.EnrollmentWaitlisted, nil
+	}
+}
+
+func (m *MockWorkshopRepository) Unenroll(ctx context.Context, workshopID string, volunteerID string) (bool, error) {
+	workshop, exists := m.workshops[workshopID]
+	if !exists {
+		return false, repositories.ErrWorkshopNotFound
+	}
+	wasEnrolled := workshop.IsEnrolled(volunteerID)
+	workshop.Enrolled = removeString(workshop.Enrolled, volunteerID)
+	workshop.Waitlist = removeString(workshop.Waitlist, volunteerID)
+	return wasEnrolled, nil
+}
+
+func (m *MockWorkshopRepository) RemoveVolunteerFromAll(ctx context.Context, volunteerID string) (int64, error) {
+	var modified int64
+	for _, workshop := range m.workshops {
+		if workshop.IsEnrolled(volunteerID) || workshop.IsWaitlisted(volunteerID) {
+			workshop.Enrolled = removeString(workshop.Enrolled, volunteerID)
+			workshop.Waitlist = removeString(workshop.Waitlist, volunteerID)
+			modified++
+		}
+	}
+	return modified, nil
+}
+
+func (m *MockWorkshopRepository) PromoteFromWaitlist(ctx context.Context, workshopID string) (string, error) {
+	workshop, exists := m.workshops[workshopID]
+	if !exists {
+		return "", repositories.ErrWorkshopNotFound
+	}
+	if len(workshop.Waitlist) == 0 || (!workshop.HasUnlimitedCapacity() && workshop.AvailableSeats() == 0) {
+		return "", nil
+	}
+	next := workshop.Waitlist[0]
+	workshop.Waitlist = workshop.Waitlist[1:]
+	workshop.Enrolled = append(workshop.Enrolled, next)
+	return next, nil
+}
+
+func (m *MockWorkshopRepository) AddCoordinator(ctx context.Context, workshopID string, userID string) error {
+	workshop, exists := m.workshops[workshopID]
+	if !exists {
+		return repositories.ErrWorkshopNotFound
+	}
+	if !workshop.HasCoordinator(userID) {
+		workshop.Coordinators = append(workshop.Coordinators, userID)
+	}
+	return nil
+}
+
+func (m *MockWorkshopRepository) RemoveCoordinator(ctx context.Context, workshopID string, userID string) error {
+	workshop, exists := m.workshops[workshopID]
+	if !exists {
+		return repositories.ErrWorkshopNotFound
+	}
+	workshop.Coordinators = removeString(workshop.Coordinators, userID)
+	return nil
+}
+
+func removeString(values []string, value string) []string {
+	result := []string{}
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func newTestVolunteer(repo *MockVolunteerRepository) *models.Volunteer {
+	volunteer := models.NewVolunteerFromRequest(models.CreateVolunteerRequest{
+		Name:      "João Silva",
+		Email:     primitive.NewObjectID().Hex() + "@example.com",
+		EntryDate: time.Now().AddDate(0, -1, 0),
+	})
+	repo.Create(context.Background(), volunteer)
+	return volunteer
+}
+
+func newTestWorkshop(repo *MockWorkshopRepository, status string) *models.Workshop {
+	workshop := models.NewWorkshopFromRequest(models.CreateWorkshopRequest{
+		Title: "Lógica com Scratch",
+		Date:  time.Now().AddDate(0, 0, 7),
+	})
+	workshop.Status = status
+	repo.Create(context.Background(), workshop)
+	return workshop
+}
+
+func TestVolunteerService_AddWorkshop(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), workshopRepo, NewMockAttendanceRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	scheduled := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	cancelled := newTestWorkshop(workshopRepo, models.WorkshopStatusCancelled)
+
+	tests := []struct {
+		name        string
+		volunteerID string
+		workshopID  string
+		wantErr     error
+	}{
+		{
+			name:        "Existing workshop",
+			volunteerID: volunteer.ID.Hex(),
+			workshopID:  scheduled.ID.Hex(),
+			wantErr:     nil,
+		},
+		{
+			name:        "Unknown workshop",
+			volunteerID: volunteer.ID.Hex(),
+			workshopID:  primitive.NewObjectID().Hex(),
+			wantErr:     repositories.ErrWorkshopNotFound,
+		},
+		{
+			name:        "Garbage workshop ID",
+			volunteerID: volunteer.ID.Hex(),
+			workshopID:  "garbage",
+			wantErr:     repositories.ErrWorkshopNotFound,
+		},
+		{
+			name:        "Cancelled workshop",
+			volunteerID: volunteer.ID.Hex(),
+			workshopID:  cancelled.ID.Hex(),
+			wantErr:     ErrWorkshopCancelled,
+		},
+		{
+			name:        "Unknown volunteer",
+			volunteerID: primitive.NewObjectID().Hex(),
+			workshopID:  scheduled.ID.Hex(),
+			wantErr:     repositories.ErrVolunteerNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddWorkshop(ctx, tt.volunteerID, tt.workshopID)
+			if err != tt.wantErr {
+				t.Errorf("AddWorkshop() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if len(volunteer.Workshops) != 1 || volunteer.Workshops[0] != scheduled.ID.Hex() {
+		t.Errorf("Workshops = %v, want only %v", volunteer.Workshops, scheduled.ID.Hex())
+	}
+}
+
+func TestVolunteerService_AddWorkshopWaitlist(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), workshopRepo, NewMockAttendanceRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	workshop := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	workshop.Capacity = 1
+	workshopID := workshop.ID.Hex()
+
+	first := newTestVolunteer(volunteerRepo)
+	second := newTestVolunteer(volunteerRepo)
+	third := newTestVolunteer(volunteerRepo)
+
+	wantStatus := []models.EnrollmentStatus{models.EnrollmentEnrolled, models.EnrollmentWaitlisted, models.EnrollmentWaitlisted}
+	for i, volunteer := range []*models.Volunteer{first, second, third} {
+		status, err := service.AddWorkshop(ctx, volunteer.ID.Hex(), workshopID)
+		if err != nil {
+			t.Fatalf("AddWorkshop() error = %v", err)
+		}
+		if status != wantStatus[i] {
+			t.Errorf("AddWorkshop() status = %v, want %v", status, wantStatus[i])
+		}
+	}
+
+	// Apenas o voluntário com vaga tem a oficina no histórico
+	if len(first.Workshops) != 1 || len(second.Workshops) != 0 || len(third.Workshops) != 0 {
+		t.Fatalf("Workshops = %v, %v, %v; only the first volunteer should hold the workshop", first.Workshops, second.Workshops, third.Workshops)
+	}
+
+	// Ao liberar a vaga, o primeiro da fila é promovido
+	if err := service.RemoveWorkshop(ctx, first.ID.Hex(), workshopID); err != nil {
+		t.Fatalf("RemoveWorkshop() error = %v", err)
+	}
+
+	if len(first.Workshops) != 0 {
+		t.Errorf("RemoveWorkshop() didn't remove the workshop from the volunteer")
+	}
+	if !workshop.IsEnrolled(second.ID.Hex()) || len(second.Workshops) != 1 {
+		t.Errorf("RemoveWorkshop() didn't promote the first waitlisted volunteer")
+	}
+	if !workshop.IsWaitlisted(third.ID.Hex()) || len(workshop.Waitlist) != 1 {
+		t.Errorf("Waitlist = %v, want only %v", workshop.Waitlist, third.ID.Hex())
+	}
+
+	// Remover alguém da fila de espera não promove ninguém
+	if err := service.RemoveWorkshop(ctx, third.ID.Hex(), workshopID); err != nil {
+		t.Fatalf("RemoveWorkshop() error = %v", err)
+	}
+	if len(workshop.Enrolled) != 1 || len(workshop.Waitlist) != 0 {
+		t.Errorf("Enrolled = %v, Waitlist = %v after removing waitlisted volunteer", workshop.Enrolled, workshop.Waitlist)
+	}
+}
+
+func TestVolunteerService_HistoryAsOf(t *testing.T) {
+	volunteerRepo := NewMockVolunteerRepository()
+	revisionRepo := NewMockVolunteerRevisionRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	service := NewVolunteerService(volunteerRepo, revisionRepo, NewMockWorkshopRepository(), attendanceRepo, NewMockUserRepository(), NewMockAuditRepository())
+
+	actor := &models.Actor{UserID: primitive.NewObjectID().Hex(), Role: models.RoleAdmin}
+	ctx := models.WithActor(context.Background(), actor)
+
+	created, err := service.Create(ctx, models.CreateVolunteerRequest{
+		Name:      "Ana Lima",
+		Email:     "ana@example.com",
+		Course:    "Engenharia",
+		EntryDate: time.Now().AddDate(0, -6, 0),
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	id := created.ID.Hex()
+
+	// A primeira versão passa a valer há uma hora
+	hourAgo := time.Now().Add(-time.Hour)
+	revisionRepo.revisions[0].RecordedAt = hourAgo
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: id, Date: hourAgo, Hours: 2})
+
+	// As inscrições não geram versões e ficam de fora da consulta por data
+	stored, _ := volunteerRepo.FindByID(ctx, id)
+	stored.Workshops = []string{primitive.NewObjectID().Hex()}
+
+	if _, err := service.Update(ctx, id, models.UpdateVolunteerRequest{Course: "Computação", Email: "ana.lima@example.com"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: id, Date: time.Now(), Hours: 3})
+
+	page, err := service.History(ctx, id, 0, 0)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	history := page.Items
+	if len(history) != 2 || history[0].Version != 2 || history[1].Version != 1 {
+		t.Fatalf("History() = %+v, want versions 2 and 1", history)
+	}
+	if history[0].ChangedBy != actor.UserID {
+		t.Errorf("History()[0].ChangedBy = %q, want %q", history[0].ChangedBy, actor.UserID)
+	}
+
+	// O histórico é paginado como as demais listagens
+	second, _ := service.History(ctx, id, 2, 1)
+	if second.Total != 2 || second.TotalPages != 2 || len(second.Items) != 1 || second.Items[0].Version != 1 {
+		t.Errorf("History() page 2 = %+v, want version 1 of 2", second)
+	}
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		wantCourse string
+		wantEmail  string
+		wantHours  float64
+		wantErr    error
+	}{
+		{"Before creation", hourAgo.Add(-time.Minute), "", "", 0, repositories.ErrVolunteerRevisionNotFound},
+		{"First version", hourAgo.Add(time.Minute), "Engenharia", "ana@example.com", 2, nil},
+		{"Current version", time.Now().Add(time.Minute), "Computação", "ana.lima@example.com", 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volunteer, err := service.GetAsOf(ctx, id, tt.at)
+			if err != tt.wantErr {
+				t.Fatalf("GetAsOf() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if volunteer.Course != tt.wantCourse || volunteer.Email != tt.wantEmail || volunteer.TotalHours != tt.wantHours {
+				t.Errorf("GetAsOf() = %s, %s, %v; want %s, %s, %v", volunteer.Course, volunteer.Email, volunteer.TotalHours, tt.wantCourse, tt.wantEmail, tt.wantHours)
+			}
+			if volunteer.Workshops != nil {
+				t.Errorf("GetAsOf().Workshops = %v, want nil", volunteer.Workshops)
+			}
+		})
+	}
+
+	// Voluntários cadastrados antes do histórico guardam a versão anterior na primeira alteração
+	legacy := newTestVolunteer(volunteerRepo)
+	if _, err := service.Update(ctx, legacy.ID.Hex(), models.UpdateVolunteerRequest{Phone: "43999990000"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	legacyPage, _ := service.History(ctx, legacy.ID.Hex(), 0, 0)
+	legacyHistory := legacyPage.Items
+	if len(legacyHistory) != 2 || legacyHistory[1].Volunteer.Phone != "" || legacyHistory[1].ChangedBy != "" {
+		t.Errorf("History() of legacy volunteer = %+v, want baseline and update", legacyHistory)
+	}
+}
+
+func TestVolunteerService_ConcurrentRevision(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	revisionRepo := &racingRevisionRepository{MockVolunteerRevisionRepository: NewMockVolunteerRevisionRepository()}
+	service := NewVolunteerService(volunteerRepo, revisionRepo, NewMockWorkshopRepository(), NewMockAttendanceRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	id := volunteer.ID.Hex()
+	revisionRepo.MockVolunteerRevisionRepository.Create(ctx, models.NewVolunteerRevision(volunteer, 1, ""))
+
+	// A versão 2 é gravada por outra alteração entre a leitura e a gravação
+	if _, err := service.Update(ctx, id, models.UpdateVolunteerRequest{Phone: "43999990000"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	page, _ := service.History(ctx, id, 0, 0)
+	history := page.Items
+	if len(history) != 3 || history[0].Version != 3 || history[0].Volunteer.Phone != "43999990000" {
+		t.Errorf("History() = %+v, want the update recorded as version 3", history)
+	}
+}
+
+func TestVolunteerService_TrashRestorePurge(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	revisionRepo := NewMockVolunteerRevisionRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	userRepo := NewMockUserRepository()
+	service := NewVolunteerService(volunteerRepo, revisionRepo, workshopRepo, attendanceRepo, userRepo, NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	id := volunteer.ID.Hex()
+	deletedBy := primitive.NewObjectID().Hex()
+	other := newTestVolunteer(volunteerRepo)
+
+	// Oficinas futuras liberam a vaga; as já realizadas continuam no histórico
+	upcoming := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	past := newTestWorkshop(workshopRepo, models.WorkshopStatusCompleted)
+	past.Date = time.Now().AddDate(0, 0, -7)
+	for _, workshop := range []*models.Workshop{upcoming, past} {
+		if _, err := enrollVolunteer(ctx, volunteerRepo, workshopRepo, id, workshop); err != nil {
+			t.Fatalf("enrollVolunteer() error = %v", err)
+		}
+	}
+
+	// Referências que precisam sumir na exclusão definitiva
+	past.Waitlist = append(past.Waitlist, id)
+	user := newTestUser(userRepo, "voluntario@example.com", models.RoleVolunteer)
+	userRepo.SetVolunteer(ctx, user.ID.Hex(), id)
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: id, WorkshopID: past.ID.Hex(), Hours: 2})
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: other.ID.Hex(), WorkshopID: past.ID.Hex(), Hours: 2})
+
+	if err := service.Purge(ctx, id); err != ErrVolunteerNotInTrash {
+		t.Errorf("Purge() outside trash error = %v, want %v", err, ErrVolunteerNotInTrash)
+	}
+
+	if err := service.Delete(ctx, id, deletedBy); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if volunteer.DeletedAt == nil || volunteer.DeletedBy != deletedBy {
+		t.Errorf("Delete() deleted_at = %v, deleted_by = %q", volunteer.DeletedAt, volunteer.DeletedBy)
+	}
+	if upcoming.IsEnrolled(id) || !past.IsEnrolled(id) {
+		t.Errorf("Delete() should release only upcoming workshops: upcoming = %v, past = %v", upcoming.Enrolled, past.Enrolled)
+	}
+
+	if _, err := service.GetByID(ctx, id); err != repositories.ErrVolunteerNotFound {
+		t.Errorf("GetByID() in trash error = %v, want %v", err, repositories.ErrVolunteerNotFound)
+	}
+	active, _ := service.GetAll(ctx, repositories.VolunteerFilter{})
+	trash, _ := service.GetAll(ctx, repositories.VolunteerFilter{Deleted: true})
+	if active.Total != 1 || trash.Total != 1 {
+		t.Errorf("GetAll() = %d active, %d in trash; want 1 and 1", active.Total, trash.Total)
+	}
+
+	// O email continua reservado enquanto o voluntário está na lixeira
+	_, err := service.Create(ctx, models.CreateVolunteerRequest{Name: "Outro", Email: volunteer.Email, EntryDate: time.Now().AddDate(0, -1, 0)})
+	if err != ErrVolunteerEmailInTrash {
+		t.Errorf("Create() with trashed email error = %v, want %v", err, ErrVolunteerEmailInTrash)
+	}
+
+	restored, err := service.Restore(ctx, id)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.DeletedAt != nil || restored.DeletedBy != "" {
+		t.Errorf("Restore() = %+v, want no deletion fields", restored)
+	}
+	if _, err := service.Restore(ctx, id); err != repositories.ErrVolunteerNotFound {
+		t.Errorf("Restore() outside trash error = %v, want %v", err, repositories.ErrVolunteerNotFound)
+	}
+
+	// A limpeza automática respeita o prazo de retenção
+	if err := service.Delete(ctx, id, deletedBy); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if purged, err := service.PurgeTrash(ctx, time.Now().AddDate(0, 0, -30)); err != nil || purged != 0 {
+		t.Errorf("PurgeTrash() before retention = %d, %v; want 0", purged, err)
+	}
+	if purged, err := service.PurgeTrash(ctx, time.Now().Add(time.Minute)); err != nil || purged != 1 {
+		t.Errorf("PurgeTrash() after retention = %d, %v; want 1", purged, err)
+	}
+	if _, err := volunteerRepo.FindDeletedByID(ctx, id); err != repositories.ErrVolunteerNotFound {
+		t.Errorf("volunteer still stored after purge")
+	}
+	if history, _ := revisionRepo.FindByVolunteer(ctx, id, 0, 0); len(history) != 0 {
+		t.Errorf("revisions after purge = %d, want 0", len(history))
+	}
+	if past.IsEnrolled(id) || past.IsWaitlisted(id) {
+		t.Errorf("past workshop after purge: enrolled = %v, waitlist = %v", past.Enrolled, past.Waitlist)
+	}
+	if user.VolunteerID != "" {
+		t.Errorf("user volunteer_id after purge = %q, want empty", user.VolunteerID)
+	}
+	if records, _ := attendanceRepo.FindAll(ctx, repositories.AttendanceFilter{VolunteerID: id}); len(records) != 0 {
+		t.Errorf("attendance after purge = %d, want 0", len(records))
+	}
+	if records, _ := attendanceRepo.FindAll(ctx, repositories.AttendanceFilter{VolunteerID: other.ID.Hex()}); len(records) != 1 {
+		t.Errorf("attendance of other volunteer after purge = %d, want 1", len(records))
+	}
+}
+
+func TestVolunteerService_ServicePeriods(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), NewMockWorkshopRepository(), attendanceRepo, NewMockUserRepository(), NewMockAuditRepository())
+	certificateService, _ := newTestCertificateService(volunteerRepo, NewMockWorkshopRepository(), attendanceRepo)
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	id := volunteer.ID.Hex()
+	firstExit := volunteer.EntryDate.AddDate(0, 0, 10)
+	secondEntry := firstExit.AddDate(0, 0, 5)
+
+	if _, err := service.Reactivate(ctx, id, models.ReactivateVolunteerRequest{EntryDate: time.Now()}); err != models.ErrVolunteerAlreadyActive {
+		t.Errorf("Reactivate() active volunteer error = %v, want %v", err, models.ErrVolunteerAlreadyActive)
+	}
+
+	if _, err := service.Inactivate(ctx, id, models.InactivateVolunteerRequest{ExitDate: firstExit}); err != nil {
+		t.Fatalf("Inactivate() error = %v", err)
+	}
+	if _, err := service.Inactivate(ctx, id, models.InactivateVolunteerRequest{ExitDate: firstExit}); err != models.ErrVolunteerAlreadyInactive {
+		t.Errorf("Inactivate() inactive volunteer error = %v, want %v", err, models.ErrVolunteerAlreadyInactive)
+	}
+
+	// O retorno não pode começar antes da última saída
+	if _, err := service.Reactivate(ctx, id, models.ReactivateVolunteerRequest{EntryDate: firstExit.AddDate(0, 0, -1)}); err == nil {
+		t.Error("Reactivate() before last exit should fail")
+	}
+
+	reactivated, err := service.Reactivate(ctx, id, models.ReactivateVolunteerRequest{EntryDate: secondEntry})
+	if err != nil {
+		t.Fatalf("Reactivate() error = %v", err)
+	}
+	if !reactivated.IsActive || reactivated.ExitDate != nil {
+		t.Errorf("Reactivate() is_active = %v, exit_date = %v; want active without exit date", reactivated.IsActive, reactivated.ExitDate)
+	}
+	if len(reactivated.Periods) != 2 || reactivated.Periods[0].ExitDate == nil || !reactivated.Periods[1].EntryDate.Equal(secondEntry) {
+		t.Fatalf("Reactivate() periods = %+v, want closed first period and open second period", reactivated.Periods)
+	}
+	if !reactivated.EntryDate.Equal(volunteer.Periods[0].EntryDate) {
+		t.Errorf("Reactivate() entry_date = %v, want first entry %v", reactivated.EntryDate, volunteer.Periods[0].EntryDate)
+	}
+
+	// Horas e certificado consideram todos os períodos
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: id, Date: firstExit.AddDate(0, 0, -1), Hours: 2})
+	attendanceRepo.Create(ctx, &models.Attendance{VolunteerID: id, Date: time.Now(), Hours: 3})
+
+	response, err := service.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if response.TotalHours != 5 {
+		t.Errorf("GetByID() total hours = %v, want 5", response.TotalHours)
+	}
+
+	certificate, _, err := certificateService.Generate(ctx, id, "admin")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if certificate.TotalHours != 5 || len(certificate.Periods) != 2 {
+		t.Errorf("Generate() = %v hours in %d periods, want 5 hours in 2 periods", certificate.TotalHours, len(certificate.Periods))
+	}
+}
+
+func TestVolunteerService_GetAllPagination(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), NewMockWorkshopRepository(), NewMockAttendanceRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	for i := 0; i < 12; i++ {
+		newTestVolunteer(volunteerRepo)
+	}
+	inactive := newTestVolunteer(volunteerRepo)
+	inactive.IsActive = false
+
+	// O total considera o filtro, não apenas a página
+	active := true
+	page, err := service.GetAll(ctx, repositories.VolunteerFilter{IsActive: &active, Page: 3, Limit: 5})
+	if err != nil {
+		t.Fatalf("GetAll() error = %v", err)
+	}
+	if page.Total != 12 || page.TotalPages != 3 || len(page.Items) != 2 {
+		t.Errorf("GetAll() total = %d, pages = %d, items = %d; want 12, 3 and 2", page.Total, page.TotalPages, len(page.Items))
+	}
+
+	page, _ = service.GetAll(ctx, repositories.VolunteerFilter{})
+	if page.Page != models.DefaultPage || page.Limit != models.DefaultLimit || len(page.Items) != models.DefaultLimit {
+		t.Errorf("GetAll() page = %d, limit = %d, items = %d; want defaults", page.Page, page.Limit, len(page.Items))
+	}
+
+	page, _ = service.GetAll(ctx, repositories.VolunteerFilter{Limit: 1000})
+	if page.Limit != models.MaxLimit || len(page.Items) != 13 {
+		t.Errorf("GetAll() limit = %d, items = %d; want %d and 13", page.Limit, len(page.Items), models.MaxLimit)
+	}
+}
+
+func TestVolunteerService_GetAllCursor(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	service := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), NewMockWorkshopRepository(), NewMockAttendanceRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	for i := 0; i < 12; i++ {
+		newTestVolunteer(volunteerRepo)
+	}
+
+	seen := map[string]bool{}
+	filter := repositories.VolunteerFilter{Limit: 5}
+	pages := 0
+	for {
+		page, err := service.GetAll(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetAll() error = %v", err)
+		}
+		pages++
+		for _, volunteer := range page.Items {
+			if seen[volunteer.ID.Hex()] {
+				t.Errorf("GetAll() returned %s twice", volunteer.ID.Hex())
+			}
+			seen[volunteer.ID.Hex()] = true
+		}
+
+		// Inserções entre as páginas não deslocam as seguintes
+		newTestVolunteer(volunteerRepo)
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor, err := models.DecodeCursor(page.NextCursor)
+		if err != nil {
+			t.Fatalf("DecodeCursor() error = %v", err)
+		}
+		filter.After = cursor
+	}
+
+	if pages != 3 || len(seen) != 12 {
+		t.Errorf("GetAll() with cursor = %d volunteers in %d pages, want 12 in 3", len(seen), pages)
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrWorkshopCancelled é retornado ao tentar operar sobre uma oficina cancelada
+	ErrWorkshopCancelled = errors.New("oficina cancelada")
+	// ErrWorkshopAlreadyCancelled é retornado ao cancelar uma oficina já cancelada
+	ErrWorkshopAlreadyCancelled = errors.New("oficina já está cancelada")
+	// ErrCapacityBelowEnrollment é retornado ao reduzir a capacidade abaixo do número de inscritos
+	ErrCapacityBelowEnrollment = errors.New("capacidade não pode ser menor que o número de inscritos")
+	// ErrForbidden é retornado quando o usuário não coordena a oficina nem tem a permissão geral
+	ErrForbidden = errors.New("acesso negado: você não coordena esta oficina")
+)
+
+// WorkshopService define a interface para o serviço de oficinas
+type WorkshopService interface {
+	Create(ctx context.Context, req models.CreateWorkshopRequest) (*models.WorkshopResponse, error)
+	GetByID(ctx context.Context, id string) (*models.WorkshopResponse, error)
+	GetAll(ctx context.Context, filter repositories.WorkshopFilter) (*models.Page[*models.WorkshopResponse], error)
+	Update(ctx context.Context, id string, req models.UpdateWorkshopRequest) (*models.WorkshopResponse, error)
+	Delete(ctx context.Context, id string) error
+	Cancel(ctx context.Context, id string) (*models.WorkshopResponse, error)
+	AddCoordinator(ctx context.Context, id string, userID string) (*models.WorkshopResponse, error)
+	RemoveCoordinator(ctx context.Context, id string, userID string) (*models.WorkshopResponse, error)
+}
+
+// workshopService implementa WorkshopService
+type workshopService struct {
+	repo          repositories.WorkshopRepository
+	volunteerRepo repositories.VolunteerRepository
+	userRepo      repositories.UserRepository
+	auditRepo     repositories.AuditRepository
+}
+
+// NewWorkshopService cria uma nova instância do serviço
+func NewWorkshopService(repo repositories.WorkshopRepository, volunteerRepo repositories.VolunteerRepository, userRepo repositories.UserRepository, auditRepo repositories.AuditRepository) WorkshopService {
+	return &workshopService{
+		repo:          repo,
+		volunteerRepo: volunteerRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// Create cria uma nova oficina
+func (s *workshopService) Create(ctx context.Context, req models.CreateWorkshopRequest) (*models.WorkshopResponse, error) {
+	workshop := models.NewWorkshopFromRequest(req)
+
+	// Validar
+	if err := workshop.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Salvar no banco
+	if err := s.repo.Create(ctx, workshop); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionCreate, models.AuditEntityWorkshop, workshop.ID.Hex(), nil, workshop)
+
+	response := workshop.ToResponse()
+	return &response, nil
+}
+
+// GetByID busca uma oficina por ID
+func (s *workshopService) GetByID(ctx context.Context, id string) (*models.WorkshopResponse, error) {
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := workshop.ToResponse()
+	return &response, nil
+}
+
+// GetAll busca uma página de oficinas com filtros
+func (s *workshopService) GetAll(ctx context.Context, filter repositories.WorkshopFilter) (*models.Page[*models.WorkshopResponse], error) {
+	filter.Page, filter.Limit = models.NormalizePagination(filter.Page, filter.Limit)
+
+	workshops, err := s.repo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.WorkshopResponse, len(workshops))
+	for i, workshop := range workshops {
+		response := workshop.ToResponse()
+		responses[i] = &response
+	}
+
+	return models.NewPage(responses, total, filter.Page, filter.Limit), nil
+}
+
+// Update atualiza uma oficina
+func (s *workshopService) Update(ctx context.Context, id string, req models.UpdateWorkshopRequest) (*models.WorkshopResponse, error) {
+	// Buscar oficina existente
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Oficinas canceladas não podem ser editadas
+	if workshop.IsCancelled() {
+		return nil, ErrWorkshopCancelled
+	}
+
+	// A capacidade não pode ficar abaixo do número de inscritos
+	if req.Capacity != nil && *req.Capacity > 0 && *req.Capacity < len(workshop.Enrolled) {
+		return nil, ErrCapacityBelowEnrollment
+	}
+
+	before := models.AuditSnapshot(workshop)
+
+	// Atualizar campos se fornecidos
+	if req.Title != "" {
+		workshop.Title = req.Title
+	}
+	if req.Description != nil {
+		workshop.Description = *req.Description
+	}
+	if req.Instructor != "" {
+		workshop.Instructor = req.Instructor
+	}
+	if !req.Date.IsZero() {
+		workshop.Date = req.Date
+	}
+	if req.Location != "" {
+		workshop.Location = req.Location
+	}
+	if req.Capacity != nil {
+		workshop.Capacity = *req.Capacity
+	}
+	if req.Status != "" {
+		workshop.Status = req.Status
+	}
+
+	workshop.UpdatedAt = time.Now()
+
+	// Validar
+	if err := workshop.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Atualizar no banco. As verificações acima usam a leitura; o repositório as
+	// repete na gravação, caso a oficina tenha mudado nesse intervalo.
+	if err := s.repo.Update(ctx, id, workshop); err != nil {
+		switch err {
+		case repositories.ErrWorkshopCancelled:
+			return nil, ErrWorkshopCancelled
+		case repositories.ErrWorkshopOverCapacity:
+			return nil, ErrCapacityBelowEnrollment
+		}
+		return nil, err
+	}
+
+	// Um aumento de capacidade pode liberar vagas para a fila de espera
+	if err := fillOpenSeats(ctx, s.repo, s.volunteerRepo, id); err != nil {
+		return nil, err
+	}
+
+	return s.auditedResponse(ctx, models.AuditActionUpdate, id, before)
+}
+
+// Delete deleta uma oficina e remove a referência a ela de todos os voluntários.
+// As referências são removidas antes da oficina: se a exclusão falhar, a
+// oficina continua existindo e a operação pode ser repetida. Os registros de
+// presença são mantidos, pois as horas continuam valendo para o voluntário;
+// oficinas removidas apenas deixam de aparecer nos certificados.
+func (s *workshopService) Delete(ctx context.Context, id string) error {
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.volunteerRepo.RemoveWorkshopFromAll(ctx, workshop.ID.Hex()); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, s.auditRepo, models.AuditActionDelete, models.AuditEntityWorkshop, id, workshop, nil)
+	return nil
+}
+
+// Cancel cancela uma oficina
+func (s *workshopService) Cancel(ctx context.Context, id string) (*models.WorkshopResponse, error) {
+	// Buscar oficina
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verificar se já está cancelada
+	if workshop.IsCancelled() {
+		return nil, ErrWorkshopAlreadyCancelled
+	}
+
+	before := models.AuditSnapshot(workshop)
+
+	// Cancelar
+	if err := s.repo.Cancel(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.auditedResponse(ctx, models.AuditActionCancel, id, before)
+}
+
+// AddCoordinator vincula um usuário à coordenação da oficina
+func (s *workshopService) AddCoordinator(ctx context.Context, id string, userID string) (*models.WorkshopResponse, error) {
+	// O usuário precisa existir antes de coordenar a oficina
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := models.AuditSnapshot(workshop)
+
+	if err := s.repo.AddCoordinator(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.auditedResponse(ctx, models.AuditActionUpdate, id, before)
+}
+
+// RemoveCoordinator desvincula um usuário da coordenação da oficina
+func (s *workshopService) RemoveCoordinator(ctx context.Context, id string, userID string) (*models.WorkshopResponse, error) {
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := models.AuditSnapshot(workshop)
+
+	if err := s.repo.RemoveCoordinator(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.auditedResponse(ctx, models.AuditActionUpdate, id, before)
+}
+
+// auditedResponse busca a oficina alterada, registra a operação na auditoria
+// e retorna a oficina atualizada
+func (s *workshopService) auditedResponse(ctx context.Context, action string, id string, before map[string]interface{}) (*models.WorkshopResponse, error) {
+	workshop, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, action, models.AuditEntityWorkshop, id, before, workshop)
+
+	response := workshop.ToResponse()
+	return &response, nil
+}
+
+// authorizeWorkshop verifica se o usuário da requisição pode alterar inscrições
+// ou presenças da oficina: basta a permissão geral informada ou, com a permissão
+// de coordenação, estar vinculado à oficina. Chamadas sem usuário no contexto
+// (tarefas internas e scripts) não são restringidas.
+func authorizeWorkshop(ctx context.Context, workshop *models.Workshop, permission string) error {
+	actor, ok := models.ActorFromContext(ctx)
+	if !ok || actor.Can(permission) {
+		return nil
+	}
+
+	if actor.Can(models.PermWorkshopsCoordinate) && workshop != nil && workshop.HasCoordinator(actor.UserID) {
+		return nil
+	}
+
+	return ErrForbidden
+}
+
+// fillOpenSeats promove voluntários da fila de espera enquanto houver vagas
+// livres, registrando a oficina no histórico de cada voluntário promovido
+func fillOpenSeats(ctx context.Context, workshopRepo repositories.WorkshopRepository, volunteerRepo repositories.VolunteerRepository, workshopID string) error {
+	for {
+		promotedID, err := workshopRepo.PromoteFromWaitlist(ctx, workshopID)
+		if err != nil {
+			return err
+		}
+		if promotedID == "" {
+			return nil
+		}
+
+		if err := volunteerRepo.AddWorkshop(ctx, promotedID, workshopID); err != nil && err != repositories.ErrVolunteerNotFound {
+			return err
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"ellp-volunter-platform/backend/internal/models"
+	"ellp-volunter-platform/backend/internal/repositories"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDeleteFailed = errors.New("falha ao excluir")
+
+// failingDeleteWorkshopRepository simula uma falha na exclusão da oficina
+type failingDeleteWorkshopRepository struct {
+	*MockWorkshopRepository
+}
+
+func (r *failingDeleteWorkshopRepository) Delete(ctx context.Context, id string) error {
+	return errDeleteFailed
+}
+
+func TestWorkshopService_DeleteCascades(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	service := NewWorkshopService(workshopRepo, volunteerRepo, NewMockUserRepository(), NewMockAuditRepository())
+
+	volunteer := newTestVolunteer(volunteerRepo)
+	deleted := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	kept := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	volunteer.Workshops = []string{deleted.ID.Hex(), kept.ID.Hex()}
+
+	// Se a exclusão falhar, a oficina continua existindo e a operação pode ser repetida
+	failing := NewWorkshopService(&failingDeleteWorkshopRepository{workshopRepo}, volunteerRepo, NewMockUserRepository(), NewMockAuditRepository())
+	if err := failing.Delete(ctx, deleted.ID.Hex()); err != errDeleteFailed {
+		t.Fatalf("Delete() error = %v, want %v", err, errDeleteFailed)
+	}
+	if _, err := workshopRepo.FindByID(ctx, deleted.ID.Hex()); err != nil {
+		t.Fatalf("failed Delete() removed the workshop: %v", err)
+	}
+
+	if err := service.Delete(ctx, deleted.ID.Hex()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := workshopRepo.FindByID(ctx, deleted.ID.Hex()); err != repositories.ErrWorkshopNotFound {
+		t.Error("Delete() didn't remove the workshop")
+	}
+
+	if len(volunteer.Workshops) != 1 || volunteer.Workshops[0] != kept.ID.Hex() {
+		t.Errorf("Workshops = %v, want only %v", volunteer.Workshops, kept.ID.Hex())
+	}
+
+	if err := service.Delete(ctx, deleted.ID.Hex()); err != repositories.ErrWorkshopNotFound {
+		t.Errorf("Delete() of missing workshop error = %v, want %v", err, repositories.ErrWorkshopNotFound)
+	}
+}
+
+func TestWorkshopService_UpdateCapacity(t *testing.T) {
+	ctx := context.Background()
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	service := NewWorkshopService(workshopRepo, volunteerRepo, NewMockUserRepository(), NewMockAuditRepository())
+
+	workshop := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	workshop.Capacity = 2
+	first := newTestVolunteer(volunteerRepo)
+	second := newTestVolunteer(volunteerRepo)
+	waiting := newTestVolunteer(volunteerRepo)
+	workshop.Enrolled = []string{first.ID.Hex(), second.ID.Hex()}
+	workshop.Waitlist = []string{waiting.ID.Hex()}
+
+	tooSmall := 1
+	if _, err := service.Update(ctx, workshop.ID.Hex(), models.UpdateWorkshopRequest{Capacity: &tooSmall}); err != ErrCapacityBelowEnrollment {
+		t.Errorf("Update() error = %v, want %v", err, ErrCapacityBelowEnrollment)
+	}
+
+	larger := 3
+	response, err := service.Update(ctx, workshop.ID.Hex(), models.UpdateWorkshopRequest{Capacity: &larger})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if len(response.Enrolled) != 3 || len(response.Waitlist) != 0 {
+		t.Errorf("Enrolled = %v, Waitlist = %v; waitlisted volunteer should be promoted", response.Enrolled, response.Waitlist)
+	}
+	if len(waiting.Workshops) != 1 {
+		t.Errorf("Promoted volunteer workshops = %v, want the workshop", waiting.Workshops)
+	}
+	if response.AvailableSeats == nil || *response.AvailableSeats != 0 {
+		t.Errorf("AvailableSeats = %v, want 0", response.AvailableSeats)
+	}
+}
+
+// concurrentWorkshopRepository simula uma operação concorrente entre a leitura
+// e a gravação: FindByID entrega uma cópia e em seguida altera a oficina gravada.
+// Update aplica as mesmas condições do filtro do repositório Mongo.
+type concurrentWorkshopRepository struct {
+	*MockWorkshopRepository
+	concurrent func(stored *models.Workshop)
+}
+
+func (r *concurrentWorkshopRepository) FindByID(ctx context.Context, id string) (*models.Workshop, error) {
+	stored, err := r.MockWorkshopRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	read := *stored
+	read.Enrolled = append([]string{}, stored.Enrolled...)
+	read.Waitlist = append([]string{}, stored.Waitlist...)
+	if r.concurrent != nil {
+		r.concurrent(stored)
+		r.concurrent = nil
+	}
+	return &read, nil
+}
+
+func (r *concurrentWorkshopRepository) Update(ctx context.Context, id string, workshop *models.Workshop) error {
+	stored, err := r.MockWorkshopRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if stored.IsCancelled() {
+		return repositories.ErrWorkshopCancelled
+	}
+	if !workshop.HasUnlimitedCapacity() && len(stored.Enrolled) > workshop.Capacity {
+		return repositories.ErrWorkshopOverCapacity
+	}
+	stored.Capacity = workshop.Capacity
+	stored.Status = workshop.Status
+	return nil
+}
+
+func TestWorkshopService_UpdateConcurrent(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockWorkshopRepository()
+	workshopRepo := &concurrentWorkshopRepository{MockWorkshopRepository: mock}
+	service := NewWorkshopService(workshopRepo, NewMockVolunteerRepository(), NewMockUserRepository(), NewMockAuditRepository())
+
+	workshop := newTestWorkshop(mock, models.WorkshopStatusScheduled)
+	workshop.Capacity = 3
+	workshop.Enrolled = []string{"a", "b"}
+
+	// Uma inscrição entre a leitura e a gravação não pode deixar a oficina acima da capacidade
+	workshopRepo.concurrent = func(stored *models.Workshop) {
+		stored.Enrolled = append(stored.Enrolled, "c")
+	}
+	capacity := 2
+	if _, err := service.Update(ctx, workshop.ID.Hex(), models.UpdateWorkshopRequest{Capacity: &capacity}); err != ErrCapacityBelowEnrollment {
+		t.Errorf("Update() with concurrent enrollment error = %v, want %v", err, ErrCapacityBelowEnrollment)
+	}
+	if workshop.Capacity != 3 {
+		t.Errorf("Capacity = %d, want 3", workshop.Capacity)
+	}
+
+	// Um cancelamento no mesmo intervalo não volta a ser agendado
+	workshopRepo.concurrent = func(stored *models.Workshop) {
+		stored.Status = models.WorkshopStatusCancelled
+	}
+	if _, err := service.Update(ctx, workshop.ID.Hex(), models.UpdateWorkshopRequest{Title: "Novo título"}); err != ErrWorkshopCancelled {
+		t.Errorf("Update() with concurrent cancel error = %v, want %v", err, ErrWorkshopCancelled)
+	}
+	if !workshop.IsCancelled() {
+		t.Errorf("Status = %s, want %s", workshop.Status, models.WorkshopStatusCancelled)
+	}
+}
+
+func TestWorkshopService_CoordinatorScope(t *testing.T) {
+	volunteerRepo := NewMockVolunteerRepository()
+	workshopRepo := NewMockWorkshopRepository()
+	attendanceRepo := NewMockAttendanceRepository()
+	userRepo := NewMockUserRepository()
+	workshopService := NewWorkshopService(workshopRepo, volunteerRepo, userRepo, NewMockAuditRepository())
+	volunteerService := NewVolunteerService(volunteerRepo, NewMockVolunteerRevisionRepository(), workshopRepo, attendanceRepo, NewMockUserRepository(), NewMockAuditRepository())
+	attendanceService := NewAttendanceService(attendanceRepo, volunteerRepo, workshopRepo, NewMockAuditRepository())
+
+	coordinator := newTestUser(userRepo, "coord@example.com", models.RoleCoordinator)
+	owned := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	other := newTestWorkshop(workshopRepo, models.WorkshopStatusScheduled)
+	volunteer := newTestVolunteer(volunteerRepo)
+
+	if _, err := workshopService.AddCoordinator(context.Background(), owned.ID.Hex(), "inexistente"); err != repositories.ErrUserNotFound {
+		t.Errorf("AddCoordinator() unknown user error = %v, want %v", err, repositories.ErrUserNotFound)
+	}
+	response, err := workshopService.AddCoordinator(context.Background(), owned.ID.Hex(), coordinator.ID.Hex())
+	if err != nil {
+		t.Fatalf("AddCoordinator() error = %v", err)
+	}
+	if len(response.Coordinators) != 1 || response.Coordinators[0] != coordinator.ID.Hex() {
+		t.Errorf("Coordinators = %v, want %v", response.Coordinators, coordinator.ID.Hex())
+	}
+
+	role, _ := models.DefaultRole(models.RoleCoordinator)
+	ctx := models.WithActor(context.Background(), &models.Actor{
+		UserID:      coordinator.ID.Hex(),
+		Role:        role.Name,
+		Permissions: role.Permissions,
+	})
+
+	// Na oficina que coordena, inscreve voluntários e lança presenças
+	if _, err := volunteerService.AddWorkshop(ctx, volunteer.ID.Hex(), owned.ID.Hex()); err != nil {
+		t.Fatalf("AddWorkshop() owned error = %v", err)
+	}
+	hours := 2.0
+	record, err := attendanceService.Record(ctx, models.RecordAttendanceRequest{
+		VolunteerID: volunteer.ID.Hex(),
+		WorkshopID:  owned.ID.Hex(),
+		Date:        time.Now(),
+		Hours:       &hours,
+	}, coordinator.ID.Hex())
+	if err != nil {
+		t.Fatalf("Record() owned error = %v", err)
+	}
+
+	// Nas demais oficinas, recebe acesso negado
+	if _, err := volunteerService.AddWorkshop(ctx, volunteer.ID.Hex(), other.ID.Hex()); err != ErrForbidden {
+		t.Errorf("AddWorkshop() other error = %v, want %v", err, ErrForbidden)
+	}
+	if len(other.Enrolled) != 0 {
+		t.Errorf("other.Enrolled = %v, want empty", other.Enrolled)
+	}
+	volunteer.Workshops = append(volunteer.Workshops, other.ID.Hex())
+	if _, err := attendanceService.CheckIn(ctx, models.CheckInRequest{VolunteerID: volunteer.ID.Hex(), WorkshopID: other.ID.Hex()}, coordinator.ID.Hex()); err != ErrForbidden {
+		t.Errorf("CheckIn() other error = %v, want %v", err, ErrForbidden)
+	}
+	if err := volunteerService.RemoveWorkshop(ctx, volunteer.ID.Hex(), other.ID.Hex()); err != ErrForbidden {
+		t.Errorf("RemoveWorkshop() other error = %v, want %v", err, ErrForbidden)
+	}
+
+	// Ao perder a coordenação, também perde o acesso aos registros da oficina
+	if _, err := workshopService.RemoveCoordinator(context.Background(), owned.ID.Hex(), coordinator.ID.Hex()); err != nil {
+		t.Fatalf("RemoveCoordinator() error = %v", err)
+	}
+	if err := attendanceService.Delete(ctx, record.ID.Hex()); err != ErrForbidden {
+		t.Errorf("Delete() after removal error = %v, want %v", err, ErrForbidden)
+	}
+	if err := volunteerService.RemoveWorkshop(ctx, volunteer.ID.Hex(), owned.ID.Hex()); err != ErrForbidden {
+		t.Errorf("RemoveWorkshop() after removal error = %v, want %v", err, ErrForbidden)
+	}
+}
@@ -0,0 +1,128 @@
+// Package totp implementa senhas de uso único baseadas em tempo (RFC 6238),
+// compatíveis com aplicativos autenticadores: HMAC-SHA1, 6 dígitos e
+// intervalos de 30 segundos.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parâmetros usados pelos aplicativos autenticadores
+const (
+	Digits = 6
+	Period = 30 // segundos
+)
+
+// secretSize é o tamanho do segredo em bytes (160 bits, recomendado pela RFC 4226)
+const secretSize = 20
+
+// ErrInvalidSecret é retornado quando o segredo não está em base32
+var ErrInvalidSecret = errors.New("segredo TOTP inválido")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret gera um novo segredo aleatório codificado em base32
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(buf), nil
+}
+
+// Step retorna o intervalo de tempo (contador) correspondente ao instante
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// Code calcula o código do instante informado
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, Step(t), Digits), nil
+}
+
+// Verify confere o código aceitando até skew intervalos antes ou depois do
+// instante informado, para tolerar diferenças de relógio. Retorna o intervalo
+// que validou o código, usado para impedir que o mesmo código seja reutilizado.
+func Verify(secret, code string, t time.Time, skew int) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for i := -skew; i <= skew; i++ {
+		step := current + int64(i)
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step, Digits)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// ProvisioningURI monta o endereço otpauth:// usado para gerar o QR code
+// lido pelos aplicativos autenticadores
+func ProvisioningURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(Period))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// hotp calcula o código HOTP (RFC 4226) do contador informado
+func hotp(key []byte, counter int64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// Truncamento dinâmico
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
+
+// decodeSecret decodifica o segredo em base32, aceitando minúsculas, espaços e padding
+func decodeSecret(secret string) ([]byte, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	normalized = strings.TrimRight(normalized, "=")
+
+	key, err := encoding.DecodeString(normalized)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Segredo dos vetores de teste da RFC 6238 (SHA-1): "12345678901234567890"
+var rfcSecret = encoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	key, _ := decodeSecret(rfcSecret)
+	for _, tt := range tests {
+		if got := hotp(key, tt.unix/Period, 8); got != tt.want {
+			t.Errorf("hotp(T=%d) = %v, want %v", tt.unix, got, tt.want)
+		}
+	}
+
+	// Com 6 dígitos, o código é o final do código de 8 dígitos
+	if got, _ := Code(rfcSecret, time.Unix(59, 0)); got != "287082" {
+		t.Errorf("Code() = %v, want 287082", got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, _ := Code(secret, now)
+
+	if step, ok := Verify(secret, code, now, 1); !ok || step != Step(now) {
+		t.Errorf("Verify() = %v, %v; want %v, true", step, ok, Step(now))
+	}
+
+	// Tolerância de um intervalo para diferenças de relógio
+	if _, ok := Verify(secret, code, now.Add(Period*time.Second), 1); !ok {
+		t.Error("Verify() should accept the previous step")
+	}
+	if _, ok := Verify(secret, code, now.Add(3*Period*time.Second), 1); ok {
+		t.Error("Verify() should reject codes outside the skew")
+	}
+
+	if _, ok := Verify(secret, "12345", now, 1); ok {
+		t.Error("Verify() should reject codes with the wrong length")
+	}
+	if _, ok := Verify("not base32!", code, now, 1); ok {
+		t.Error("Verify() should reject an invalid secret")
+	}
+
+	// Segredos digitados em minúsculas e com espaços são aceitos
+	if _, ok := Verify(strings.ToLower(secret[:4])+" "+secret[4:], code, now, 0); !ok {
+		t.Error("Verify() should normalize the secret")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("ELLP", "admin@ellp.com", "JBSWY3DPEHPK3PXP")
+
+	want := "otpauth://totp/ELLP:admin@ellp.com?algorithm=SHA1&digits=6&issuer=ELLP&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("ProvisioningURI() = %v, want %v", uri, want)
+	}
+}
@@ -16,14 +16,19 @@ import (
 )
 
 func main() {
-	client := config.ConnectDB()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := config.ConnectDB(cfg.MongoURI)
 	defer func() {
 		if err := client.Disconnect(context.Background()); err != nil {
 			log.Fatal(err)
 		}
 	}()
 
-	db := client.Database("ellp_db")
+	db := client.Database(cfg.MongoDatabase)
 
 	fmt.Println("🧹 Clearing existing data...")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -116,6 +121,8 @@ func seedWorkshops(db *mongo.Database) []primitive.ObjectID {
 			"location":    "Room 101",
 			"capacity":    30,
 			"status":      "scheduled",
+			"enrolled":    []string{},
+			"waitlist":    []string{},
 			"created_at":  time.Now(),
 			"updated_at":  time.Now(),
 		},
@@ -128,6 +135,8 @@ func seedWorkshops(db *mongo.Database) []primitive.ObjectID {
 			"location":    "Room 203",
 			"capacity":    20,
 			"status":      "scheduled",
+			"enrolled":    []string{},
+			"waitlist":    []string{},
 			"created_at":  time.Now(),
 			"updated_at":  time.Now(),
 		},
@@ -140,6 +149,8 @@ func seedWorkshops(db *mongo.Database) []primitive.ObjectID {
 			"location":    "Room 305",
 			"capacity":    25,
 			"status":      "scheduled",
+			"enrolled":    []string{},
+			"waitlist":    []string{},
 			"created_at":  time.Now(),
 			"updated_at":  time.Now(),
 		},
@@ -152,6 +163,8 @@ func seedWorkshops(db *mongo.Database) []primitive.ObjectID {
 			"location":    "Lab 401",
 			"capacity":    35,
 			"status":      "scheduled",
+			"enrolled":    []string{},
+			"waitlist":    []string{},
 			"created_at":  time.Now(),
 			"updated_at":  time.Now(),
 		},
@@ -164,6 +177,8 @@ func seedWorkshops(db *mongo.Database) []primitive.ObjectID {
 			"location":    "Room 401",
 			"capacity":    20,
 			"status":      "scheduled",
+			"enrolled":    []string{},
+			"waitlist":    []string{},
 			"created_at":  time.Now(),
 			"updated_at":  time.Now(),
 		},
@@ -324,9 +339,20 @@ func seedVolunteers(db *mongo.Database, workshopIDs []primitive.ObjectID) []mode
 	}
 
 	collection := db.Collection("volunteers")
+	workshopsCollection := db.Collection("workshops")
 	for _, volunteer := range volunteers {
 		if _, err := collection.InsertOne(ctx, volunteer); err != nil {
 			log.Printf("Error inserting volunteer: %v", err)
+			continue
+		}
+
+		// Keep the workshop enrollment lists in sync with the volunteer history
+		for _, workshopID := range volunteer.Workshops {
+			oid, _ := primitive.ObjectIDFromHex(workshopID)
+			update := bson.M{"$addToSet": bson.M{"enrolled": volunteer.ID.Hex()}}
+			if _, err := workshopsCollection.UpdateOne(ctx, bson.M{"_id": oid}, update); err != nil {
+				log.Printf("Error enrolling volunteer in workshop: %v", err)
+			}
 		}
 	}
 
@@ -4,6 +4,7 @@ import { Link, useNavigate } from 'react-router-dom';
 import Input from '../components/Input';
 import Button from '../components/Button';
 import { useAuth } from '../hooks/useAuth';
+import { authService } from '../services/auth.service';
 
 type ViewMode = 'login' | 'register';
 
@@ -21,7 +22,7 @@ interface RegisterForm {
 
 function LoginPage() {
   const navigate = useNavigate();
-  const { login, register: registerUser } = useAuth();
+  const { login, verifyMFA, register: registerUser } = useAuth();
   const [currentView, setCurrentView] = useState<ViewMode>('login');
   const [loading, setLoading] = useState(false);
   const [loginForm, setLoginForm] = useState<LoginForm>({
@@ -58,7 +59,23 @@ function LoginPage() {
 
     try {
       setLoading(true);
-      await login(loginForm.email, loginForm.password);
+      const challenge = await login(loginForm.email, loginForm.password);
+      if (challenge) {
+        if (challenge.mfa_setup_required) {
+          const setup = await authService.setupMFAChallenge(challenge.mfa_token);
+          alert(`A autenticação em dois fatores é obrigatória. Cadastre esta chave no seu aplicativo autenticador:\n\n${setup.secret}`);
+        }
+
+        const code = window.prompt('Informe o código do aplicativo autenticador ou um código de recuperação');
+        if (!code) {
+          return;
+        }
+
+        const response = await verifyMFA(challenge.mfa_token, code.trim());
+        if (response.recovery_codes?.length) {
+          alert(`Guarde seus códigos de recuperação. Eles não serão exibidos novamente:\n\n${response.recovery_codes.join('\n')}`);
+        }
+      }
       navigate('/dashboard');
     } catch (err: any) {
       console.error('Erro ao fazer login:', err);
@@ -9,6 +9,8 @@ import trashIcon from '../assets/trash-icon.svg';
 
 function VolunteersPage() {
   const [volunteers, setVolunteers] = useState<Volunteer[]>([]);
+  const [total, setTotal] = useState(0);
+  const [totalPages, setTotalPages] = useState(0);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
   const [searchTerm, setSearchTerm] = useState('');
@@ -16,17 +18,24 @@ function VolunteersPage() {
   const itemsPerPage = 5;
   const navigate = useNavigate();
 
-  // Carrega voluntários ao montar o componente
+  // Carrega a página atual de voluntários, filtrada pelo nome no backend
   useEffect(() => {
     loadVolunteers();
-  }, []);
+  }, [currentPage, searchTerm]);
 
   const loadVolunteers = async () => {
     try {
       setLoading(true);
       setError(null);
-      const data = await volunteersService.getAll({ is_active: true });
-      setVolunteers(data);
+      const data = await volunteersService.getAll({
+        is_active: true,
+        name: searchTerm || undefined,
+        page: currentPage,
+        limit: itemsPerPage,
+      });
+      setVolunteers(data.items);
+      setTotal(data.total);
+      setTotalPages(data.total_pages);
     } catch (err) {
       console.error('Erro ao carregar voluntários:', err);
       setError('Erro ao carregar voluntários. Tente novamente.');
@@ -35,13 +44,7 @@ function VolunteersPage() {
     }
   };
 
-  const filteredVolunteers = volunteers.filter(volunteer =>
-    volunteer.name.toLowerCase().includes(searchTerm.toLowerCase())
-  );
-
-  const totalPages = Math.ceil(filteredVolunteers.length / itemsPerPage);
   const startIndex = (currentPage - 1) * itemsPerPage;
-  const paginatedVolunteers = filteredVolunteers.slice(startIndex, startIndex + itemsPerPage);
 
   const handlePageChange = (page: number) => {
     setCurrentPage(page);
@@ -188,7 +191,7 @@ function VolunteersPage() {
                 </tr>
               </thead>
               <tbody className="bg-white divide-y divide-gray-200">
-                {paginatedVolunteers.map((volunteer) => (
+                {volunteers.map((volunteer) => (
                   <tr key={volunteer.id} className="hover:bg-gray-50">
                     <td className="px-4 py-4 whitespace-nowrap text-sm font-medium text-gray-900">
                       {volunteer.name}
@@ -283,10 +286,10 @@ function VolunteersPage() {
                     <span className="font-medium">{startIndex + 1}</span>
                     {' '}a{' '}
                     <span className="font-medium">
-                      {Math.min(startIndex + itemsPerPage, filteredVolunteers.length)}
+                      {Math.min(startIndex + itemsPerPage, total)}
                     </span>
                     {' '}de{' '}
-                    <span className="font-medium">{filteredVolunteers.length}</span>
+                    <span className="font-medium">{total}</span>
                     {' '}resultados
                   </p>
                 </div>
@@ -41,8 +41,10 @@ api.interceptors.response.use(
             { refresh_token: refreshToken }
           );
 
-          const { access_token } = response.data;
+          // O refresh token é rotacionado a cada uso: guarda o novo
+          const { access_token, refresh_token } = response.data;
           localStorage.setItem('access_token', access_token);
+          localStorage.setItem('refresh_token', refresh_token);
 
           // Retry requisição original com novo token
           originalRequest.headers.Authorization = `Bearer ${access_token}`;
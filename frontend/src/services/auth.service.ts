@@ -1,11 +1,25 @@
 import api from './api';
-import type { AuthResponse, LoginRequest, RegisterRequest, User } from '../types/auth.types';
+import type {
+  AuthResponse,
+  LoginRequest,
+  MFAChallengeResponse,
+  MFASetupResponse,
+  RegisterRequest,
+  UpdateProfileRequest,
+  User,
+} from '../types/auth.types';
 
 export const authService = {
   // Login
-  async login(credentials: LoginRequest): Promise<AuthResponse> {
-    const response = await api.post<AuthResponse>('/auth/login', credentials);
-    
+  // Com autenticação em dois fatores, a resposta é um desafio e os tokens
+  // só são emitidos em verifyMFA
+  async login(credentials: LoginRequest): Promise<AuthResponse | MFAChallengeResponse> {
+    const response = await api.post<AuthResponse | MFAChallengeResponse>('/auth/login', credentials);
+
+    if ('mfa_required' in response.data) {
+      return response.data;
+    }
+
     // Armazena tokens no localStorage
     localStorage.setItem('access_token', response.data.access_token);
     localStorage.setItem('refresh_token', response.data.refresh_token);
@@ -13,6 +27,27 @@ export const authService = {
     return response.data;
   },
 
+  // Conclui o login com o código do autenticador ou um código de recuperação
+  async verifyMFA(mfaToken: string, code: string): Promise<AuthResponse> {
+    const response = await api.post<AuthResponse>('/auth/mfa/verify', {
+      mfa_token: mfaToken,
+      code,
+    });
+
+    localStorage.setItem('access_token', response.data.access_token);
+    localStorage.setItem('refresh_token', response.data.refresh_token);
+
+    return response.data;
+  },
+
+  // Gera o segredo do autenticador quando a política exige MFA no login
+  async setupMFAChallenge(mfaToken: string): Promise<MFASetupResponse> {
+    const response = await api.post<MFASetupResponse>('/auth/mfa/challenge/setup', {
+      mfa_token: mfaToken,
+    });
+    return response.data;
+  },
+
   // Register
   async register(data: RegisterRequest): Promise<AuthResponse> {
     const response = await api.post<AuthResponse>('/auth/register', data);
@@ -24,10 +59,13 @@ export const authService = {
     return response.data;
   },
 
-  // Logout
+  // Logout (revoga a sessão do refresh token no servidor)
   async logout(): Promise<void> {
     try {
-      await api.post('/auth/logout');
+      const refreshToken = localStorage.getItem('refresh_token');
+      if (refreshToken) {
+        await api.post('/auth/logout', { refresh_token: refreshToken });
+      }
     } finally {
       // Remove tokens do localStorage mesmo se a requisição falhar
       localStorage.removeItem('access_token');
@@ -41,6 +79,25 @@ export const authService = {
     return response.data;
   },
 
+  // Atualiza nome e email do usuário autenticado
+  async updateProfile(data: UpdateProfileRequest): Promise<User> {
+    const response = await api.put<User>('/auth/me', data);
+    return response.data;
+  },
+
+  // Troca a senha; as outras sessões são encerradas e novos tokens são emitidos
+  async changePassword(currentPassword: string, newPassword: string): Promise<AuthResponse> {
+    const response = await api.post<AuthResponse>('/auth/me/password', {
+      current_password: currentPassword,
+      new_password: newPassword,
+    });
+
+    localStorage.setItem('access_token', response.data.access_token);
+    localStorage.setItem('refresh_token', response.data.refresh_token);
+
+    return response.data;
+  },
+
   // Refresh token
   async refreshToken(refreshToken: string): Promise<AuthResponse> {
     const response = await api.post<AuthResponse>('/auth/refresh', {
@@ -54,6 +111,16 @@ export const authService = {
     return response.data;
   },
 
+  // Solicita o email de redefinição de senha
+  async forgotPassword(email: string): Promise<void> {
+    await api.post('/auth/forgot-password', { email });
+  },
+
+  // Redefine a senha com o token recebido por email
+  async resetPassword(token: string, password: string): Promise<void> {
+    await api.post('/auth/reset-password', { token, password });
+  },
+
   // Check if user is authenticated
   isAuthenticated(): boolean {
     return !!localStorage.getItem('access_token');
@@ -1,4 +1,5 @@
 import type { Volunteer } from '../types/volunteer.types';
+import { volunteersService } from './volunteers.service';
 
 /**
  * Service for generating PDF documents
@@ -6,51 +7,24 @@ import type { Volunteer } from '../types/volunteer.types';
  */
 export const pdfService = {
   /**
-   * Generate a participation certificate for a volunteer
-   * Uses canvas-based rendering to create a professional PDF
+   * Download the participation certificate for a volunteer
+   * The PDF is generated by the backend and carries a verification code
    */
   async generateParticipationCertificate(volunteer: Volunteer): Promise<void> {
-    // Dynamically import dependencies to avoid bundling issues
-    const { jsPDF } = await import('jspdf');
-    const html2canvas = (await import('html2canvas')).default;
-
-    // Create HTML content for the certificate
-    const certificateHTML = this.createCertificateHTML(volunteer);
+    const blob = await volunteersService.downloadCertificate(volunteer.id);
 
-    // Create temporary container
-    const container = document.createElement('div');
-    container.innerHTML = certificateHTML;
-    container.style.position = 'absolute';
-    container.style.left = '-9999px';
-    container.style.width = '800px';
-    document.body.appendChild(container);
+    const url = URL.createObjectURL(blob);
+    const link = document.createElement('a');
+    link.href = url;
+    link.download = `certificado-${volunteer.name.replace(/\s+/g, '-')}.pdf`;
+    document.body.appendChild(link);
 
     try {
-      // Convert HTML to canvas
-      const canvas = await html2canvas(container, {
-        scale: 2,
-        useCORS: true,
-        backgroundColor: '#ffffff',
-      });
-
-      // Create PDF from canvas
-      const pdf = new jsPDF({
-        orientation: 'landscape',
-        unit: 'mm',
-        format: 'a4',
-      });
-
-      const imgData = canvas.toDataURL('image/png');
-      const pageWidth = pdf.internal.pageSize.getWidth();
-      const pageHeight = pdf.internal.pageSize.getHeight();
-
-      pdf.addImage(imgData, 'PNG', 0, 0, pageWidth, pageHeight);
-
-      // Download the PDF
-      pdf.save(`certificado-${volunteer.name.replace(/\s+/g, '-')}.pdf`);
+      link.click();
     } finally {
       // Clean up
-      document.body.removeChild(container);
+      document.body.removeChild(link);
+      URL.revokeObjectURL(url);
     }
   },
 
@@ -164,69 +138,6 @@ export const pdfService = {
     pdf.save(`relatorio-${volunteer.name.replace(/\s+/g, '-')}.pdf`);
   },
 
-  /**
-   * Create HTML for a professional certificate
-   */
-  createCertificateHTML(volunteer: Volunteer): string {
-    const entryDate = new Date(volunteer.entry_date).toLocaleDateString('pt-BR', {
-      year: 'numeric',
-      month: 'long',
-      day: 'numeric',
-    });
-
-    const workshopCount = volunteer.workshops?.length || 0;
-
-    return `
-      <div style="
-        width: 800px;
-        height: 600px;
-        padding: 40px;
-        background: linear-gradient(135deg, #ffffff 0%, #f5f5f5 100%);
-        font-family: 'Georgia', serif;
-        display: flex;
-        flex-direction: column;
-        justify-content: space-between;
-        border: 3px solid #1976d2;
-        box-sizing: border-box;
-      ">
-        <div style="text-align: center;">
-          <div style="font-size: 32px; font-weight: bold; color: #1976d2; margin-bottom: 10px;">
-            CERTIFICADO DE PARTICIPAÇÃO
-          </div>
-          <div style="border-bottom: 2px solid #1976d2; width: 60%; margin: 0 auto; padding: 10px 0; margin-bottom: 30px;"></div>
-        </div>
-
-        <div style="text-align: center; font-size: 18px; color: #333; line-height: 1.8;">
-          <p style="margin: 10px 0;">
-            Por este meio, certificamos que
-          </p>
-          <p style="font-size: 24px; font-weight: bold; color: #1976d2; margin: 20px 0;">
-            ${volunteer.name}
-          </p>
-          <p style="margin: 10px 0;">
-            participou como voluntário do projeto
-          </p>
-          <p style="font-size: 20px; font-weight: bold; color: #1976d2; margin: 15px 0;">
-            ELLP - Ensino Lúdico de Lógica e Programação
-          </p>
-        </div>
-
-        <div style="text-align: center; font-size: 14px; color: #666; margin: 20px 0;">
-          <p>Data de Participação: ${entryDate}</p>
-          <p>Total de Oficinas Participadas: ${workshopCount}</p>
-          <p>${volunteer.is_academic ? `Curso: ${volunteer.course || 'N/A'} | RA: ${volunteer.ra || 'N/A'}` : 'Voluntário Externo'}</p>
-        </div>
-
-        <div style="text-align: center; font-size: 12px; color: #999; margin-top: 20px;">
-          <p>
-            Documento emitido em ${new Date().toLocaleDateString('pt-BR')}<br/>
-            Projeto ELLP - Universidade Tecnológica Federal do Paraná
-          </p>
-        </div>
-      </div>
-    `;
-  },
-
   /**
    * Generate batch participation report for multiple volunteers
    */
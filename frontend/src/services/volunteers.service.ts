@@ -4,8 +4,10 @@ import type {
   CreateVolunteerRequest, 
   UpdateVolunteerRequest, 
   InactivateVolunteerRequest,
+  ReactivateVolunteerRequest,
   VolunteerFilter 
 } from '../types/volunteer.types';
+import type { PaginatedResponse } from '../types/pagination.types';
 
 export const volunteersService = {
   // Criar voluntário
@@ -20,16 +22,17 @@ export const volunteersService = {
     return response.data;
   },
 
-  // Listar todos os voluntários com filtros
-  async getAll(filters?: VolunteerFilter): Promise<Volunteer[]> {
+  // Listar voluntários com filtros, uma página por vez
+  async getAll(filters?: VolunteerFilter): Promise<PaginatedResponse<Volunteer>> {
     const params = new URLSearchParams();
     
     if (filters?.name) params.append('name', filters.name);
     if (filters?.is_active !== undefined) params.append('is_active', String(filters.is_active));
     if (filters?.page) params.append('page', String(filters.page));
     if (filters?.limit) params.append('limit', String(filters.limit));
+    if (filters?.cursor) params.append('cursor', filters.cursor);
 
-    const response = await api.get<Volunteer[]>(`/volunteers?${params.toString()}`);
+    const response = await api.get<PaginatedResponse<Volunteer>>(`/volunteers?${params.toString()}`);
     return response.data;
   },
 
@@ -50,6 +53,12 @@ export const volunteersService = {
     return response.data;
   },
 
+  // Reativar voluntário, abrindo um novo período de atuação
+  async reactivate(id: string, data: ReactivateVolunteerRequest): Promise<Volunteer> {
+    const response = await api.post<Volunteer>(`/volunteers/${id}/reactivate`, data);
+    return response.data;
+  },
+
   // Adicionar oficina ao voluntário
   async addWorkshop(volunteerId: string, workshopId: string): Promise<void> {
     await api.post(`/volunteers/${volunteerId}/workshops/${workshopId}`);
@@ -59,4 +68,12 @@ export const volunteersService = {
   async removeWorkshop(volunteerId: string, workshopId: string): Promise<void> {
     await api.delete(`/volunteers/${volunteerId}/workshops/${workshopId}`);
   },
+
+  // Emitir certificado de participação (PDF gerado pelo backend)
+  async downloadCertificate(id: string): Promise<Blob> {
+    const response = await api.get<Blob>(`/volunteers/${id}/certificate.pdf`, {
+      responseType: 'blob',
+    });
+    return response.data;
+  },
 };
@@ -1,7 +1,7 @@
 import React, { useState, useEffect, type ReactNode } from 'react';
 import { AuthContext } from './authContext';
 import { authService } from '../../services/auth.service';
-import type { User, AuthContextType } from '../../types/auth.types';
+import type { User, AuthContextType, MFAChallengeResponse } from '../../types/auth.types';
 
 interface AuthProviderProps {
   children: ReactNode;
@@ -31,9 +31,20 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
     loadUser();
   }, []);
 
-  const login = async (email: string, password: string) => {
+  // Retorna o desafio quando o login exige o segundo fator
+  const login = async (email: string, password: string): Promise<MFAChallengeResponse | null> => {
     const response = await authService.login({ email, password });
+    if ('mfa_required' in response) {
+      return response;
+    }
     setUser(response.user);
+    return null;
+  };
+
+  const verifyMFA = async (mfaToken: string, code: string) => {
+    const response = await authService.verifyMFA(mfaToken, code);
+    setUser(response.user);
+    return response;
   };
 
   const register = async (email: string, password: string, name: string) => {
@@ -50,6 +61,7 @@ export const AuthProvider: React.FC<AuthProviderProps> = ({ children }) => {
     user,
     loading,
     login,
+    verifyMFA,
     register,
     logout,
     isAuthenticated: !!user,
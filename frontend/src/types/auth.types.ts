@@ -2,7 +2,17 @@ export interface User {
   id: string;
   email: string;
   name: string;
+  role: string; // nome do papel (ex.: admin, member)
+  is_active: boolean;
+  mfa_enabled: boolean;
+  volunteer_id?: string; // voluntário vinculado, com acesso ao portal
   created_at: string;
+  updated_at: string;
+}
+
+export interface UpdateProfileRequest {
+  name?: string;
+  email?: string;
 }
 
 export interface LoginRequest {
@@ -14,12 +24,27 @@ export interface RegisterRequest {
   email: string;
   password: string;
   name: string;
+  invite_token?: string;
 }
 
 export interface AuthResponse {
   access_token: string;
   refresh_token: string;
   user: User;
+  recovery_codes?: string[];
+}
+
+// Resposta do login quando falta o segundo fator (TOTP)
+export interface MFAChallengeResponse {
+  mfa_required: true;
+  mfa_setup_required: boolean;
+  mfa_token: string;
+  expires_in: number;
+}
+
+export interface MFASetupResponse {
+  secret: string;
+  provisioning_uri: string;
 }
 
 export interface RefreshTokenRequest {
@@ -29,7 +54,8 @@ export interface RefreshTokenRequest {
 export interface AuthContextType {
   user: User | null;
   loading: boolean;
-  login: (email: string, password: string) => Promise<void>;
+  login: (email: string, password: string) => Promise<MFAChallengeResponse | null>;
+  verifyMFA: (mfaToken: string, code: string) => Promise<AuthResponse>;
   register: (email: string, password: string, name: string) => Promise<void>;
   logout: () => Promise<void>;
   isAuthenticated: boolean;
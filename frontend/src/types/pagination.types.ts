@@ -0,0 +1,11 @@
+// Envelope comum das listagens paginadas da API
+export interface PaginatedResponse<T> {
+  items: T[];
+  total: number;
+  page?: number; // ausente na paginação por cursor
+  limit: number;
+  total_pages: number;
+  next_cursor?: string; // cursor do último item, ausente na última página
+  next?: string; // link para a próxima página, ausente na última
+  prev?: string; // link para a página anterior, ausente na primeira
+}
@@ -1,3 +1,8 @@
+export interface ServicePeriod {
+  entry_date: string;
+  exit_date?: string;
+}
+
 export interface Volunteer {
   id: string;
   name: string;
@@ -9,6 +14,7 @@ export interface Volunteer {
   entry_date: string;
   exit_date?: string;
   is_active: boolean;
+  service_periods?: ServicePeriod[];
   workshops?: string[];
   created_at: string;
   updated_at: string;
@@ -39,12 +45,17 @@ export interface InactivateVolunteerRequest {
   exit_date: string;
 }
 
+export interface ReactivateVolunteerRequest {
+  entry_date: string;
+}
+
 // Filter Types
 export interface VolunteerFilter {
   name?: string;
   is_active?: boolean;
   page?: number;
   limit?: number;
+  cursor?: string; // next_cursor da página anterior; substitui page
 }
 
 // Form Types
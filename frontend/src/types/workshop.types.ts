@@ -1,3 +1,5 @@
+import type { PaginatedResponse } from './pagination.types';
+
 export interface Workshop {
   id: string;
   name: string;
@@ -13,12 +15,7 @@ export interface WorkshopResponse {
   workshop: Workshop;
 }
 
-export interface WorkshopsListResponse {
-  workshops: Workshop[];
-  total: number;
-  page: number;
-  limit: number;
-}
+export type WorkshopsListResponse = PaginatedResponse<Workshop>;
 
 // Form Types
 export interface WorkshopFormData {